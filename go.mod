@@ -1,9 +1,10 @@
 module github.com/lujin3/go-mcp-registry
 
-go 1.25
+go 1.25.0
 
 require (
 	github.com/Masterminds/semver/v3 v3.4.0
 	github.com/google/go-querystring v1.1.0
 	github.com/modelcontextprotocol/registry v1.2.3
+	gopkg.in/yaml.v3 v3.0.1
 )
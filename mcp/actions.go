@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// AnnotationLevel is the severity of a GitHub Actions annotation.
+type AnnotationLevel string
+
+// GitHub Actions annotation levels.
+const (
+	AnnotationError   AnnotationLevel = "error"
+	AnnotationWarning AnnotationLevel = "warning"
+	AnnotationNotice  AnnotationLevel = "notice"
+)
+
+// Annotation is a GitHub Actions annotation, shown on the workflow run and,
+// if File is set, on the file in pull requests.
+type Annotation struct {
+	Level   AnnotationLevel
+	Title   string
+	File    string
+	Line    int // 1-based line in File, or 0
+	Message string
+}
+
+// String returns the workflow command that creates the annotation, such as
+//
+//	::error file=server.json,title=description::description must have 1 to 100 characters
+func (a Annotation) String() string {
+	level := a.Level
+	if level == "" {
+		level = AnnotationError
+	}
+
+	var props []string
+	if a.File != "" {
+		props = append(props, "file="+escapeProperty(a.File))
+	}
+	if a.Line > 0 {
+		props = append(props, fmt.Sprintf("line=%d", a.Line))
+	}
+	if a.Title != "" {
+		props = append(props, "title="+escapeProperty(a.Title))
+	}
+
+	cmd := "::" + string(level)
+	if len(props) > 0 {
+		cmd += " " + strings.Join(props, ",")
+	}
+	return cmd + "::" + escapeData(a.Message)
+}
+
+// escapeData escapes s for the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes s for a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// ErrorAnnotations returns error annotations for err on file, which may be
+// empty. Errors joined with errors.Join, such as those of Scaffold, and the
+// field errors of an *ErrorResponse, such as a rejected publish, become one
+// annotation each, titled with the field name where known.
+func ErrorAnnotations(err error, file string) []Annotation {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var annotations []Annotation
+		for _, e := range joined.Unwrap() {
+			annotations = append(annotations, ErrorAnnotations(e, file)...)
+		}
+		return annotations
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && len(errResp.Errors) > 0 {
+		annotations := make([]Annotation, len(errResp.Errors))
+		for i, e := range errResp.Errors {
+			message := e.Message
+			if message == "" {
+				message = e.Code
+			}
+			annotations[i] = Annotation{Level: AnnotationError, Title: e.Field, File: file, Message: message}
+		}
+		return annotations
+	}
+
+	return []Annotation{{Level: AnnotationError, File: file, Message: err.Error()}}
+}
+
+// WriteAnnotations writes annotations to w, normally os.Stdout, one workflow
+// command per line.
+func WriteAnnotations(w io.Writer, annotations []Annotation) error {
+	var b strings.Builder
+	for _, a := range annotations {
+		b.WriteString(a.String() + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// AppendStepSummary appends the Markdown text to the summary of the current
+// GitHub Actions job step. Outside GitHub Actions, when GITHUB_STEP_SUMMARY
+// is not set, it does nothing.
+func AppendStepSummary(markdown string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(markdown, "\n") {
+		markdown += "\n"
+	}
+	if _, err := io.WriteString(f, markdown); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// MarkdownTable returns a Markdown table with the header row headers and
+// the given rows, for step summaries. Cell text is escaped so that pipes and
+// line breaks do not break the table.
+func MarkdownTable(headers []string, rows [][]string) string {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for i := range headers {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			b.WriteString(" " + escapeTableCell(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(headers)
+	b.WriteString("|" + strings.Repeat(" --- |", len(headers)) + "\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return b.String()
+}
+
+// escapeTableCell escapes s for a cell of a Markdown table.
+func escapeTableCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(s)
+}
+
+// Markdown returns d as a Markdown step summary: a heading naming the
+// server version and its state, followed by a table of the differences.
+func (d *ManifestDiff) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s %s\n\n", d.Name, d.Version)
+	switch {
+	case !d.Published:
+		b.WriteString("Not published in the registry.\n")
+	case len(d.Diffs) == 0:
+		b.WriteString("The registry entry matches the local server.json.\n")
+	default:
+		b.WriteString("The registry entry differs from the local server.json:\n\n")
+		rows := make([][]string, len(d.Diffs))
+		for i, diff := range d.Diffs {
+			path, change, _ := strings.Cut(diff, ": ")
+			rows[i] = []string{"`" + path + "`", change}
+		}
+		b.WriteString(MarkdownTable([]string{"Field", "Change"}, rows))
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAnnotation_String(t *testing.T) {
+	tests := []struct {
+		annotation Annotation
+		want       string
+	}{
+		{Annotation{Message: "failed"}, "::error::failed"},
+		{
+			Annotation{Level: AnnotationWarning, File: "server.json", Line: 3, Title: "a: b, c", Message: "100% wrong\nsee docs"},
+			"::warning file=server.json,line=3,title=a%3A b%2C c::100%25 wrong%0Asee docs",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.annotation.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestErrorAnnotations(t *testing.T) {
+	errResp := &ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusBadRequest, Request: &http.Request{Method: "POST"}},
+		Errors: []Error{
+			{Field: "description", Message: "too long"},
+			{Field: "version", Code: "invalid"},
+		},
+	}
+	err := errors.Join(errors.New("name is invalid"), fmt.Errorf("publish: %w", errResp))
+
+	got := ErrorAnnotations(err, "server.json")
+	want := []Annotation{
+		{Level: AnnotationError, File: "server.json", Message: "name is invalid"},
+		{Level: AnnotationError, File: "server.json", Title: "description", Message: "too long"},
+		{Level: AnnotationError, File: "server.json", Title: "version", Message: "invalid"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ErrorAnnotations = %+v, want %+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAnnotations(&buf, got[:1]); err != nil {
+		t.Fatalf("WriteAnnotations returned error: %v", err)
+	}
+	if want := "::error file=server.json::name is invalid\n"; buf.String() != want {
+		t.Errorf("WriteAnnotations wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestAppendStepSummary(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	if err := AppendStepSummary("ignored"); err != nil {
+		t.Fatalf("AppendStepSummary outside Actions returned error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+
+	diff := &ManifestDiff{
+		Name:      "com.example/weather",
+		Version:   "1.0.0",
+		Published: true,
+		Diffs:     []string{`$.description: "Weather" became "Forecasts | beta"`},
+	}
+	if err := AppendStepSummary(diff.Markdown()); err != nil {
+		t.Fatalf("AppendStepSummary returned error: %v", err)
+	}
+	if err := AppendStepSummary("Done."); err != nil {
+		t.Fatalf("AppendStepSummary returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "### com.example/weather 1.0.0\n\n" +
+		"The registry entry differs from the local server.json:\n\n" +
+		"| Field | Change |\n" +
+		"| --- | --- |\n" +
+		"| `$.description` | \"Weather\" became \"Forecasts \\| beta\" |\n" +
+		"Done.\n"
+	if string(got) != want {
+		t.Errorf("step summary =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// WithAdmin returns an Option that enables the Admin service. Moderation
+// endpoints change what every consumer of a registry sees, so they are kept
+// off the default client and must be opted into explicitly.
+//
+// Admin requests require a registry token with edit permission for the
+// affected servers, configured with WithAuthToken or ContextWithToken.
+func WithAdmin() Option {
+	return func(c *Client) error {
+		c.Admin = (*AdminService)(&c.common)
+		return nil
+	}
+}
+
+// SetStatus overrides the status of a server version and returns the
+// updated entry. The current server.json of the version is sent unchanged
+// alongside the new status, as required by the official registry server.
+//
+// The official registry server does not allow deleted versions to change
+// status again; such requests fail with an *ErrorResponse.
+func (s *AdminService) SetStatus(ctx context.Context, name, version string, status model.Status, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	return (*ServersService)(s).SetStatus(ctx, name, version, status, opts...)
+}
+
+// Takedown hides a server version from consumers by setting its status to
+// deleted. On the official registry server a takedown is permanent.
+func (s *AdminService) Takedown(ctx context.Context, name, version string, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	return s.SetStatus(ctx, name, version, model.StatusDeleted, opts...)
+}
+
+// Restore makes a server version available again by setting its status to
+// active, for example after it was deprecated. Registries that support
+// undeleting can also restore versions that were taken down.
+func (s *AdminService) Restore(ctx context.Context, name, version string, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	return s.SetStatus(ctx, name, version, model.StatusActive, opts...)
+}
+
+// AdminListOptions specifies the optional parameters to the
+// AdminService.ListAll method.
+type AdminListOptions struct {
+	ServerListOptions
+
+	// Statuses, if set, keeps only entries with one of these statuses, e.g.
+	// model.StatusDeleted to review takedowns. Filtering is done client-side.
+	Statuses []model.Status
+}
+
+// ListAll fetches all pages of registry entries, including deprecated and
+// deleted ones, with their moderation metadata. Unlike ServersService.ListAll
+// it returns the full ServerResponse of each entry, so operators can review
+// the status and publication times the registry holds for it.
+func (s *AdminService) ListAll(ctx context.Context, opts *AdminListOptions, reqOpts ...RequestOption) ([]registryv0.ServerResponse, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
+	if opts == nil {
+		opts = &AdminListOptions{}
+	}
+	var entries []registryv0.ServerResponse
+	lastResp, err := s.client.Servers.listPages(ctx, &opts.ServerListOptions, func(entry *registryv0.ServerResponse) {
+		if len(opts.Statuses) == 0 || slices.Contains(opts.Statuses, entryStatus(entry)) {
+			entries = append(entries, *entry)
+		}
+	})
+	if err != nil {
+		return entries, lastResp, err
+	}
+
+	return entries, lastResp, nil
+}
+
+// Moderation returns the moderation metadata the registry holds for a server
+// version: its status, when it was published and last updated, and whether it
+// is the latest version. It returns a *NotFoundError if the version does not
+// exist.
+func (s *AdminService) Moderation(ctx context.Context, name, version string, opts ...RequestOption) (*registryv0.RegistryExtensions, *Response, error) {
+	params := map[string]string{"serverName": name, "version": version}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersion, params, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entry *registryv0.ServerResponse
+	resp, err := s.client.Do(ctx, req, &entry, opts...)
+	if err != nil {
+		return nil, resp, serverError(err, name, version)
+	}
+	if entry == nil || entry.Meta.Official == nil {
+		return nil, resp, fmt.Errorf("registry returned no moderation metadata for server %s version %s", name, version)
+	}
+
+	return entry.Meta.Official, resp, nil
+}
+
+// entryStatus returns the status of a registry entry. Entries without
+// official metadata are reported as active.
+func entryStatus(entry *registryv0.ServerResponse) model.Status {
+	if entry.Meta.Official == nil {
+		return model.StatusActive
+	}
+	return entry.Meta.Official.Status
+}
@@ -0,0 +1,158 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestNewClient_AdminOptIn(t *testing.T) {
+	c, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if c.Admin != nil {
+		t.Error("Admin service enabled without WithAdmin")
+	}
+
+	c, err = NewClient(nil, WithAdmin())
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if c.Admin == nil {
+		t.Error("Admin service not enabled with WithAdmin")
+	}
+}
+
+func TestAdminService_Takedown(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithAdmin()(client); err != nil {
+		t.Fatalf("WithAdmin returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmail/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0","description":"Mail"},
+				"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}}`)
+		case "PUT":
+			testFormValues(t, r, values{"status": "deleted"})
+			if got, want := r.Header.Get("Authorization"), "Bearer admin-token"; got != want {
+				t.Errorf("Authorization = %q, want %q", got, want)
+			}
+
+			var body registryv0.ServerJSON
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			if body.Name != "com.example/mail" || body.Version != "1.0.0" || body.Description != "Mail" {
+				t.Errorf("request body = %+v, want current server.json", body)
+			}
+
+			fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0"},
+				"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deleted"}}}`)
+		default:
+			t.Errorf("unexpected request method %s", r.Method)
+		}
+	})
+
+	ctx := ContextWithToken(context.Background(), "admin-token")
+	updated, _, err := client.Admin.Takedown(ctx, "com.example/mail", "1.0.0")
+	if err != nil {
+		t.Fatalf("Takedown returned error: %v", err)
+	}
+	if updated.Meta.Official == nil || updated.Meta.Official.Status != model.StatusDeleted {
+		t.Errorf("Takedown Meta.Official = %+v, want status deleted", updated.Meta.Official)
+	}
+}
+
+func TestAdminService_SetStatus_Invalid(t *testing.T) {
+	client, err := NewClient(nil, WithAdmin())
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	if _, _, err := client.Admin.SetStatus(context.Background(), "com.example/mail", "1.0.0", "hidden"); err == nil {
+		t.Error("SetStatus with invalid status returned nil error")
+	}
+}
+
+func TestAdminService_ListAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithAdmin()(client); err != nil {
+		t.Fatalf("WithAdmin returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("cursor") == "" {
+			testFormValues(t, r, values{"search": "example"})
+			fmt.Fprint(w, `{"servers":[
+				{"server":{"name":"com.example/a","version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+				{"server":{"name":"com.example/b","version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deleted"}}}
+			],"metadata":{"nextCursor":"next","count":2}}`)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"com.example/c","version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}}
+		],"metadata":{"count":1}}`)
+	})
+
+	all, _, err := client.Admin.ListAll(context.Background(), &AdminListOptions{ServerListOptions: ServerListOptions{Search: "example"}})
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(all) != 3 || all[1].Meta.Official.Status != model.StatusDeleted {
+		t.Errorf("ListAll returned %+v, want all three entries with metadata", all)
+	}
+
+	opts := &AdminListOptions{
+		ServerListOptions: ServerListOptions{Search: "example"},
+		Statuses:          []model.Status{model.StatusDeleted, model.StatusDeprecated},
+	}
+	moderated, _, err := client.Admin.ListAll(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(moderated) != 2 || moderated[0].Server.Name != "com.example/b" || moderated[1].Server.Name != "com.example/c" {
+		t.Errorf("ListAll with statuses returned %+v, want b and c", moderated)
+	}
+	if opts.Cursor != "" {
+		t.Errorf("ListAll modified the caller's cursor to %q", opts.Cursor)
+	}
+}
+
+func TestAdminService_Moderation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithAdmin()(client); err != nil {
+		t.Fatalf("WithAdmin returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmail/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0"},
+			"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated","publishedAt":"2025-01-01T00:00:00Z","updatedAt":"2025-02-01T00:00:00Z","isLatest":true}}}`)
+	})
+
+	meta, _, err := client.Admin.Moderation(context.Background(), "com.example/mail", "1.0.0")
+	if err != nil {
+		t.Fatalf("Moderation returned error: %v", err)
+	}
+	if meta.Status != model.StatusDeprecated || !meta.IsLatest || meta.UpdatedAt.Month() != 2 {
+		t.Errorf("Moderation = %+v, want deprecated latest entry updated in February", meta)
+	}
+
+	_, _, err = client.Admin.Moderation(context.Background(), "com.example/missing", "1.0.0")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Moderation of missing version error = %v, want *NotFoundError", err)
+	}
+}
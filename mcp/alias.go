@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Aliases maps former server names to the names the servers were
+// republished under.
+type Aliases map[string]string
+
+// Resolve returns the current name of the server named name, following
+// chains of renames. Names without an alias are returned unchanged, and
+// cyclic aliases stop at the last name before the cycle repeats.
+func (a Aliases) Resolve(name string) string {
+	seen := map[string]bool{name: true}
+	for {
+		next, ok := a[name]
+		if !ok || seen[next] {
+			return name
+		}
+		seen[next] = true
+		name = next
+	}
+}
+
+// WithAliases returns an Option that makes version resolution follow renamed
+// servers, so long-lived pins keep resolving after a server is republished
+// under a new name. ResolveVersion and ResolveChannel look up the current
+// name of the server they are asked for with aliases.Resolve; the returned
+// server's Name reveals whether a rename was followed.
+//
+// Aliases can be maintained by hand or detected with DetectRenames.
+func WithAliases(aliases Aliases) Option {
+	return func(c *Client) error {
+		if aliases == nil {
+			return fmt.Errorf("aliases cannot be nil")
+		}
+		c.aliases = maps.Clone(aliases)
+		return nil
+	}
+}
+
+// nameHistory is the publication span of one server name.
+type nameHistory struct {
+	name         string
+	first, last  time.Time
+	hasTimestamp bool
+}
+
+// DetectRenames infers renames from registry entries, such as the result of
+// AdminService.ListAll. Servers are considered renamed when entries with
+// different names share a repository, identified by repository ID or by
+// normalized URL and subfolder, and every version of the old name was
+// published before the first version of the new one. Entries without a
+// repository or publication time are ignored.
+//
+// Monorepos publishing several servers from one repository root without
+// subfolders are only reported if their names were published one after the
+// other, which is why detected aliases should be reviewed before use.
+func DetectRenames(entries []registryv0.ServerResponse) Aliases {
+	groups := make(map[string]map[string]*nameHistory)
+	for i := range entries {
+		entry := &entries[i]
+		key := repositoryKey(&entry.Server)
+		if key == "" || entry.Meta.Official == nil || entry.Meta.Official.PublishedAt.IsZero() {
+			continue
+		}
+		published := entry.Meta.Official.PublishedAt
+
+		if groups[key] == nil {
+			groups[key] = make(map[string]*nameHistory)
+		}
+		h := groups[key][entry.Server.Name]
+		if h == nil {
+			h = &nameHistory{name: entry.Server.Name}
+			groups[key][entry.Server.Name] = h
+		}
+		if !h.hasTimestamp || published.Before(h.first) {
+			h.first = published
+		}
+		if !h.hasTimestamp || published.After(h.last) {
+			h.last = published
+		}
+		h.hasTimestamp = true
+	}
+
+	aliases := make(Aliases)
+	for _, names := range groups {
+		if len(names) < 2 {
+			continue
+		}
+
+		// Order names by first publication; each name is renamed to the
+		// next if its history ends before the next one starts
+		history := make([]*nameHistory, 0, len(names))
+		for _, h := range names {
+			history = append(history, h)
+		}
+		sort.Slice(history, func(i, j int) bool {
+			return history[i].first.Before(history[j].first)
+		})
+		for i := 0; i+1 < len(history); i++ {
+			if history[i].last.Before(history[i+1].first) {
+				aliases[history[i].name] = history[i+1].name
+			}
+		}
+	}
+
+	return aliases
+}
+
+// repositoryKey identifies the source repository of server, or returns ""
+// if it has none.
+func repositoryKey(server *registryv0.ServerJSON) string {
+	repo := server.Repository
+	if repo.ID != "" {
+		return strings.ToLower(repo.Source) + ":" + repo.ID + "/" + strings.Trim(repo.Subfolder, "/")
+	}
+	if repo.URL == "" {
+		return ""
+	}
+
+	info, err := ParseRepository(repo.URL)
+	if err != nil {
+		return ""
+	}
+	subfolder := repo.Subfolder
+	if subfolder == "" {
+		subfolder = info.Subfolder
+	}
+	return strings.ToLower(info.Host+"/"+info.Owner+"/"+info.Repo) + "/" + strings.Trim(subfolder, "/")
+}
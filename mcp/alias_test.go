@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestAliases_Resolve(t *testing.T) {
+	aliases := Aliases{
+		"com.example/old":   "com.example/newer",
+		"com.example/newer": "com.example/newest",
+		"com.example/a":     "com.example/b",
+		"com.example/b":     "com.example/a",
+	}
+
+	tests := map[string]string{
+		"com.example/old":     "com.example/newest",
+		"com.example/newest":  "com.example/newest",
+		"com.example/unknown": "com.example/unknown",
+		"com.example/a":       "com.example/b",
+	}
+	for name, want := range tests {
+		if got := aliases.Resolve(name); got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := Aliases(nil).Resolve("com.example/old"); got != "com.example/old" {
+		t.Errorf("nil Resolve = %q, want unchanged name", got)
+	}
+}
+
+func TestWithAliases_Nil(t *testing.T) {
+	if _, err := NewClient(nil, WithAliases(nil)); err == nil {
+		t.Error("WithAliases(nil) returned no error")
+	}
+}
+
+func TestDetectRenames(t *testing.T) {
+	var entries []registryv0.ServerResponse
+	err := json.Unmarshal([]byte(`[
+		{"server":{"name":"io.github.alice/weather","version":"1.0.0","repository":{"url":"https://github.com/alice/weather","source":"github"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated","publishedAt":"2025-01-01T00:00:00Z"}}},
+		{"server":{"name":"io.github.alice/weather","version":"1.1.0","repository":{"url":"https://github.com/Alice/weather.git","source":"github"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated","publishedAt":"2025-02-01T00:00:00Z"}}},
+		{"server":{"name":"com.example/weather","version":"2.0.0","repository":{"url":"https://github.com/alice/weather","source":"github"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-03-01T00:00:00Z"}}},
+		{"server":{"name":"com.example/tools-a","version":"1.0.0","repository":{"url":"https://github.com/example/tools","source":"github","subfolder":"a"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-01-01T00:00:00Z"}}},
+		{"server":{"name":"com.example/tools-b","version":"1.0.0","repository":{"url":"https://github.com/example/tools","source":"github","subfolder":"b"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-02-01T00:00:00Z"}}},
+		{"server":{"name":"com.example/one","version":"1.0.0","repository":{"url":"https://github.com/example/mono","source":"github"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-01-01T00:00:00Z"}}},
+		{"server":{"name":"com.example/two","version":"1.0.0","repository":{"url":"https://github.com/example/mono","source":"github"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-02-01T00:00:00Z"}}},
+		{"server":{"name":"com.example/one","version":"1.1.0","repository":{"url":"https://github.com/example/mono","source":"github"}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-03-01T00:00:00Z"}}},
+		{"server":{"name":"com.example/norepo","version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-01-01T00:00:00Z"}}}
+	]`), &entries)
+	if err != nil {
+		t.Fatalf("decoding entries: %v", err)
+	}
+
+	got := DetectRenames(entries)
+	want := Aliases{"io.github.alice/weather": "com.example/weather"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectRenames = %v, want %v", got, want)
+	}
+}
+
+func TestServersService_ResolveVersion_Aliases(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithAliases(Aliases{"io.github.alice/weather": "com.example/weather"})(client); err != nil {
+		t.Fatalf("WithAliases returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"com.example/weather","version":"2.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}}
+		],"metadata":{"count":1}}`)
+	})
+
+	server, _, err := client.Servers.ResolveVersion(context.Background(), "io.github.alice/weather", nil)
+	if err != nil {
+		t.Fatalf("ResolveVersion returned error: %v", err)
+	}
+	if server == nil || server.Name != "com.example/weather" || server.Version != "2.0.0" {
+		t.Errorf("ResolveVersion = %+v, want com.example/weather 2.0.0", server)
+	}
+}
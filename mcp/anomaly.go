@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// AnomalyKind identifies an unusual release pattern.
+type AnomalyKind string
+
+// Release patterns reported by VersionTimeline.Anomalies.
+const (
+	// AnomalyBurst is an unusually large number of releases in a short
+	// time, as seen when a compromised publisher token is abused.
+	AnomalyBurst AnomalyKind = "burst"
+
+	// AnomalyDormantSourceChange is a release after a long dormancy that
+	// points to a different repository or different packages, as seen
+	// when an abandoned server changes hands.
+	AnomalyDormantSourceChange AnomalyKind = "dormant-source-change"
+)
+
+// Anomaly is an unusual release pattern of a server. Anomalies are risk
+// signals for review, not proof of compromise.
+type Anomaly struct {
+	Kind AnomalyKind
+
+	// Version and PublishedAt identify the release the pattern was
+	// detected at: the first release of a burst, or the release after a
+	// dormancy.
+	Version     string
+	PublishedAt time.Time
+
+	// Detail describes the pattern for reports.
+	Detail string
+}
+
+// AnomalyOptions specifies the optional parameters to
+// VersionTimeline.Anomalies.
+type AnomalyOptions struct {
+	// BurstReleases releases within BurstWindow are reported as a burst.
+	// They default to 5 releases within 24 hours.
+	BurstReleases int
+	BurstWindow   time.Duration
+
+	// Dormancy is the gap between releases after which a change of
+	// repository or packages is reported. Defaults to 180 days.
+	Dormancy time.Duration
+}
+
+// Anomalies returns the unusual release patterns of t in order of
+// publication: bursts of releases, and releases that change the repository
+// or the packages of the server after a long dormancy. A nil opts uses the
+// defaults described by AnomalyOptions.
+func (t *VersionTimeline) Anomalies(opts *AnomalyOptions) []Anomaly {
+	o := AnomalyOptions{BurstReleases: 5, BurstWindow: 24 * time.Hour, Dormancy: 180 * 24 * time.Hour}
+	if opts != nil {
+		if opts.BurstReleases > 0 {
+			o.BurstReleases = opts.BurstReleases
+		}
+		if opts.BurstWindow > 0 {
+			o.BurstWindow = opts.BurstWindow
+		}
+		if opts.Dormancy > 0 {
+			o.Dormancy = opts.Dormancy
+		}
+	}
+
+	var anomalies []Anomaly
+	entries := t.Entries
+
+	// A burst is reported once, at its first release, and extends as long
+	// as further releases keep the window full.
+	for start := 0; start+o.BurstReleases <= len(entries); {
+		first := entries[start]
+		end := start + o.BurstReleases - 1
+		if entries[end].PublishedAt.Sub(first.PublishedAt) > o.BurstWindow {
+			start++
+			continue
+		}
+		for end+1 < len(entries) && entries[end+1].PublishedAt.Sub(entries[end+2-o.BurstReleases].PublishedAt) <= o.BurstWindow {
+			end++
+		}
+		anomalies = append(anomalies, Anomaly{
+			Kind:        AnomalyBurst,
+			Version:     first.Version,
+			PublishedAt: first.PublishedAt,
+			Detail: fmt.Sprintf("%d releases from %s to %s within %s",
+				end-start+1, first.Version, entries[end].Version, entries[end].PublishedAt.Sub(first.PublishedAt)),
+		})
+		start = end + 1
+	}
+
+	for i := 1; i < len(entries); i++ {
+		prev, next := entries[i-1], entries[i]
+		gap := next.PublishedAt.Sub(prev.PublishedAt)
+		if gap < o.Dormancy {
+			continue
+		}
+		if detail := sourceChange(prev, next); detail != "" {
+			anomalies = append(anomalies, Anomaly{
+				Kind:        AnomalyDormantSourceChange,
+				Version:     next.Version,
+				PublishedAt: next.PublishedAt,
+				Detail:      fmt.Sprintf("%s after %s without releases", detail, formatDays(gap)),
+			})
+		}
+	}
+
+	slices.SortStableFunc(anomalies, func(a, b Anomaly) int {
+		return a.PublishedAt.Compare(b.PublishedAt)
+	})
+	return anomalies
+}
+
+// sourceChange describes how next changes the repository or the packages
+// of prev, or returns "" if it does not.
+func sourceChange(prev, next TimelineEntry) string {
+	switch {
+	case prev.Repository.URL != next.Repository.URL:
+		return fmt.Sprintf("repository changed from %q to %q", prev.Repository.URL, next.Repository.URL)
+	case prev.Repository.ID != "" && next.Repository.ID != "" && prev.Repository.ID != next.Repository.ID:
+		return fmt.Sprintf("repository ID changed from %s to %s", prev.Repository.ID, next.Repository.ID)
+	case !sameElements(prev.Packages, next.Packages):
+		return fmt.Sprintf("packages changed from %v to %v", prev.Packages, next.Packages)
+	}
+	return ""
+}
+
+// sameElements reports whether a and b hold the same elements, in any
+// order.
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
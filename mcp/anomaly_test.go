@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestVersionTimeline_Anomalies(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repoA := model.Repository{URL: "https://github.com/alice/weather", Source: "github"}
+	repoB := model.Repository{URL: "https://github.com/mallory/weather", Source: "github"}
+
+	var entries []TimelineEntry
+	// Six releases within five hours
+	for i := range 6 {
+		entries = append(entries, TimelineEntry{
+			Version:     fmt.Sprintf("1.0.%d", i),
+			PublishedAt: start.Add(time.Duration(i) * time.Hour),
+			Repository:  repoA,
+			Packages:    []string{"npm:weather"},
+		})
+	}
+	entries = append(entries,
+		// Regular releases, one after a dormancy without source change
+		TimelineEntry{Version: "1.1.0", PublishedAt: start.AddDate(0, 1, 0), Repository: repoA, Packages: []string{"npm:weather"}},
+		TimelineEntry{Version: "1.2.0", PublishedAt: start.AddDate(0, 8, 0), Repository: repoA, Packages: []string{"npm:weather"}},
+		// A new repository after a dormancy
+		TimelineEntry{Version: "2.0.0", PublishedAt: start.AddDate(1, 8, 0), Repository: repoB, Packages: []string{"npm:weather"}},
+		// New packages soon after are not reported
+		TimelineEntry{Version: "2.0.1", PublishedAt: start.AddDate(1, 8, 1), Repository: repoB, Packages: []string{"npm:weather-mcp"}},
+	)
+	timeline := &VersionTimeline{Name: "com.example/weather", Entries: entries}
+
+	var got []string
+	for _, a := range timeline.Anomalies(nil) {
+		got = append(got, string(a.Kind)+" "+a.Version)
+	}
+	want := []string{"burst 1.0.0", "dormant-source-change 2.0.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Anomalies = %v, want %v", got, want)
+	}
+
+	// A larger burst threshold ignores the burst.
+	got = nil
+	for _, a := range timeline.Anomalies(&AnomalyOptions{BurstReleases: 7}) {
+		got = append(got, string(a.Kind)+" "+a.Version)
+	}
+	if want := []string{"dormant-source-change 2.0.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Anomalies with 7 burst releases = %v, want %v", got, want)
+	}
+}
+
+func TestVersionTimeline_AnomaliesBurstDetail(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeline := &VersionTimeline{Entries: []TimelineEntry{
+		{Version: "1.0.0", PublishedAt: start},
+		{Version: "1.0.1", PublishedAt: start.Add(time.Hour)},
+		{Version: "1.0.2", PublishedAt: start.Add(2 * time.Hour)},
+		{Version: "1.0.3", PublishedAt: start.Add(3 * time.Hour)},
+	}}
+
+	anomalies := timeline.Anomalies(&AnomalyOptions{BurstReleases: 2, BurstWindow: time.Hour})
+	want := []Anomaly{{
+		Kind:        AnomalyBurst,
+		Version:     "1.0.0",
+		PublishedAt: start,
+		Detail:      "4 releases from 1.0.0 to 1.0.3 within 3h0m0s",
+	}}
+	if !reflect.DeepEqual(anomalies, want) {
+		t.Errorf("Anomalies = %+v, want %+v", anomalies, want)
+	}
+}
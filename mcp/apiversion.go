@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// API versions of the registry, used as the path prefix of every route.
+const (
+	APIVersionV0  = "v0"
+	APIVersionV01 = "v0.1"
+	APIVersionV1  = "v1"
+)
+
+// apiVersions are the API versions tried by NegotiateAPIVersion, newest
+// first.
+var apiVersions = []string{APIVersionV1, APIVersionV01, APIVersionV0}
+
+// apiVersionRE matches a valid API version path prefix.
+var apiVersionRE = regexp.MustCompile(`^v[0-9]+(\.[0-9]+)?$`)
+
+// WithAPIVersion returns an Option that sets the API version path prefix of
+// every route, such as APIVersionV0 for older self-hosted registries or
+// APIVersionV1 for newer ones. Defaults to APIVersionV01. Versions without
+// a constant, such as "v2", are accepted so the client can follow the API as
+// it evolves; routes whose paths differ between versions can be adjusted
+// with WithRoutes.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) error {
+		if !apiVersionRE.MatchString(version) {
+			return fmt.Errorf("invalid API version: %q", version)
+		}
+		c.apiVersion = version
+		return nil
+	}
+}
+
+// APIVersion returns the API version path prefix of the client's routes.
+func (c *Client) APIVersion() string {
+	return c.apiVersion
+}
+
+// NegotiateAPIVersion probes the registry for the API versions in versions,
+// in order, and makes the client use the first one it serves. It returns
+// the version chosen. If versions is empty, APIVersionV1, APIVersionV01 and
+// APIVersionV0 are tried. A version is served if listing one server under
+// it succeeds; a 404 response moves on to the next version, and other errors
+// are returned.
+//
+// NegotiateAPIVersion changes the client's configuration, so it must be
+// called before the client is used by other goroutines, typically right
+// after NewClient.
+func (c *Client) NegotiateAPIVersion(ctx context.Context, versions ...string) (string, error) {
+	if len(versions) == 0 {
+		versions = apiVersions
+	}
+	for _, version := range versions {
+		if !apiVersionRE.MatchString(version) {
+			return "", fmt.Errorf("invalid API version: %q", version)
+		}
+	}
+
+	for _, version := range versions {
+		path, err := addOptions(version+"/"+strings.TrimPrefix(c.routes[RouteListServers].Path, "/"), &ListOptions{Limit: 1})
+		if err != nil {
+			return "", err
+		}
+		req, err := c.NewRequest(c.routes[RouteListServers].Method, path, nil)
+		if err != nil {
+			return "", err
+		}
+		if _, err := c.Do(ctx, req, nil); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return "", fmt.Errorf("probing API version %s: %w", version, err)
+		}
+		c.apiVersion = version
+		return version, nil
+	}
+	return "", fmt.Errorf("registry serves none of the API versions %s", strings.Join(versions, ", "))
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWithAPIVersion(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if got := client.APIVersion(); got != APIVersionV01 {
+		t.Errorf("APIVersion() = %q, want %q", got, APIVersionV01)
+	}
+
+	if err := WithAPIVersion(APIVersionV0)(client); err != nil {
+		t.Fatalf("WithAPIVersion returned error: %v", err)
+	}
+	mux.HandleFunc("/v0/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Errorf("List returned error: %v", err)
+	}
+
+	for _, version := range []string{"", "0.1", "v1/", "v1.x", "../v1"} {
+		if err := WithAPIVersion(version)(client); err == nil {
+			t.Errorf("WithAPIVersion(%q) returned no error", version)
+		}
+	}
+	if err := WithAPIVersion("v2")(client); err != nil {
+		t.Errorf("WithAPIVersion(v2) returned error: %v", err)
+	}
+}
+
+func TestClient_NegotiateAPIVersion(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var probed []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		probed = append(probed, r.URL.Path)
+		if r.URL.Path != "/v0/servers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		testFormValues(t, r, values{"limit": "1"})
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	ctx := context.Background()
+	got, err := client.NegotiateAPIVersion(ctx)
+	if err != nil {
+		t.Fatalf("NegotiateAPIVersion returned error: %v", err)
+	}
+	if got != APIVersionV0 || client.APIVersion() != APIVersionV0 {
+		t.Errorf("NegotiateAPIVersion = %q, APIVersion() = %q, want %q", got, client.APIVersion(), APIVersionV0)
+	}
+	if want := []string{"/v1/servers", "/v0.1/servers", "/v0/servers"}; !reflect.DeepEqual(probed, want) {
+		t.Errorf("probed %v, want %v", probed, want)
+	}
+
+	if _, err := client.NegotiateAPIVersion(ctx, APIVersionV1); err == nil {
+		t.Error("NegotiateAPIVersion of an unserved version returned no error")
+	}
+	if client.APIVersion() != APIVersionV0 {
+		t.Errorf("APIVersion() = %q after a failed negotiation, want %q", client.APIVersion(), APIVersionV0)
+	}
+}
+
+func TestClient_NegotiateAPIVersion_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := client.NegotiateAPIVersion(context.Background())
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("NegotiateAPIVersion error = %v, want ErrServer", err)
+	}
+	if client.APIVersion() != APIVersionV01 {
+		t.Errorf("APIVersion() = %q after an error, want %q", client.APIVersion(), APIVersionV01)
+	}
+}
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HeaderOnBehalfOf is the request header identifying the end user a request
+// is made for.
+const HeaderOnBehalfOf = "X-On-Behalf-Of"
+
+// onBehalfOfContextKey is the context key for the acting end user.
+type onBehalfOfContextKey struct{}
+
+// ContextWithOnBehalfOf returns a copy of ctx identifying subject as the end
+// user a request is made for. Requests sent by Client.Do with the returned
+// context carry subject in the X-On-Behalf-Of header, so private registry
+// operators can audit who triggered which read or write. The subject is also
+// reported to the audit hook configured with WithAuditHook.
+//
+// The header is informational: it does not change the credentials a request
+// is authenticated with.
+func ContextWithOnBehalfOf(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, onBehalfOfContextKey{}, subject)
+}
+
+// OnBehalfOfFromContext returns the subject stored in ctx by
+// ContextWithOnBehalfOf, and whether one was present.
+func OnBehalfOfFromContext(ctx context.Context) (string, bool) {
+	subject, ok := ctx.Value(onBehalfOfContextKey{}).(string)
+	return subject, ok
+}
+
+// AuditEvent describes a request sent by Client.Do.
+type AuditEvent struct {
+	// Method and URL of the request. Credentials in the URL are redacted.
+	Method string
+	URL    string
+
+	// OnBehalfOf is the end user set with ContextWithOnBehalfOf, if any.
+	OnBehalfOf string
+
+	// StatusCode is the HTTP status of the response, or 0 if no response
+	// was received.
+	StatusCode int
+
+	// Duration is the time taken by the request, including any time spent
+	// waiting for a rate budget.
+	Duration time.Duration
+
+	// Err is the error returned by Client.Do, if any.
+	Err error
+}
+
+// AuditHook is called after each request sent by Client.Do.
+type AuditHook func(AuditEvent)
+
+// WithAuditHook returns an Option that calls hook after each request, for
+// example to write an audit log of the requests made for each end user. The
+// hook is called synchronously and should return quickly.
+func WithAuditHook(hook AuditHook) Option {
+	return func(c *Client) error {
+		if hook == nil {
+			return fmt.Errorf("audit hook cannot be nil")
+		}
+		c.auditHook = hook
+		return nil
+	}
+}
+
+// setOnBehalfOf sets the X-On-Behalf-Of header of req from ctx. req must be a
+// copy owned by the caller.
+func setOnBehalfOf(ctx context.Context, req *http.Request) {
+	subject, ok := OnBehalfOfFromContext(ctx)
+	if !ok || subject == "" {
+		return
+	}
+
+	req.Header = req.Header.Clone()
+	req.Header.Set(HeaderOnBehalfOf, subject)
+}
+
+// audit reports a completed request to the client's audit hook, if any.
+func (c *Client) audit(ctx context.Context, req *http.Request, resp *Response, start time.Time, err error) {
+	if c.auditHook == nil {
+		return
+	}
+
+	event := AuditEvent{
+		Method:   req.Method,
+		URL:      sanitizeURL(req.URL).String(),
+		Duration: c.clock.Now().Sub(start),
+		Err:      err,
+	}
+	event.OnBehalfOf, _ = OnBehalfOfFromContext(ctx)
+	if resp != nil && resp.Response != nil {
+		event.StatusCode = resp.StatusCode
+	}
+
+	c.auditHook(event)
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestContextWithOnBehalfOf(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var events []AuditEvent
+	if err := WithAuditHook(func(e AuditEvent) { events = append(events, e) })(client); err != nil {
+		t.Fatalf("WithAuditHook returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get(HeaderOnBehalfOf), "alice@example.com"; got != want {
+			t.Errorf("%s = %q, want %q", HeaderOnBehalfOf, got, want)
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	ctx := ContextWithOnBehalfOf(context.Background(), "alice@example.com")
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("audit hook called %d times, want 1", len(events))
+	}
+	e := events[0]
+	if e.Method != "GET" || e.OnBehalfOf != "alice@example.com" || e.StatusCode != http.StatusOK || e.Err != nil {
+		t.Errorf("AuditEvent = %+v, want GET for alice@example.com with status 200", e)
+	}
+}
+
+func TestAuditHook_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var events []AuditEvent
+	if err := WithAuditHook(func(e AuditEvent) { events = append(events, e) })(client); err != nil {
+		t.Fatalf("WithAuditHook returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(HeaderOnBehalfOf); got != "" {
+			t.Errorf("%s = %q, want empty", HeaderOnBehalfOf, got)
+		}
+		http.Error(w, `{"title":"Forbidden"}`, http.StatusForbidden)
+	})
+
+	_, _, err := client.Servers.List(context.Background(), nil)
+	if err == nil {
+		t.Fatal("List returned nil error, want 403")
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("audit hook called %d times, want 1", len(events))
+	}
+	if e := events[0]; e.StatusCode != http.StatusForbidden || e.Err != err || e.OnBehalfOf != "" {
+		t.Errorf("AuditEvent = %+v, want status 403 with the returned error", e)
+	}
+}
+
+func TestWithAuditHook_Nil(t *testing.T) {
+	if _, err := NewClient(nil, WithAuditHook(nil)); err == nil {
+		t.Error("NewClient with nil audit hook returned nil error")
+	}
+}
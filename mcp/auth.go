@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// WithAuthToken returns an Option that authenticates every request with
+// token as a Bearer credential. Registry tokens are required for write
+// operations such as publishing, and by private registries that require
+// authentication for reads.
+//
+// A token attached to a request context with ContextWithToken takes
+// precedence over token. WithAuthToken replaces any TokenSource configured
+// with WithTokenSource.
+func WithAuthToken(token string) Option {
+	return func(c *Client) error {
+		if token == "" {
+			return fmt.Errorf("auth token cannot be empty")
+		}
+		c.tokenSource = staticTokenSource(token)
+		return nil
+	}
+}
+
+// WithAuthScheme returns an Option that sends registry tokens in header, as
+// prefix followed by the token, instead of the default
+// "Authorization: Bearer <token>". It suits private registries fronted by API
+// gateways that expect a key in a header such as X-API-Key:
+//
+//	client, err := mcp.NewClient(nil,
+//		mcp.WithAuthScheme("X-API-Key", ""),
+//		mcp.WithAuthToken(apiKey))
+//
+// The scheme applies to tokens from every source, including ContextWithToken
+// and WithRequestToken.
+func WithAuthScheme(header, prefix string) Option {
+	return func(c *Client) error {
+		if header == "" {
+			return fmt.Errorf("auth header cannot be empty")
+		}
+		c.authHeader, c.authPrefix = header, prefix
+		return nil
+	}
+}
+
+// WithAPIKey returns an Option that sends key in header with every request,
+// alongside any registry token. Use it when a gateway in front of the
+// registry requires a key of its own while the registry still expects Bearer
+// tokens for publishing.
+func WithAPIKey(header, key string) Option {
+	return func(c *Client) error {
+		if header == "" {
+			return fmt.Errorf("API key header cannot be empty")
+		}
+		if key == "" {
+			return fmt.Errorf("API key cannot be empty")
+		}
+		c.apiKeyHeader, c.apiKey = header, key
+		return nil
+	}
+}
+
+// WithBasicAuth returns an Option that sends user and password as HTTP Basic
+// credentials with every request, for registries behind a reverse proxy that
+// requires them.
+//
+// Basic credentials use the Authorization header, so they are only sent on
+// requests that are not authenticated with a registry token in that header.
+// To send both, move registry tokens to another header with WithAuthScheme.
+func WithBasicAuth(user, password string) Option {
+	return func(c *Client) error {
+		if user == "" {
+			return fmt.Errorf("basic auth user cannot be empty")
+		}
+		c.basicUser, c.basicPassword = user, password
+		return nil
+	}
+}
+
+// TokenSource supplies registry tokens. Token is called before each request,
+// so implementations that fetch tokens remotely should cache them until they
+// expire.
+//
+// An oauth2.TokenSource can be adapted with TokenSourceFunc:
+//
+//	ts := oauth2.ReuseTokenSource(nil, src)
+//	source := mcp.TokenSourceFunc(func(ctx context.Context) (string, error) {
+//		t, err := ts.Token()
+//		if err != nil {
+//			return "", err
+//		}
+//		return t.AccessToken, nil
+//	})
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshableTokenSource is a TokenSource that can replace a token the
+// registry has rejected. When a request authenticated with a token from a
+// RefreshableTokenSource fails with 401 Unauthorized, typically because the
+// token expired, Client.Do calls Refresh and resends the request once with
+// the new token, instead of returning the error to the caller.
+//
+// Requests authenticated with a context token are never retried.
+type RefreshableTokenSource interface {
+	TokenSource
+
+	// Refresh discards any cached token and returns a new one.
+	Refresh(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is an adapter to allow the use of ordinary functions as a
+// TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// staticTokenSource is a TokenSource that always returns the same token.
+type staticTokenSource string
+
+func (s staticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// WithTokenSource returns an Option that authenticates every request with a
+// Bearer token obtained from ts, so tokens can be refreshed while the client
+// is in use rather than fixed at construction time. If ts returns an error,
+// the request is not sent and Client.Do returns the error.
+//
+// A token attached to a request context with ContextWithToken takes
+// precedence over ts. WithTokenSource replaces any token configured with
+// WithAuthToken.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) error {
+		if ts == nil {
+			return fmt.Errorf("token source cannot be nil")
+		}
+		c.tokenSource = ts
+		return nil
+	}
+}
+
+// tokenContextKey is the context key for per-request tokens.
+type tokenContextKey struct{}
+
+// ContextWithToken returns a copy of ctx carrying token. Requests sent by
+// Client.Do with the returned context authenticate with token as a Bearer
+// credential, so multi-tenant services can act on behalf of a specific
+// tenant without cloning clients.
+//
+// Precedence rules:
+//
+//   - A context token overrides any credential configured on the Client.
+//   - An empty context token sends the request without credentials, even if
+//     the Client has some configured.
+//   - Transports of a custom http.Client run after Client.Do and may still
+//     replace the Authorization header.
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// TokenFromContext returns the token stored in ctx by ContextWithToken, and
+// whether one was present.
+func TokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(string)
+	return token, ok
+}
+
+// authenticate sets the Authorization header of req according to the
+// credential precedence rules documented on ContextWithToken, and reports
+// whether the token came from the client's token source. req must be a copy
+// owned by the caller.
+func (c *Client) authenticate(ctx context.Context, req *http.Request) (bool, error) {
+	token, fromSource, err := c.token(ctx)
+	if err != nil {
+		return false, err
+	}
+	// An empty context token removes credentials; otherwise an empty token
+	// leaves the request as it is
+	if _, ok := TokenFromContext(ctx); !ok && token == "" {
+		return false, nil
+	}
+
+	c.setToken(req, token)
+	return fromSource, nil
+}
+
+// token returns the token requests with ctx are authenticated with, and
+// whether it came from the client's token source rather than ctx. It returns
+// an empty token if there is none.
+func (c *Client) token(ctx context.Context) (string, bool, error) {
+	if token, ok := TokenFromContext(ctx); ok {
+		return token, false, nil
+	}
+	if c.tokenSource == nil {
+		return "", false, nil
+	}
+
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("obtaining registry token: %w", err)
+	}
+	return token, true, nil
+}
+
+// setToken sets the auth header of req to token according to the client's
+// auth scheme, or removes it if token is empty. The header is cloned first so
+// the caller's request is not modified.
+func (c *Client) setToken(req *http.Request, token string) {
+	req.Header = req.Header.Clone()
+	if token == "" {
+		req.Header.Del(c.authHeader)
+		return
+	}
+	req.Header.Set(c.authHeader, c.authPrefix+token)
+}
+
+// setAPIKey sets the API key header of req, if the client has an API key.
+// req must be a copy owned by the caller.
+func (c *Client) setAPIKey(req *http.Request) {
+	if c.apiKey == "" {
+		return
+	}
+
+	req.Header = req.Header.Clone()
+	req.Header.Set(c.apiKeyHeader, c.apiKey)
+}
+
+// setBasicAuth sets the Basic credentials of req, if the client has them and
+// req does not already carry an Authorization header. req must be a copy
+// owned by the caller.
+func (c *Client) setBasicAuth(req *http.Request) {
+	if c.basicUser == "" || req.Header.Get("Authorization") != "" {
+		return
+	}
+
+	req.Header = req.Header.Clone()
+	req.SetBasicAuth(c.basicUser, c.basicPassword)
+}
+
+// refreshAndRetry handles the result of sending req with a token from the
+// client's token source. If the registry rejected the token with 401
+// Unauthorized and the source is a RefreshableTokenSource, the token is
+// refreshed and req is sent once more. Otherwise resp and err are returned
+// unchanged, as they are if req has a body that cannot be replayed.
+func (c *Client) refreshAndRetry(ctx context.Context, req *http.Request, v any, resp *Response, err error) (*Response, error) {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	source, ok := c.tokenSource.(RefreshableTokenSource)
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+
+	token, refreshErr := source.Refresh(ctx)
+	if refreshErr != nil {
+		return resp, fmt.Errorf("refreshing registry token: %w", refreshErr)
+	}
+
+	retry := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, err
+		}
+		retry.Body = body
+	}
+	c.setToken(retry, token)
+
+	return c.send(ctx, retry, v)
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// AnonymousNamespace is the namespace anonymous tokens may publish and edit
+// servers in.
+const AnonymousNamespace = "io.modelcontextprotocol.anonymous"
+
+// Anonymous obtains an anonymous registry token, granting publish and edit
+// permission for the AnonymousNamespace/* namespace. Registries only serve
+// anonymous tokens when configured to, which is meant for local development
+// and automated tests; production registries respond with 404.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-anonymous-token
+func Anonymous(ctx context.Context, client *mcp.Client) (*Token, *mcp.Response, error) {
+	return exchange(ctx, client, mcp.RouteGetAnonymousToken, nil)
+}
+
+// AnonymousTokenSource returns an mcp.TokenSource that authenticates every
+// request with an anonymous token from client's registry, obtaining a new
+// one as described by NewTokenSource. client only obtains the tokens; the
+// source is passed to NewClient for the client that uses them. Test suites
+// and sandbox registries that require a token even for reads can then be
+// used with:
+//
+//	bootstrap, _ := mcp.NewClient(nil, mcp.WithBaseURL(sandboxURL))
+//	client, _ := mcp.NewClient(nil, mcp.WithBaseURL(sandboxURL),
+//		mcp.WithTokenSource(auth.AnonymousTokenSource(bootstrap)))
+func AnonymousTokenSource(client *mcp.Client) mcp.RefreshableTokenSource {
+	return NewTokenSource(client, func(ctx context.Context) (*Token, error) {
+		token, _, err := Anonymous(ctx, client)
+		return token, err
+	})
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func TestAnonymous(t *testing.T) {
+	client, mux, _ := setup(t)
+
+	mux.HandleFunc("/v0.1/auth/none", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		fmt.Fprint(w, `{"registry_token":"anon-jwt","expires_at":1735689600}`)
+	})
+
+	token, _, err := Anonymous(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Anonymous returned error: %v", err)
+	}
+	if token.RegistryToken != "anon-jwt" {
+		t.Errorf("RegistryToken = %q, want %q", token.RegistryToken, "anon-jwt")
+	}
+	if want := time.Unix(1735689600, 0); !token.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", token.ExpiresAt, want)
+	}
+}
+
+func TestAnonymousTokenSource(t *testing.T) {
+	_, mux, serverURL := setup(t)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := mcptest.NewClock(start)
+	bootstrap, err := mcp.NewClient(nil, mcp.WithBaseURL(serverURL), mcp.WithClock(clock))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	client, err := mcp.NewClient(nil, mcp.WithBaseURL(serverURL), mcp.WithClock(clock),
+		mcp.WithTokenSource(AnonymousTokenSource(bootstrap)))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	issued := 0
+	mux.HandleFunc("/v0.1/auth/none", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("token request sent credentials %q", got)
+		}
+		issued++
+		expires := clock.Now().Add(5 * time.Minute).Unix()
+		fmt.Fprintf(w, `{"registry_token":"anon-%d","expires_at":%d}`, issued, expires)
+	})
+	var got []string
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	list := func() {
+		t.Helper()
+		if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+
+	list()
+	clock.Advance(4 * time.Minute)
+	list()
+	// Within the refresh margin of expiry, a new token is obtained
+	clock.Advance(40 * time.Second)
+	list()
+
+	want := []string{"Bearer anon-1", "Bearer anon-1", "Bearer anon-2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Authorization headers = %v, want %v", got, want)
+	}
+}
+
+func TestAnonymousTokenSource_Disabled(t *testing.T) {
+	bootstrap, mux, serverURL := setup(t)
+	client, err := mcp.NewClient(nil, mcp.WithBaseURL(serverURL), mcp.WithTokenSource(AnonymousTokenSource(bootstrap)))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/auth/none", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent without a token")
+	})
+
+	if _, _, err := client.Servers.List(context.Background(), nil); err == nil {
+		t.Error("List returned nil error when anonymous tokens are disabled")
+	}
+}
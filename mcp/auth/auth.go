@@ -0,0 +1,59 @@
+// Package auth implements the registry's token exchange flows, which turn
+// proof of identity, such as a GitHub Actions OIDC token, into a short-lived
+// registry token for publishing.
+//
+// The registry token is then used with the mcp package:
+//
+//	token, _, err := auth.GitHubActions(ctx, client)
+//	if err != nil {
+//		return err
+//	}
+//	ctx = mcp.ContextWithToken(ctx, token.RegistryToken)
+//	_, _, err = client.Servers.Publish(ctx, server)
+//
+// CLIs can keep tokens between runs in a CredentialStore, such as a
+// FileStore, keyed by the host of the client's BaseURL.
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// Token is a registry token obtained from a token exchange.
+type Token struct {
+	// RegistryToken is the token to send as a Bearer credential.
+	RegistryToken string
+
+	// ExpiresAt is when the token expires.
+	ExpiresAt time.Time
+}
+
+// tokenResponse is the response body of the token exchange endpoints.
+type tokenResponse struct {
+	RegistryToken string `json:"registry_token"`
+	ExpiresAt     int64  `json:"expires_at"`
+}
+
+// exchange posts body to the named token exchange route of client and
+// returns the registry token it responds with. The request is sent without
+// credentials, even if client is configured with some.
+func exchange(ctx context.Context, client *mcp.Client, route string, body any) (*Token, *mcp.Response, error) {
+	req, err := client.NewRouteRequest(route, nil, nil, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tr tokenResponse
+	resp, err := client.Do(mcp.ContextWithToken(ctx, ""), req, &tr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &Token{
+		RegistryToken: tr.RegistryToken,
+		ExpiresAt:     time.Unix(tr.ExpiresAt, 0),
+	}, resp, nil
+}
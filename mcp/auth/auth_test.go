@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// setup starts a test registry and returns a client pointed at it.
+func setup(t *testing.T) (*mcp.Client, *http.ServeMux, string) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := mcp.NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, mux, server.URL
+}
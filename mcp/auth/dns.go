@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// DNSChallenge is the DNS record a domain owner publishes to prove control of
+// a domain to the registry.
+type DNSChallenge struct {
+	// Name is the domain the record is published at.
+	Name string
+
+	// Type is the record type, always TXT.
+	Type string
+
+	// Value is the record value, e.g. "v=MCPv1; k=ed25519; p=...".
+	Value string
+}
+
+// NewDNSChallenge returns the TXT record to publish at domain so that the
+// registry accepts signatures made with the private key of key. Once
+// published, ExchangeDNS grants publish permission for the com.example/*
+// namespace of domain example.com, and for those of its subdomains.
+func NewDNSChallenge(domain string, key ed25519.PublicKey) *DNSChallenge {
+	return &DNSChallenge{
+		Name:  domain,
+		Type:  "TXT",
+		Value: mcp.FormatMCPPublicKey(key),
+	}
+}
+
+// ExchangeDNS proves control of domain by signing the current time with key,
+// whose public key must be published at domain as described by
+// NewDNSChallenge, and returns a registry token for the domain's namespaces.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-dns-token
+func ExchangeDNS(ctx context.Context, client *mcp.Client, domain string, key ed25519.PrivateKey) (*Token, *mcp.Response, error) {
+	return signedExchange(ctx, client, mcp.RouteExchangeDNSToken, domain, key, client.Clock().Now())
+}
+
+// WaitDNS is like ExchangeDNS, but keeps retrying while the registry cannot
+// yet see the published record, for example while DNS changes propagate. It
+// returns once a token is issued, a non-retryable error occurs, or ctx is
+// done.
+func WaitDNS(ctx context.Context, client *mcp.Client, domain string, key ed25519.PrivateKey, opts *PollOptions) (*Token, *mcp.Response, error) {
+	return pollSignedExchange(ctx, client, mcp.RouteExchangeDNSToken, domain, key, opts)
+}
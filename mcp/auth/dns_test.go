@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+// testKey is a fixed Ed25519 key used by the signed exchange tests.
+var testKey = ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+
+// handleSignedExchange registers a handler for a signed exchange endpoint
+// that verifies signatures against testKey and rejects the first
+// rejectCount requests with 401.
+func handleSignedExchange(t *testing.T, mux *http.ServeMux, path, domain string, rejectCount int) *int {
+	t.Helper()
+
+	requests := 0
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var body struct {
+			Domain          string `json:"domain"`
+			Timestamp       string `json:"timestamp"`
+			SignedTimestamp string `json:"signed_timestamp"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding exchange body: %v", err)
+		}
+		if body.Domain != domain {
+			t.Errorf("domain = %q, want %q", body.Domain, domain)
+		}
+		if _, err := time.Parse(time.RFC3339, body.Timestamp); err != nil {
+			t.Errorf("timestamp %q is not RFC 3339: %v", body.Timestamp, err)
+		}
+		sig, err := hex.DecodeString(body.SignedTimestamp)
+		if err != nil || !ed25519.Verify(testKey.Public().(ed25519.PublicKey), []byte(body.Timestamp), sig) {
+			t.Errorf("signed_timestamp does not verify")
+		}
+
+		if requests <= rejectCount {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"title":"Unauthorized","status":401,"detail":"no valid MCP public keys found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"registry_token":"registry-jwt","expires_at":1735689600}`)
+	})
+	return &requests
+}
+
+func TestNewDNSChallenge(t *testing.T) {
+	challenge := NewDNSChallenge("example.com", testKey.Public().(ed25519.PublicKey))
+
+	if challenge.Name != "example.com" || challenge.Type != "TXT" {
+		t.Errorf("challenge = %+v, want TXT record at example.com", challenge)
+	}
+	if !strings.HasPrefix(challenge.Value, "v=MCPv1; k=ed25519; p=") {
+		t.Errorf("challenge Value = %q, want MCP key record", challenge.Value)
+	}
+	if keys := mcp.ParseMCPPublicKeys([]string{challenge.Value}); len(keys) != 1 || !keys[0].Equal(testKey.Public()) {
+		t.Errorf("challenge Value does not round trip through ParseMCPPublicKeys")
+	}
+}
+
+func TestExchangeDNS(t *testing.T) {
+	client, mux, _ := setup(t)
+	handleSignedExchange(t, mux, "/v0.1/auth/dns", "example.com", 0)
+
+	token, _, err := ExchangeDNS(context.Background(), client, "example.com", testKey)
+	if err != nil {
+		t.Fatalf("ExchangeDNS returned error: %v", err)
+	}
+	if token.RegistryToken != "registry-jwt" {
+		t.Errorf("RegistryToken = %q, want %q", token.RegistryToken, "registry-jwt")
+	}
+}
+
+func TestWaitDNS(t *testing.T) {
+	client, mux, _ := setup(t)
+	clock := mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := mcp.WithClock(clock)(client); err != nil {
+		t.Fatalf("WithClock returned error: %v", err)
+	}
+	requests := handleSignedExchange(t, mux, "/v0.1/auth/dns", "example.com", 2)
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := WaitDNS(context.Background(), client, "example.com", testKey, &PollOptions{Interval: time.Minute})
+		done <- err
+	}()
+
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("WaitDNS returned error: %v", err)
+	}
+	if *requests != 3 {
+		t.Errorf("exchange requests = %d, want 3", *requests)
+	}
+}
+
+func TestWaitDNS_ContextDone(t *testing.T) {
+	client, mux, _ := setup(t)
+	handleSignedExchange(t, mux, "/v0.1/auth/dns", "example.com", 100)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err := WaitDNS(ctx, client, "example.com", testKey, &PollOptions{Interval: time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitDNS error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := ParsePrivateKey(strings.Repeat("00", ed25519.SeedSize) + "\n")
+	if err != nil {
+		t.Fatalf("ParsePrivateKey returned error: %v", err)
+	}
+	if !key.Equal(testKey) {
+		t.Error("ParsePrivateKey returned a different key")
+	}
+
+	for _, seed := range []string{"zz", "00"} {
+		if _, err := ParsePrivateKey(seed); err == nil {
+			t.Errorf("ParsePrivateKey(%q) returned nil error", seed)
+		}
+	}
+}
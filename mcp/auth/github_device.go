@@ -0,0 +1,274 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// GitHub OAuth device flow endpoints. They are variables so tests can point
+// them at a local server.
+var (
+	githubDeviceCodeURL  = "https://github.com/login/device/code"
+	githubAccessTokenURL = "https://github.com/login/oauth/access_token"
+)
+
+// defaultGitHubScopes are the scopes the registry needs to determine which
+// namespaces a GitHub user may publish to.
+var defaultGitHubScopes = []string{"read:org", "read:user"}
+
+// defaultDevicePollInterval is the poll interval used when GitHub does not
+// specify one, and the increase applied when asked to slow down.
+const defaultDevicePollInterval = 5 * time.Second
+
+// Errors returned by GitHubDeviceFlow.Login when the user does not complete
+// the flow.
+var (
+	ErrDeviceCodeExpired = errors.New("auth: device code expired before authorization")
+	ErrAccessDenied      = errors.New("auth: authorization was denied")
+)
+
+// DeviceCode is the code a user enters at VerificationURI to authorize a
+// device flow login.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// GitHubDeviceFlow walks a user through GitHub's OAuth device flow and
+// exchanges the resulting GitHub token for a registry token granting publish
+// permission for the user's io.github.<login>/* namespace and those of their
+// organizations. It is intended for CLIs:
+//
+//	flow := &auth.GitHubDeviceFlow{}
+//	token, err := flow.Login(ctx, client)
+type GitHubDeviceFlow struct {
+	// ClientID is the GitHub OAuth app client ID. Defaults to the client ID
+	// the registry reports from its health endpoint.
+	ClientID string
+
+	// Scopes are the OAuth scopes requested. Defaults to read:org and
+	// read:user.
+	Scopes []string
+
+	// Prompt is called with the device code the user must enter. Defaults to
+	// printing instructions to standard error.
+	Prompt func(*DeviceCode) error
+
+	// HTTPClient is used for requests to GitHub. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Clock is used to wait between polls. Defaults to the clock of the
+	// client passed to Login.
+	Clock mcp.Clock
+}
+
+// Login runs the device flow and returns a registry token obtained from
+// client's registry. It blocks until the user authorizes the device, the
+// device code expires, or ctx is done.
+func (f *GitHubDeviceFlow) Login(ctx context.Context, client *mcp.Client) (*Token, error) {
+	clientID := f.ClientID
+	if clientID == "" {
+		var err error
+		clientID, _, err = GitHubClientID(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	code, err := f.requestDeviceCode(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := f.Prompt
+	if prompt == nil {
+		prompt = printDeviceCode
+	}
+	if err := prompt(code); err != nil {
+		return nil, err
+	}
+
+	clock := f.Clock
+	if clock == nil {
+		clock = client.Clock()
+	}
+
+	githubToken, err := f.pollAccessToken(ctx, clock, clientID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	token, _, err := ExchangeGitHubToken(ctx, client, githubToken)
+	return token, err
+}
+
+// requestDeviceCode starts the device flow.
+func (f *GitHubDeviceFlow) requestDeviceCode(ctx context.Context, clientID string) (*DeviceCode, error) {
+	scopes := f.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultGitHubScopes
+	}
+
+	var code DeviceCode
+	err := f.postGitHub(ctx, githubDeviceCodeURL, map[string]string{
+		"client_id": clientID,
+		"scope":     strings.Join(scopes, " "),
+	}, &code)
+	if err != nil {
+		return nil, fmt.Errorf("requesting device code: %w", err)
+	}
+	if code.DeviceCode == "" || code.UserCode == "" {
+		return nil, fmt.Errorf("requesting device code: GitHub returned an incomplete response")
+	}
+
+	return &code, nil
+}
+
+// pollAccessToken polls GitHub until the user authorizes code and returns
+// the GitHub access token.
+func (f *GitHubDeviceFlow) pollAccessToken(ctx context.Context, clock mcp.Clock, clientID string, code *DeviceCode) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	var deadline time.Time
+	if code.ExpiresIn > 0 {
+		deadline = clock.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-clock.After(interval):
+		}
+
+		var resp struct {
+			AccessToken string `json:"access_token"`
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		err := f.postGitHub(ctx, githubAccessTokenURL, map[string]string{
+			"client_id":   clientID,
+			"device_code": code.DeviceCode,
+			"grant_type":  "urn:ietf:params:oauth:grant-type:device_code",
+		}, &resp)
+		if err != nil {
+			return "", fmt.Errorf("polling for access token: %w", err)
+		}
+
+		switch resp.Error {
+		case "":
+			if resp.AccessToken == "" {
+				return "", fmt.Errorf("polling for access token: GitHub returned an empty token")
+			}
+			return resp.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += defaultDevicePollInterval
+		case "expired_token":
+			return "", ErrDeviceCodeExpired
+		case "access_denied":
+			return "", ErrAccessDenied
+		default:
+			return "", fmt.Errorf("polling for access token: %s: %s", resp.Error, resp.Description)
+		}
+
+		if !deadline.IsZero() && !clock.Now().Before(deadline) {
+			return "", ErrDeviceCodeExpired
+		}
+	}
+}
+
+// postGitHub posts body as JSON to a GitHub OAuth endpoint and decodes the
+// JSON response into v.
+func (f *GitHubDeviceFlow) postGitHub(ctx context.Context, url string, body any, v any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := f.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%d %s", resp.StatusCode, msg)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// printDeviceCode tells the user how to authorize the device.
+func printDeviceCode(code *DeviceCode) error {
+	_, err := fmt.Fprintf(os.Stderr, "To authenticate, open %s and enter the code: %s\n", code.VerificationURI, code.UserCode)
+	return err
+}
+
+// ExchangeGitHubToken exchanges a GitHub OAuth access token for a registry
+// token granting publish permission for the namespaces of the GitHub user
+// and their organizations.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-github-token
+func ExchangeGitHubToken(ctx context.Context, client *mcp.Client, githubToken string) (*Token, *mcp.Response, error) {
+	if githubToken == "" {
+		return nil, nil, fmt.Errorf("GitHub token cannot be empty")
+	}
+
+	body := struct {
+		GitHubToken string `json:"github_token"`
+	}{githubToken}
+	return exchange(ctx, client, mcp.RouteExchangeGitHubToken, body)
+}
+
+// GitHubClientID returns the GitHub OAuth app client ID the registry reports
+// from its health endpoint.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-health
+func GitHubClientID(ctx context.Context, client *mcp.Client) (string, *mcp.Response, error) {
+	req, err := client.NewRouteRequest(mcp.RouteGetHealth, nil, nil, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var health struct {
+		GitHubClientID string `json:"github_client_id"`
+	}
+	resp, err := client.Do(ctx, req, &health)
+	if err != nil {
+		return "", resp, err
+	}
+	if health.GitHubClientID == "" {
+		return "", resp, fmt.Errorf("registry does not report a GitHub client ID")
+	}
+
+	return health.GitHubClientID, resp, nil
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+// runDeviceFlow runs flow.Login against a test registry whose GitHub
+// endpoints respond to polls with pollErrors before issuing a token, and
+// returns the result.
+func runDeviceFlow(t *testing.T, flow *GitHubDeviceFlow, pollErrors []string) (*Token, error) {
+	t.Helper()
+	client, mux, serverURL := setup(t)
+
+	oldDeviceCodeURL, oldAccessTokenURL := githubDeviceCodeURL, githubAccessTokenURL
+	githubDeviceCodeURL = serverURL + "/login/device/code"
+	githubAccessTokenURL = serverURL + "/login/oauth/access_token"
+	t.Cleanup(func() { githubDeviceCodeURL, githubAccessTokenURL = oldDeviceCodeURL, oldAccessTokenURL })
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"ok","github_client_id":"registry-client-id"}`)
+	})
+	mux.HandleFunc("/login/device/code", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["client_id"] != "registry-client-id" || body["scope"] != "read:org read:user" {
+			t.Errorf("device code request = %v, want registry client ID and default scopes", body)
+		}
+		fmt.Fprint(w, `{"device_code":"dev-123","user_code":"ABCD-1234","verification_uri":"https://github.com/login/device","expires_in":900,"interval":5}`)
+	})
+	polls := 0
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["device_code"] != "dev-123" {
+			t.Errorf("access token request device_code = %q, want dev-123", body["device_code"])
+		}
+		if polls < len(pollErrors) {
+			fmt.Fprintf(w, `{"error":%q}`, pollErrors[polls])
+			polls++
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"gho_abc","token_type":"bearer"}`)
+	})
+	mux.HandleFunc("/v0.1/auth/github-at", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["github_token"] != "gho_abc" {
+			t.Errorf("exchange github_token = %q, want gho_abc", body["github_token"])
+		}
+		fmt.Fprint(w, `{"registry_token":"registry-jwt","expires_at":1735689600}`)
+	})
+
+	clock := mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	flow.Clock = clock
+
+	type result struct {
+		token *Token
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		token, err := flow.Login(context.Background(), client)
+		done <- result{token, err}
+	}()
+
+	for {
+		select {
+		case r := <-done:
+			return r.token, r.err
+		case <-time.After(time.Millisecond):
+			if clock.Waiters() > 0 {
+				clock.Advance(10 * time.Second)
+			}
+		}
+	}
+}
+
+func TestGitHubDeviceFlow_Login(t *testing.T) {
+	var prompted *DeviceCode
+	flow := &GitHubDeviceFlow{
+		Prompt: func(code *DeviceCode) error {
+			prompted = code
+			return nil
+		},
+	}
+
+	token, err := runDeviceFlow(t, flow, []string{"authorization_pending", "slow_down"})
+	if err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+	if token.RegistryToken != "registry-jwt" {
+		t.Errorf("RegistryToken = %q, want %q", token.RegistryToken, "registry-jwt")
+	}
+	if prompted == nil || prompted.UserCode != "ABCD-1234" {
+		t.Errorf("Prompt called with %+v, want user code ABCD-1234", prompted)
+	}
+}
+
+func TestGitHubDeviceFlow_Login_Denied(t *testing.T) {
+	flow := &GitHubDeviceFlow{Prompt: func(*DeviceCode) error { return nil }}
+
+	if _, err := runDeviceFlow(t, flow, []string{"access_denied"}); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Login error = %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestGitHubDeviceFlow_Login_Expired(t *testing.T) {
+	flow := &GitHubDeviceFlow{Prompt: func(*DeviceCode) error { return nil }}
+
+	if _, err := runDeviceFlow(t, flow, []string{"expired_token"}); !errors.Is(err, ErrDeviceCodeExpired) {
+		t.Errorf("Login error = %v, want ErrDeviceCodeExpired", err)
+	}
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// GitHubOIDCAudience is the audience the registry requires of GitHub Actions
+// OIDC tokens.
+const GitHubOIDCAudience = "mcp-registry"
+
+// Environment variables GitHub Actions sets for jobs with the id-token: write
+// permission.
+const (
+	envOIDCRequestURL   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	envOIDCRequestToken = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// ExchangeGitHubOIDC exchanges a GitHub Actions OIDC token for a registry
+// token granting publish permission for the io.github.<owner>/* namespace of
+// the repository the workflow runs in.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-github-oidc-token
+func ExchangeGitHubOIDC(ctx context.Context, client *mcp.Client, oidcToken string) (*Token, *mcp.Response, error) {
+	if oidcToken == "" {
+		return nil, nil, fmt.Errorf("OIDC token cannot be empty")
+	}
+
+	body := struct {
+		OIDCToken string `json:"oidc_token"`
+	}{oidcToken}
+	return exchange(ctx, client, mcp.RouteExchangeGitHubOIDCToken, body)
+}
+
+// GitHubActionsOIDCToken requests an OIDC token for audience from the
+// GitHub Actions runtime. It only works inside a GitHub Actions job with the
+// id-token: write permission. If httpClient is nil, http.DefaultClient is
+// used.
+func GitHubActionsOIDCToken(ctx context.Context, httpClient *http.Client, audience string) (string, error) {
+	requestURL := os.Getenv(envOIDCRequestURL)
+	requestToken := os.Getenv(envOIDCRequestToken)
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("%s and %s are not set; the job needs the id-token: write permission", envOIDCRequestURL, envOIDCRequestToken)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s: %w", envOIDCRequestURL, err)
+	}
+	q := u.Query()
+	q.Set("audience", audience)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("requesting GitHub Actions OIDC token: %d %s", resp.StatusCode, body)
+	}
+
+	var tr struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return "", fmt.Errorf("decoding GitHub Actions OIDC token: %w", err)
+	}
+	if tr.Value == "" {
+		return "", fmt.Errorf("GitHub Actions returned an empty OIDC token")
+	}
+
+	return tr.Value, nil
+}
+
+// GitHubActions obtains a registry token from inside a GitHub Actions job,
+// by requesting an OIDC token from the Actions runtime and exchanging it with
+// the registry, so CI pipelines can publish without long-lived secrets.
+func GitHubActions(ctx context.Context, client *mcp.Client) (*Token, *mcp.Response, error) {
+	oidcToken, err := GitHubActionsOIDCToken(ctx, nil, GitHubOIDCAudience)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ExchangeGitHubOIDC(ctx, client, oidcToken)
+}
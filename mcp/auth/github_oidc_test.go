@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+func TestGitHubActions(t *testing.T) {
+	client, mux, serverURL := setup(t)
+	if err := mcp.WithAuthToken("stale-token")(client); err != nil {
+		t.Fatalf("WithAuthToken returned error: %v", err)
+	}
+
+	mux.HandleFunc("/actions/token", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer request-token"; got != want {
+			t.Errorf("OIDC request Authorization = %q, want %q", got, want)
+		}
+		if got := r.URL.Query().Get("audience"); got != GitHubOIDCAudience {
+			t.Errorf("OIDC request audience = %q, want %q", got, GitHubOIDCAudience)
+		}
+		if got := r.URL.Query().Get("api-version"); got != "2.0" {
+			t.Errorf("OIDC request lost existing query parameter, api-version = %q", got)
+		}
+		fmt.Fprint(w, `{"value":"oidc-jwt"}`)
+	})
+	mux.HandleFunc("/v0.1/auth/github-oidc", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("exchange method = %s, want POST", r.Method)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("exchange sent client credentials %q", got)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding exchange body: %v", err)
+		}
+		if body["oidc_token"] != "oidc-jwt" {
+			t.Errorf("exchange oidc_token = %q, want %q", body["oidc_token"], "oidc-jwt")
+		}
+		fmt.Fprint(w, `{"registry_token":"registry-jwt","expires_at":1735689600}`)
+	})
+
+	t.Setenv(envOIDCRequestURL, serverURL+"/actions/token?api-version=2.0")
+	t.Setenv(envOIDCRequestToken, "request-token")
+
+	token, _, err := GitHubActions(context.Background(), client)
+	if err != nil {
+		t.Fatalf("GitHubActions returned error: %v", err)
+	}
+	if token.RegistryToken != "registry-jwt" {
+		t.Errorf("RegistryToken = %q, want %q", token.RegistryToken, "registry-jwt")
+	}
+	if want := time.Unix(1735689600, 0); !token.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", token.ExpiresAt, want)
+	}
+}
+
+func TestGitHubActionsOIDCToken_NotInActions(t *testing.T) {
+	t.Setenv(envOIDCRequestURL, "")
+	t.Setenv(envOIDCRequestToken, "")
+
+	if _, err := GitHubActionsOIDCToken(context.Background(), nil, GitHubOIDCAudience); err == nil {
+		t.Error("GitHubActionsOIDCToken returned nil error outside GitHub Actions")
+	}
+}
+
+func TestExchangeGitHubOIDC_Unauthorized(t *testing.T) {
+	client, mux, _ := setup(t)
+
+	mux.HandleFunc("/v0.1/auth/github-oidc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"title":"Unauthorized","status":401,"detail":"Invalid OIDC token"}`)
+	})
+
+	_, _, err := ExchangeGitHubOIDC(context.Background(), client, "bad-token")
+	var errResp *mcp.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("ExchangeGitHubOIDC error = %v, want 401 *mcp.ErrorResponse", err)
+	}
+
+	if _, _, err := ExchangeGitHubOIDC(context.Background(), client, ""); err == nil {
+		t.Error("ExchangeGitHubOIDC with empty token returned nil error")
+	}
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// WellKnownPath is the path at which a domain serves its MCP public key for
+// the HTTP namespace verification flow.
+const WellKnownPath = "/.well-known/mcp-registry-auth"
+
+// maxChallengeSize limits the size of challenge documents read by Check.
+const maxChallengeSize = 4096
+
+// HTTPChallenge is the document a website owner serves to prove control of a
+// domain to the registry. It implements http.Handler, so it can be served
+// directly by a Go web server:
+//
+//	challenge := auth.NewHTTPChallenge("example.com", pub)
+//	mux.Handle(auth.WellKnownPath, challenge)
+type HTTPChallenge struct {
+	// URL is where the registry fetches the document.
+	URL string
+
+	// Body is the document content, e.g. "v=MCPv1; k=ed25519; p=...".
+	Body string
+}
+
+// NewHTTPChallenge returns the document to serve at domain so that the
+// registry accepts signatures made with the private key of key. Once served,
+// ExchangeHTTP grants publish permission for the com.example/* namespace of
+// domain example.com. Unlike the DNS flow, subdomains are not included.
+func NewHTTPChallenge(domain string, key ed25519.PublicKey) *HTTPChallenge {
+	return &HTTPChallenge{
+		URL:  "https://" + domain + WellKnownPath,
+		Body: mcp.FormatMCPPublicKey(key),
+	}
+}
+
+// ServeHTTP serves the challenge document as plain text.
+func (c *HTTPChallenge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, c.Body+"\n")
+}
+
+// Check fetches the challenge URL and reports whether it serves the
+// challenge document, so publishers can confirm their website is set up
+// before exchanging. If httpClient is nil, http.DefaultClient is used.
+func (c *HTTPChallenge) Check(ctx context.Context, httpClient *http.Client) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: %s", c.URL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxChallengeSize))
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", c.URL, err)
+	}
+
+	want := mcp.ParseMCPPublicKeys([]string{c.Body})
+	for _, key := range mcp.ParseMCPPublicKeys([]string{strings.TrimSpace(string(data))}) {
+		if len(want) == 1 && key.Equal(want[0]) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s does not serve the expected public key", c.URL)
+}
+
+// ExchangeHTTP proves control of domain by signing the current time with
+// key, whose public key must be served at domain as described by
+// NewHTTPChallenge, and returns a registry token for the domain's namespace.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/exchange-http-token
+func ExchangeHTTP(ctx context.Context, client *mcp.Client, domain string, key ed25519.PrivateKey) (*Token, *mcp.Response, error) {
+	return signedExchange(ctx, client, mcp.RouteExchangeHTTPToken, domain, key, client.Clock().Now())
+}
+
+// WaitHTTP is like ExchangeHTTP, but keeps retrying while the registry
+// cannot yet fetch the challenge document, for example while a deployment
+// rolls out. It returns once a token is issued, a non-retryable error
+// occurs, or ctx is done.
+func WaitHTTP(ctx context.Context, client *mcp.Client, domain string, key ed25519.PrivateKey, opts *PollOptions) (*Token, *mcp.Response, error) {
+	return pollSignedExchange(ctx, client, mcp.RouteExchangeHTTPToken, domain, key, opts)
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPChallenge(t *testing.T) {
+	pub := testKey.Public().(ed25519.PublicKey)
+	challenge := NewHTTPChallenge("example.com", pub)
+
+	if challenge.URL != "https://example.com/.well-known/mcp-registry-auth" {
+		t.Errorf("URL = %q, want the well-known path on example.com", challenge.URL)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(WellKnownPath, challenge)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Point the challenge at the test server to check what it serves
+	served := *challenge
+	served.URL = server.URL + WellKnownPath
+	if err := served.Check(context.Background(), nil); err != nil {
+		t.Errorf("Check returned error: %v", err)
+	}
+
+	other := NewHTTPChallenge("example.com", make(ed25519.PublicKey, ed25519.PublicKeySize))
+	other.URL = served.URL
+	if err := other.Check(context.Background(), nil); err == nil {
+		t.Error("Check with a different key returned nil error")
+	}
+
+	missing := served
+	missing.URL = server.URL + "/missing"
+	if err := missing.Check(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Check of missing document error = %v, want 404", err)
+	}
+}
+
+func TestHTTPChallenge_MethodNotAllowed(t *testing.T) {
+	challenge := NewHTTPChallenge("example.com", testKey.Public().(ed25519.PublicKey))
+
+	rec := httptest.NewRecorder()
+	challenge.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, WellKnownPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestExchangeHTTP(t *testing.T) {
+	client, mux, _ := setup(t)
+	handleSignedExchange(t, mux, "/v0.1/auth/http", "example.com", 0)
+
+	token, _, err := ExchangeHTTP(context.Background(), client, "example.com", testKey)
+	if err != nil {
+		t.Fatalf("ExchangeHTTP returned error: %v", err)
+	}
+	if token.RegistryToken != "registry-jwt" {
+		t.Errorf("RegistryToken = %q, want %q", token.RegistryToken, "registry-jwt")
+	}
+}
+
+func TestWaitHTTP_NonRetryable(t *testing.T) {
+	client, mux, _ := setup(t)
+	mux.HandleFunc("/v0.1/auth/http", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"title":"Bad Request","status":400}`, http.StatusBadRequest)
+	})
+
+	if _, _, err := WaitHTTP(context.Background(), client, "example.com", testKey, nil); err == nil {
+		t.Error("WaitHTTP returned nil error for 400 response")
+	}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// defaultPollInterval is the interval between exchange attempts while
+// waiting for a namespace challenge to become visible to the registry.
+const defaultPollInterval = 30 * time.Second
+
+// PollOptions specifies how to wait for a namespace challenge, such as a DNS
+// record, to become visible to the registry.
+type PollOptions struct {
+	// Interval is the time between exchange attempts. Defaults to 30
+	// seconds.
+	Interval time.Duration
+}
+
+// ParsePrivateKey parses a hex-encoded Ed25519 seed, the format used by the
+// official publisher CLI, into a private key.
+func ParsePrivateKey(hexSeed string) (ed25519.PrivateKey, error) {
+	seed, err := hex.DecodeString(strings.TrimSpace(hexSeed))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex seed: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid seed length: got %d bytes, want %d", len(seed), ed25519.SeedSize)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// signedExchange proves control of domain to the registry by signing the
+// current time with key, and returns the registry token it responds with.
+func signedExchange(ctx context.Context, client *mcp.Client, route, domain string, key ed25519.PrivateKey, now time.Time) (*Token, *mcp.Response, error) {
+	if domain == "" {
+		return nil, nil, fmt.Errorf("domain cannot be empty")
+	}
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, nil, fmt.Errorf("invalid Ed25519 private key")
+	}
+
+	timestamp := now.UTC().Format(time.RFC3339)
+	body := struct {
+		Domain          string `json:"domain"`
+		Timestamp       string `json:"timestamp"`
+		SignedTimestamp string `json:"signed_timestamp"`
+	}{
+		Domain:          domain,
+		Timestamp:       timestamp,
+		SignedTimestamp: hex.EncodeToString(ed25519.Sign(key, []byte(timestamp))),
+	}
+
+	return exchange(ctx, client, route, body)
+}
+
+// pollSignedExchange repeats signedExchange until the registry accepts the
+// signature or ctx is done. The registry rejects signatures with 401 until
+// it can see the public key, so 401 responses are retried and other errors
+// are returned immediately.
+func pollSignedExchange(ctx context.Context, client *mcp.Client, route, domain string, key ed25519.PrivateKey, opts *PollOptions) (*Token, *mcp.Response, error) {
+	if opts == nil {
+		opts = &PollOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	clock := client.Clock()
+
+	for {
+		token, resp, err := signedExchange(ctx, client, route, domain, key, clock.Now())
+		var errResp *mcp.ErrorResponse
+		if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnauthorized {
+			return token, resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, resp, fmt.Errorf("waiting for %s to verify: %w (last error: %v)", domain, ctx.Err(), err)
+		case <-clock.After(interval):
+		}
+	}
+}
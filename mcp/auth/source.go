@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+// refreshMargin is how long before expiry a TokenSource replaces a cached
+// token.
+const refreshMargin = 30 * time.Second
+
+// ExchangeFunc obtains a new registry token, typically by calling one of the
+// exchange functions of this package.
+type ExchangeFunc func(ctx context.Context) (*Token, error)
+
+// NewTokenSource returns an mcp.RefreshableTokenSource that caches the token
+// obtained from exchange. A new token is obtained shortly before the cached
+// one expires, and when the registry rejects it, so long-running processes
+// keep working without handling 401 responses themselves. client obtains
+// the tokens and should be a separate bootstrap client; the source is passed
+// to NewClient for the client that uses them:
+//
+//	bootstrap, _ := mcp.NewClient(nil)
+//	source := auth.NewTokenSource(bootstrap, func(ctx context.Context) (*auth.Token, error) {
+//		token, _, err := auth.ExchangeDNS(ctx, bootstrap, "example.com", key)
+//		return token, err
+//	})
+//	client, _ := mcp.NewClient(nil, mcp.WithTokenSource(source))
+//
+// Expiry is judged by the bootstrap client's Clock. The returned source is
+// safe for concurrent use.
+func NewTokenSource(client *mcp.Client, exchange ExchangeFunc) mcp.RefreshableTokenSource {
+	return &tokenSource{client: client, exchange: exchange}
+}
+
+// tokenSource is the mcp.RefreshableTokenSource returned by NewTokenSource.
+type tokenSource struct {
+	client   *mcp.Client
+	exchange ExchangeFunc
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// Token implements mcp.TokenSource.
+func (s *tokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.client.Clock().Now()
+	if s.token == nil || !now.Add(refreshMargin).Before(s.token.ExpiresAt) {
+		return s.refreshLocked(ctx)
+	}
+
+	return s.token.RegistryToken, nil
+}
+
+// Refresh implements mcp.RefreshableTokenSource.
+func (s *tokenSource) Refresh(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.refreshLocked(ctx)
+}
+
+// refreshLocked replaces the cached token. s.mu must be held.
+func (s *tokenSource) refreshLocked(ctx context.Context) (string, error) {
+	token, err := s.exchange(ctx)
+	if err != nil {
+		s.token = nil
+		return "", err
+	}
+	s.token = token
+
+	return token.RegistryToken, nil
+}
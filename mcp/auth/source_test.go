@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+)
+
+func TestNewTokenSource_RefreshOnUnauthorized(t *testing.T) {
+	bootstrap, mux, serverURL := setup(t)
+
+	exchanges := 0
+	source := NewTokenSource(bootstrap, func(ctx context.Context) (*Token, error) {
+		exchanges++
+		return &Token{
+			RegistryToken: fmt.Sprintf("registry-%d", exchanges),
+			ExpiresAt:     time.Now().Add(time.Hour),
+		}, nil
+	})
+	client, err := mcp.NewClient(nil, mcp.WithBaseURL(serverURL), mcp.WithTokenSource(source))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	// The registry revokes the first token before it expires
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer registry-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"title":"Unauthorized","status":401}`)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+	if exchanges != 2 {
+		t.Errorf("exchanges = %d, want 2", exchanges)
+	}
+}
+
+func TestNewTokenSource_ExchangeError(t *testing.T) {
+	client, _, _ := setup(t)
+
+	errDenied := errors.New("denied")
+	source := NewTokenSource(client, func(ctx context.Context) (*Token, error) {
+		return nil, errDenied
+	})
+
+	if _, err := source.Token(context.Background()); !errors.Is(err, errDenied) {
+		t.Errorf("Token error = %v, want %v", err, errDenied)
+	}
+	if _, err := source.Refresh(context.Background()); !errors.Is(err, errDenied) {
+		t.Errorf("Refresh error = %v, want %v", err, errDenied)
+	}
+}
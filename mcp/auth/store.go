@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrNoCredential is returned by CredentialStore.Get when no token is stored
+// for a host.
+var ErrNoCredential = errors.New("no stored credential")
+
+// CredentialStore persists registry tokens between runs, keyed by registry
+// host, e.g. "registry.modelcontextprotocol.io". Implementations must be
+// safe for concurrent use.
+type CredentialStore interface {
+	// Get returns the token stored for host, or ErrNoCredential.
+	Get(host string) (*Token, error)
+
+	// Put stores token for host, replacing any stored token.
+	Put(host string, token *Token) error
+
+	// Delete removes the token stored for host. Deleting a host without a
+	// stored token is not an error.
+	Delete(host string) error
+}
+
+// FileStore is a CredentialStore that keeps tokens in a JSON file readable
+// only by the current user. The file and its directory are created on the
+// first Put.
+type FileStore struct {
+	// Path is the location of the credentials file.
+	Path string
+
+	mu sync.Mutex
+}
+
+// DefaultCredentialsPath returns the default location of the credentials
+// file, mcp-registry/credentials.json in the user's configuration directory.
+func DefaultCredentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mcp-registry", "credentials.json"), nil
+}
+
+// storedToken is the on-disk form of a Token.
+type storedToken struct {
+	RegistryToken string `json:"registry_token"`
+	ExpiresAt     int64  `json:"expires_at,omitempty"`
+}
+
+// credentialsFile is the on-disk format of a FileStore.
+type credentialsFile struct {
+	Hosts map[string]storedToken `json:"hosts"`
+}
+
+// Get implements CredentialStore.
+func (s *FileStore) Get(host string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	stored, ok := creds.Hosts[host]
+	if !ok {
+		return nil, ErrNoCredential
+	}
+
+	token := &Token{RegistryToken: stored.RegistryToken}
+	if stored.ExpiresAt != 0 {
+		token.ExpiresAt = time.Unix(stored.ExpiresAt, 0)
+	}
+	return token, nil
+}
+
+// Put implements CredentialStore.
+func (s *FileStore) Put(host string, token *Token) error {
+	if token == nil || token.RegistryToken == "" {
+		return fmt.Errorf("token cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	stored := storedToken{RegistryToken: token.RegistryToken}
+	if !token.ExpiresAt.IsZero() {
+		stored.ExpiresAt = token.ExpiresAt.Unix()
+	}
+	creds.Hosts[host] = stored
+
+	return s.write(creds)
+}
+
+// Delete implements CredentialStore.
+func (s *FileStore) Delete(host string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := creds.Hosts[host]; !ok {
+		return nil
+	}
+	delete(creds.Hosts, host)
+
+	return s.write(creds)
+}
+
+// read loads the credentials file. A missing file holds no credentials.
+func (s *FileStore) read() (*credentialsFile, error) {
+	creds := &credentialsFile{Hosts: make(map[string]storedToken)}
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return creds, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, creds); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", s.Path, err)
+	}
+	if creds.Hosts == nil {
+		creds.Hosts = make(map[string]storedToken)
+	}
+	return creds, nil
+}
+
+// write replaces the credentials file with creds. The file is written to a
+// temporary file first, so a failed write never leaves it truncated.
+func (s *FileStore) write(creds *credentialsFile) error {
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.Path)
+}
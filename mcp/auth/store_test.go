@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "credentials.json")
+	store := &FileStore{Path: path}
+
+	if _, err := store.Get("registry.example.com"); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("Get before Put error = %v, want ErrNoCredential", err)
+	}
+
+	expires := time.Unix(1735689600, 0)
+	if err := store.Put("registry.example.com", &Token{RegistryToken: "jwt-1", ExpiresAt: expires}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if err := store.Put("other.example.com", &Token{RegistryToken: "jwt-2"}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	// A new store reads what the first one wrote
+	reopened := &FileStore{Path: path}
+	token, err := reopened.Get("registry.example.com")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if token.RegistryToken != "jwt-1" || !token.ExpiresAt.Equal(expires) {
+		t.Errorf("Get = %+v, want jwt-1 expiring at %v", token, expires)
+	}
+	if token, err := reopened.Get("other.example.com"); err != nil || !token.ExpiresAt.IsZero() {
+		t.Errorf("Get(other) = %+v, %v; want token without expiry", token, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Stat returned error: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Errorf("credentials file mode = %v, want 0600", perm)
+		}
+	}
+
+	if err := reopened.Delete("registry.example.com"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := reopened.Delete("registry.example.com"); err != nil {
+		t.Errorf("second Delete returned error: %v", err)
+	}
+	if _, err := store.Get("registry.example.com"); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("Get after Delete error = %v, want ErrNoCredential", err)
+	}
+	if _, err := store.Get("other.example.com"); err != nil {
+		t.Errorf("Delete removed another host's token: %v", err)
+	}
+}
+
+func TestFileStore_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	store := &FileStore{Path: path}
+
+	if err := store.Put("registry.example.com", &Token{}); err == nil {
+		t.Error("Put with empty token returned nil error")
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("registry.example.com"); err == nil {
+		t.Error("Get from corrupt file returned nil error")
+	}
+}
+
+func TestFileStore_Concurrent(t *testing.T) {
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "credentials.json")}
+
+	var wg sync.WaitGroup
+	for _, host := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := store.Put(host, &Token{RegistryToken: host}); err != nil {
+				t.Errorf("Put(%s) returned error: %v", host, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, host := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		if token, err := store.Get(host); err != nil || token.RegistryToken != host {
+			t.Errorf("Get(%s) = %+v, %v; want stored token", host, token, err)
+		}
+	}
+}
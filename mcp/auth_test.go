@@ -0,0 +1,474 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestContextWithToken(t *testing.T) {
+	ctx := ContextWithToken(context.Background(), "tenant-token")
+
+	token, ok := TokenFromContext(ctx)
+	if !ok || token != "tenant-token" {
+		t.Errorf("TokenFromContext = %q, %v; want %q, true", token, ok, "tenant-token")
+	}
+
+	if _, ok := TokenFromContext(context.Background()); ok {
+		t.Error("TokenFromContext on empty context reported a token")
+	}
+}
+
+func TestDo_ContextToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		header     string
+		wantHeader string
+	}{
+		{
+			name:       "no context token",
+			ctx:        context.Background(),
+			wantHeader: "",
+		},
+		{
+			name:       "context token",
+			ctx:        ContextWithToken(context.Background(), "tenant-token"),
+			wantHeader: "Bearer tenant-token",
+		},
+		{
+			name:       "context token overrides request header",
+			ctx:        ContextWithToken(context.Background(), "tenant-token"),
+			header:     "Bearer other",
+			wantHeader: "Bearer tenant-token",
+		},
+		{
+			name:       "empty context token removes credentials",
+			ctx:        ContextWithToken(context.Background(), ""),
+			header:     "Bearer other",
+			wantHeader: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tt.wantHeader {
+					t.Errorf("Authorization = %q, want %q", got, tt.wantHeader)
+				}
+				fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+			})
+
+			req, err := client.NewRequest("GET", "v0.1/servers", nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			if _, err := client.Do(tt.ctx, req, nil); err != nil {
+				t.Fatalf("Do returned error: %v", err)
+			}
+
+			// The caller's request must not be modified
+			if got := req.Header.Get("Authorization"); got != tt.header {
+				t.Errorf("caller request Authorization = %q, want %q", got, tt.header)
+			}
+		})
+	}
+}
+
+func TestWithAuthToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantHeader string
+	}{
+		{
+			name:       "client token",
+			ctx:        context.Background(),
+			wantHeader: "Bearer client-token",
+		},
+		{
+			name:       "context token takes precedence",
+			ctx:        ContextWithToken(context.Background(), "tenant-token"),
+			wantHeader: "Bearer tenant-token",
+		},
+		{
+			name:       "empty context token sends no credentials",
+			ctx:        ContextWithToken(context.Background(), ""),
+			wantHeader: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			if err := WithAuthToken("client-token")(client); err != nil {
+				t.Fatalf("WithAuthToken returned error: %v", err)
+			}
+
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tt.wantHeader {
+					t.Errorf("Authorization = %q, want %q", got, tt.wantHeader)
+				}
+				fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+			})
+
+			if _, _, err := client.Servers.List(tt.ctx, nil); err != nil {
+				t.Fatalf("List returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWithAuthToken_Empty(t *testing.T) {
+	if _, err := NewClient(nil, WithAuthToken("")); err == nil {
+		t.Error("NewClient with empty auth token returned nil error")
+	}
+}
+
+func TestWithTokenSource(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	calls := 0
+	source := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		calls++
+		return fmt.Sprintf("token-%d", calls), nil
+	})
+	if err := WithTokenSource(source)(client); err != nil {
+		t.Fatalf("WithTokenSource returned error: %v", err)
+	}
+
+	var got []string
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+
+	// Context tokens take precedence without consulting the source
+	ctx := ContextWithToken(context.Background(), "tenant-token")
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	want := []string{"Bearer token-1", "Bearer token-2", "Bearer tenant-token"}
+	if len(got) != len(want) {
+		t.Fatalf("Authorization headers = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d Authorization = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if calls != 2 {
+		t.Errorf("token source called %d times, want 2", calls)
+	}
+}
+
+func TestWithTokenSource_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	errExpired := errors.New("refresh token expired")
+	source := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return "", errExpired
+	})
+	if err := WithTokenSource(source)(client); err != nil {
+		t.Fatalf("WithTokenSource returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent despite token source error")
+	})
+
+	if _, _, err := client.Servers.List(context.Background(), nil); !errors.Is(err, errExpired) {
+		t.Errorf("List error = %v, want wrapped token source error", err)
+	}
+}
+
+func TestWithTokenSource_Nil(t *testing.T) {
+	if _, err := NewClient(nil, WithTokenSource(nil)); err == nil {
+		t.Error("NewClient with nil token source returned nil error")
+	}
+}
+
+// refreshableSource is a RefreshableTokenSource returning numbered tokens.
+type refreshableSource struct {
+	token      string
+	refreshes  int
+	refreshErr error
+}
+
+func (s *refreshableSource) Token(context.Context) (string, error) {
+	return s.token, nil
+}
+
+func (s *refreshableSource) Refresh(context.Context) (string, error) {
+	if s.refreshErr != nil {
+		return "", s.refreshErr
+	}
+	s.refreshes++
+	s.token = fmt.Sprintf("token-%d", s.refreshes+1)
+	return s.token, nil
+}
+
+func TestDo_RefreshOnUnauthorized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	source := &refreshableSource{token: "token-1"}
+	if err := WithTokenSource(source)(client); err != nil {
+		t.Fatalf("WithTokenSource returned error: %v", err)
+	}
+
+	var got []string
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.Header.Get("Authorization")+" "+string(body))
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"title":"Unauthorized","status":401,"detail":"Invalid or expired Registry JWT token"}`)
+			return
+		}
+		fmt.Fprint(w, `{"server":{"name":"io.github.example/server","version":"1.0.0"}}`)
+	})
+
+	req, err := client.NewRequest("POST", "v0.1/publish", map[string]string{"name": "io.github.example/server"})
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	body := `{"name":"io.github.example/server"}` + "\n"
+	want := []string{"Bearer token-1 " + body, "Bearer token-2 " + body}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+	if source.refreshes != 1 {
+		t.Errorf("Refresh called %d times, want 1", source.refreshes)
+	}
+}
+
+func TestDo_RefreshOnUnauthorized_NoRetry(t *testing.T) {
+	errRevoked := errors.New("grant revoked")
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		source    *refreshableSource
+		requests  int
+		refreshes int
+		wantErr   error
+	}{
+		{
+			name:      "rejected after refresh",
+			ctx:       context.Background(),
+			source:    &refreshableSource{token: "token-1"},
+			requests:  2,
+			refreshes: 1,
+		},
+		{
+			name:     "context token",
+			ctx:      ContextWithToken(context.Background(), "tenant-token"),
+			source:   &refreshableSource{token: "token-1"},
+			requests: 1,
+		},
+		{
+			name:     "refresh error",
+			ctx:      context.Background(),
+			source:   &refreshableSource{token: "token-1", refreshErr: errRevoked},
+			requests: 1,
+			wantErr:  errRevoked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			if err := WithTokenSource(tt.source)(client); err != nil {
+				t.Fatalf("WithTokenSource returned error: %v", err)
+			}
+
+			requests := 0
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"title":"Unauthorized","status":401}`)
+			})
+
+			_, _, err := client.Servers.List(tt.ctx, nil)
+			var errResp *ErrorResponse
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("List error = %v, want %v", err, tt.wantErr)
+				}
+			} else if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusUnauthorized {
+				t.Errorf("List error = %v, want 401 *ErrorResponse", err)
+			}
+			if requests != tt.requests {
+				t.Errorf("requests = %d, want %d", requests, tt.requests)
+			}
+			if tt.source.refreshes != tt.refreshes {
+				t.Errorf("refreshes = %d, want %d", tt.source.refreshes, tt.refreshes)
+			}
+		})
+	}
+}
+
+func TestWithAuthScheme(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	for _, opt := range []Option{WithAuthScheme("X-API-Key", ""), WithAuthToken("gateway-key")} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	var got []string
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %q, want none", auth)
+		}
+		got = append(got, r.Header.Get("X-API-Key"))
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	ctxs := []context.Context{
+		context.Background(),
+		ContextWithToken(context.Background(), "tenant-key"),
+		ContextWithToken(context.Background(), ""),
+	}
+	for _, ctx := range ctxs {
+		if _, _, err := client.Servers.List(ctx, nil); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+
+	want := []string{"gateway-key", "tenant-key", ""}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("X-API-Key headers = %q, want %q", got, want)
+	}
+}
+
+func TestWithAPIKey(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	for _, opt := range []Option{WithAPIKey("X-Gateway-Key", "gw-123"), WithAuthToken("registry-jwt")} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Gateway-Key"), "gw-123"; got != want {
+			t.Errorf("X-Gateway-Key = %q, want %q", got, want)
+		}
+		if got, want := r.Header.Get("Authorization"), "Bearer registry-jwt"; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	req, err := client.NewRequest("GET", "v0.1/servers", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got := req.Header.Get("X-Gateway-Key"); got != "" {
+		t.Errorf("caller request modified, X-Gateway-Key = %q", got)
+	}
+}
+
+func TestWithBasicAuth(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantAuth     string
+		wantRegistry string
+	}{
+		{
+			name:     "basic only",
+			opts:     []Option{WithBasicAuth("mirror", "s3cret")},
+			wantAuth: "Basic bWlycm9yOnMzY3JldA==",
+		},
+		{
+			name:     "token takes precedence",
+			opts:     []Option{WithBasicAuth("mirror", "s3cret"), WithAuthToken("registry-jwt")},
+			wantAuth: "Bearer registry-jwt",
+		},
+		{
+			name:         "token in another header",
+			opts:         []Option{WithBasicAuth("mirror", "s3cret"), WithAuthToken("registry-jwt"), WithAuthScheme("X-Registry-Token", "")},
+			wantAuth:     "Basic bWlycm9yOnMzY3JldA==",
+			wantRegistry: "registry-jwt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			for _, opt := range tt.opts {
+				if err := opt(client); err != nil {
+					t.Fatalf("option returned error: %v", err)
+				}
+			}
+
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tt.wantAuth {
+					t.Errorf("Authorization = %q, want %q", got, tt.wantAuth)
+				}
+				if got := r.Header.Get("X-Registry-Token"); got != tt.wantRegistry {
+					t.Errorf("X-Registry-Token = %q, want %q", got, tt.wantRegistry)
+				}
+				fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+			})
+
+			req, err := client.NewRequest("GET", "v0.1/servers", nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+			if _, err := client.Do(context.Background(), req, nil); err != nil {
+				t.Fatalf("Do returned error: %v", err)
+			}
+			if got := req.Header.Get("Authorization"); got != "" {
+				t.Errorf("caller request modified, Authorization = %q", got)
+			}
+		})
+	}
+}
+
+func TestWithAuthScheme_Invalid(t *testing.T) {
+	opts := []Option{
+		WithAuthScheme("", "Bearer "),
+		WithAPIKey("", "key"),
+		WithAPIKey("X-API-Key", ""),
+		WithBasicAuth("", "password"),
+	}
+	for i, opt := range opts {
+		if _, err := NewClient(nil, opt); err == nil {
+			t.Errorf("option %d: NewClient returned nil error", i)
+		}
+	}
+}
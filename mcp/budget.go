@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateBudget coordinates the combined request rate of one or more Clients
+// against a single upstream rate limit. It is a token bucket that refills at
+// a fixed rate and additionally pauses all attached clients when a response
+// reports the upstream limit as exhausted.
+//
+// A RateBudget is safe for concurrent use and is typically shared between
+// per-tenant clients talking to the same registry:
+//
+//	budget := mcp.NewRateBudget(100, time.Minute)
+//	a, _ := mcp.NewClient(nil, mcp.WithRateBudget(budget))
+//	b, _ := mcp.NewClient(nil, mcp.WithRateBudget(budget))
+type RateBudget struct {
+	mu           sync.Mutex
+	interval     time.Duration // time to refill one token
+	burst        float64       // maximum number of tokens
+	tokens       float64       // currently available tokens
+	last         time.Time     // last refill time, zero before the first request
+	blockedUntil time.Time     // upstream reported exhaustion until this time
+}
+
+// NewRateBudget returns a RateBudget allowing requests requests per period,
+// with bursts of up to requests requests. It panics if requests or per is
+// not positive.
+func NewRateBudget(requests int, per time.Duration) *RateBudget {
+	if requests <= 0 || per <= 0 {
+		panic(fmt.Sprintf("mcp: invalid rate budget %d per %v", requests, per))
+	}
+
+	return &RateBudget{
+		interval: per / time.Duration(requests),
+		burst:    float64(requests),
+		tokens:   float64(requests),
+	}
+}
+
+// WithRateBudget returns an Option that makes the client wait for the
+// shared budget before sending each request, and report the upstream rate
+// limit information of each response back to it.
+func WithRateBudget(b *RateBudget) Option {
+	return func(c *Client) error {
+		if b == nil {
+			return fmt.Errorf("rate budget cannot be nil")
+		}
+		c.rateBudget = b
+		return nil
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. It returns
+// ctx.Err() if the context is canceled or times out first.
+func (b *RateBudget) Wait(ctx context.Context) error {
+	return b.wait(ctx, systemClock{})
+}
+
+// wait implements Wait using clock.
+func (b *RateBudget) wait(ctx context.Context, clock Clock) error {
+	for {
+		delay := b.reserve(clock.Now())
+		if delay == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// reserve takes a token if one is available at now and returns zero, or
+// returns how long to wait before trying again.
+func (b *RateBudget) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) * float64(b.interval))
+}
+
+// Observe records the rate limit information returned by the upstream.
+// When the upstream reports no remaining requests, all waiters are paused
+// until the reported reset time.
+func (b *RateBudget) Observe(rate Rate) {
+	if rate.Limit <= 0 || rate.Remaining > 0 || rate.Reset.IsZero() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if rate.Reset.After(b.blockedUntil) {
+		b.blockedUntil = rate.Reset
+	}
+}
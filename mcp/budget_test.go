@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewRateBudget_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewRateBudget expected panic for zero requests")
+		}
+	}()
+	NewRateBudget(0, time.Second)
+}
+
+func TestRateBudget_Wait(t *testing.T) {
+	b := NewRateBudget(2, 100*time.Millisecond)
+	ctx := context.Background()
+
+	// The burst is available immediately
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := b.Wait(ctx); err != nil {
+			t.Fatalf("Wait returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("burst took %v, want immediate", elapsed)
+	}
+
+	// The next token refills after one interval (50ms)
+	if err := b.Wait(ctx); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("third Wait took %v, want at least one refill interval", elapsed)
+	}
+}
+
+func TestRateBudget_Wait_CancelledContext(t *testing.T) {
+	b := NewRateBudget(1, time.Hour)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := b.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRateBudget_Observe(t *testing.T) {
+	b := NewRateBudget(100, time.Second)
+	b.Observe(Rate{Limit: 10, Remaining: 0, Reset: time.Now().Add(time.Hour)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := b.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait error = %v, want %v after upstream exhaustion", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWithRateBudget(t *testing.T) {
+	if _, err := NewClient(nil, WithRateBudget(nil)); err == nil {
+		t.Error("WithRateBudget(nil) expected error")
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	budget := NewRateBudget(1, time.Hour)
+	if err := WithRateBudget(budget)(client); err != nil {
+		t.Fatalf("WithRateBudget returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		fmt.Fprint(w, `{"servers":[],"metadata":{"count":0}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("Servers.List returned error: %v", err)
+	}
+
+	// A second client sharing the budget must wait for it
+	other, err := NewClient(nil, WithBaseURL(client.BaseURL.String()), WithRateBudget(budget))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := other.Servers.List(ctx, nil); err != context.DeadlineExceeded {
+		t.Errorf("Servers.List error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Bump is the part of a semantic version incremented by BumpVersion.
+type Bump string
+
+// Supported version bumps.
+const (
+	BumpPatch Bump = "patch"
+	BumpMinor Bump = "minor"
+	BumpMajor Bump = "major"
+)
+
+// BumpOptions specifies the optional parameters to BumpVersion.
+type BumpOptions struct {
+	// Packages also bumps the version of every package by the same
+	// increment, for servers whose packages are released together with
+	// the server.
+	Packages bool
+}
+
+// BumpVersion increments the version of server by bump, following semantic
+// versioning: lower parts are reset to zero, and a bump of a prerelease
+// releases it, as in npm, so a patch bump of 1.3.0-beta.2 gives 1.3.0. Build
+// metadata is dropped and a leading "v" is kept. It returns the new version,
+// for release automation that edits server.json in CI.
+//
+// Nothing is modified if server or, with opts.Packages, one of its packages
+// does not have a semantic version.
+func BumpVersion(server *registryv0.ServerJSON, bump Bump, opts *BumpOptions) (string, error) {
+	if server == nil {
+		return "", fmt.Errorf("server cannot be nil")
+	}
+	if opts == nil {
+		opts = &BumpOptions{}
+	}
+
+	version, err := bumpVersion(server.Version, bump)
+	if err != nil {
+		return "", err
+	}
+
+	var packageVersions []string
+	if opts.Packages {
+		packageVersions = make([]string, len(server.Packages))
+		for i, pkg := range server.Packages {
+			v, err := bumpVersion(pkg.Version, bump)
+			if err != nil {
+				return "", fmt.Errorf("package %s: %w", pkg.Identifier, err)
+			}
+			packageVersions[i] = v
+		}
+	}
+
+	server.Version = version
+	for i, v := range packageVersions {
+		server.Packages[i].Version = v
+	}
+	return version, nil
+}
+
+// bumpVersion returns version incremented by bump.
+func bumpVersion(version string, bump Bump) (string, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("version %q is not a semantic version: %w", version, err)
+	}
+
+	major, minor, patch := v.Major(), v.Minor(), v.Patch()
+	pre := v.Prerelease() != ""
+	switch bump {
+	case BumpPatch:
+		if !pre {
+			patch++
+		}
+	case BumpMinor:
+		if !pre || patch != 0 {
+			minor++
+		}
+		patch = 0
+	case BumpMajor:
+		if !pre || minor != 0 || patch != 0 {
+			major++
+		}
+		minor, patch = 0, 0
+	default:
+		return "", fmt.Errorf("invalid version bump: %q", bump)
+	}
+
+	next := semver.New(major, minor, patch, "", "").String()
+	if t := strings.TrimSpace(version); strings.HasPrefix(t, "v") || strings.HasPrefix(t, "V") {
+		next = "v" + next
+	}
+	return next, nil
+}
@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    Bump
+		want    string
+	}{
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"v1.2.3", BumpPatch, "v1.2.4"},
+		{"1.2.3+build.5", BumpPatch, "1.2.4"},
+		{"1.2", BumpPatch, "1.2.1"},
+		// Prereleases are released by the smallest bump that reaches them
+		{"1.3.0-beta.2", BumpPatch, "1.3.0"},
+		{"1.3.0-beta.2", BumpMinor, "1.3.0"},
+		{"1.3.0-beta.2", BumpMajor, "2.0.0"},
+		{"1.3.1-beta.2", BumpMinor, "1.4.0"},
+		{"2.0.0-rc.1", BumpMajor, "2.0.0"},
+	}
+
+	for _, tt := range tests {
+		server := &registryv0.ServerJSON{Version: tt.version}
+		got, err := BumpVersion(server, tt.bump, nil)
+		if err != nil {
+			t.Errorf("BumpVersion(%s, %s) returned error: %v", tt.version, tt.bump, err)
+			continue
+		}
+		if got != tt.want || server.Version != tt.want {
+			t.Errorf("BumpVersion(%s, %s) = %s, server version %s, want %s", tt.version, tt.bump, got, server.Version, tt.want)
+		}
+	}
+}
+
+func TestBumpVersion_Packages(t *testing.T) {
+	server := &registryv0.ServerJSON{
+		Version: "1.0.0",
+		Packages: []model.Package{
+			{Identifier: "weather", Version: "1.0.0"},
+			{Identifier: "weather-py", Version: "0.4.1"},
+		},
+	}
+
+	if _, err := BumpVersion(server, BumpMinor, &BumpOptions{Packages: true}); err != nil {
+		t.Fatalf("BumpVersion returned error: %v", err)
+	}
+	if server.Version != "1.1.0" || server.Packages[0].Version != "1.1.0" || server.Packages[1].Version != "0.5.0" {
+		t.Errorf("BumpVersion set versions %s, %s, %s; want 1.1.0, 1.1.0, 0.5.0",
+			server.Version, server.Packages[0].Version, server.Packages[1].Version)
+	}
+
+	// Without the option, packages are left alone.
+	if _, err := BumpVersion(server, BumpPatch, nil); err != nil {
+		t.Fatalf("BumpVersion returned error: %v", err)
+	}
+	if server.Packages[0].Version != "1.1.0" {
+		t.Errorf("package version = %s, want it unchanged", server.Packages[0].Version)
+	}
+}
+
+func TestBumpVersion_Invalid(t *testing.T) {
+	server := &registryv0.ServerJSON{
+		Version:  "1.0.0",
+		Packages: []model.Package{{Identifier: "weather", Version: "latest"}},
+	}
+
+	if _, err := BumpVersion(server, BumpMinor, &BumpOptions{Packages: true}); err == nil {
+		t.Error("BumpVersion with a non-semantic package version returned no error")
+	}
+	if server.Version != "1.0.0" {
+		t.Errorf("server version = %s after failed bump, want it unchanged", server.Version)
+	}
+	if _, err := BumpVersion(server, "huge", nil); err == nil {
+		t.Error("BumpVersion with an invalid bump returned no error")
+	}
+	if _, err := BumpVersion(&registryv0.ServerJSON{Version: "2024-01"}, BumpPatch, nil); err == nil {
+		t.Error("BumpVersion of a non-semantic version returned no error")
+	}
+}
@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxListCacheEntries bounds the number of pages kept by the list cache.
+const maxListCacheEntries = 256
+
+// listCache keeps recent GET responses, keyed by request URL. It backs both
+// the list cache and the version cache.
+type listCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*listCacheEntry
+}
+
+// listCacheEntry is a cached response body and the response it came with.
+type listCacheEntry struct {
+	body    []byte
+	resp    *Response
+	expires time.Time
+}
+
+// WithListCache returns an Option that caches the pages returned by
+// ServersService.List for ttl, keyed by the full set of list options, so
+// that interactive UIs re-rendering the same search do not send the same
+// request on every keystroke. Cached pages are decoded afresh for each call
+// and reported with Response.Cached set.
+//
+// Requests authenticated with a context token bypass the cache, calls made
+// with WithNoCache refresh it, and any successful write request sent by the
+// client clears it.
+func WithListCache(ttl time.Duration) Option {
+	return func(c *Client) error {
+		if ttl <= 0 {
+			return fmt.Errorf("list cache TTL must be positive, got %v", ttl)
+		}
+		c.listCache = &listCache{ttl: ttl, entries: make(map[string]*listCacheEntry)}
+		return nil
+	}
+}
+
+// WithVersionCache returns an Option that caches the responses of
+// ServersService.Get, GetByNameExactVersion and ListVersionsByName for ttl, so that
+// services looking up the same servers repeatedly, such as the latest
+// version of every installed server, do not send the same request each time.
+// Client.Warmup fills the cache at startup. Cached responses are reported
+// with Response.Cached set.
+//
+// Like the list cache, it is bypassed by requests authenticated with a
+// context token, refreshed by calls made with WithNoCache, and cleared by
+// any successful write request sent by the client.
+func WithVersionCache(ttl time.Duration) Option {
+	return func(c *Client) error {
+		if ttl <= 0 {
+			return fmt.Errorf("version cache TTL must be positive, got %v", ttl)
+		}
+		c.versionCache = &listCache{ttl: ttl, entries: make(map[string]*listCacheEntry)}
+		return nil
+	}
+}
+
+// doCached sends the GET request req like Do, serving it from cache if
+// possible and storing successful responses that decode into v in it. A nil cache disables
+// caching.
+func (c *Client) doCached(ctx context.Context, cache *listCache, req *http.Request, v any, opts ...RequestOption) (*Response, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+	if cache == nil {
+		return c.Do(ctx, req, v)
+	}
+	if _, ok := TokenFromContext(ctx); ok {
+		return c.Do(ctx, req, v)
+	}
+
+	key := req.URL.String()
+	if body, resp, ok := cache.get(key, c.clock.Now()); ok && !requestOptionsFromContext(ctx).noCache {
+		cached := *resp
+		cached.Cached = true
+		return &cached, c.decodeCached(body, v)
+	}
+
+	var buf bytes.Buffer
+	resp, err := c.Do(ctx, req, &buf)
+	if err != nil {
+		return resp, err
+	}
+	// Only cache bodies that decode, so that one bad response, such as an
+	// error page from a proxy, is not served to every call until it expires
+	if err := c.decodeCached(buf.Bytes(), v); err != nil {
+		return resp, err
+	}
+	cache.put(key, buf.Bytes(), resp, c.clock.Now())
+
+	return resp, nil
+}
+
+// decodeCached decodes a cached response body into v like Do.
+func (c *Client) decodeCached(body []byte, v any) error {
+	return c.decodeJSON(bytes.NewReader(body), v)
+}
+
+// invalidateCache clears the caches after a successful write request.
+func (c *Client) invalidateCache(req *http.Request, err error) {
+	if err != nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return
+	}
+	for _, cache := range []*listCache{c.listCache, c.versionCache} {
+		if cache != nil {
+			cache.clear()
+		}
+	}
+}
+
+// get returns the unexpired entry for key at now.
+func (lc *listCache) get(key string, now time.Time) ([]byte, *Response, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	entry, ok := lc.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return nil, nil, false
+	}
+	return entry.body, entry.resp, true
+}
+
+// put stores body and resp for key, evicting expired entries and, if the
+// cache is still full, the entry closest to expiry.
+func (lc *listCache) put(key string, body []byte, resp *Response, now time.Time) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if len(lc.entries) >= maxListCacheEntries {
+		var oldest string
+		for k, entry := range lc.entries {
+			if !now.Before(entry.expires) {
+				delete(lc.entries, k)
+			} else if oldest == "" || entry.expires.Before(lc.entries[oldest].expires) {
+				oldest = k
+			}
+		}
+		if len(lc.entries) >= maxListCacheEntries {
+			delete(lc.entries, oldest)
+		}
+	}
+
+	lc.entries[key] = &listCacheEntry{body: body, resp: resp, expires: now.Add(lc.ttl)}
+}
+
+// clear removes all entries.
+func (lc *listCache) clear() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	clear(lc.entries)
+}
@@ -0,0 +1,192 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func TestWithListCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	clock := mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, opt := range []Option{WithClock(clock), WithListCache(time.Minute)} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"servers":[{"server":{"name":"com.example/%s","version":"1.0.0"}}],"metadata":{}}`, r.URL.Query().Get("search"))
+	})
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	ctx := context.Background()
+	list := func(search string) (string, *Response) {
+		t.Helper()
+		page, resp, err := client.Servers.List(ctx, &ServerListOptions{Search: search})
+		if err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+		return page.Servers[0].Server.Name, resp
+	}
+
+	if name, resp := list("weather"); name != "com.example/weather" || resp.Cached {
+		t.Errorf("first List = %s, Cached %v; want uncached com.example/weather", name, resp.Cached)
+	}
+
+	// Mutating a result does not affect the cached page
+	page, _, _ := client.Servers.List(ctx, &ServerListOptions{Search: "weather"})
+	page.Servers[0].Server.Name = "mutated"
+	if name, resp := list("weather"); name != "com.example/weather" || !resp.Cached {
+		t.Errorf("repeated List = %s, Cached %v; want cached com.example/weather", name, resp.Cached)
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want 1", requests)
+	}
+
+	// Different options are cached separately
+	if name, _ := list("mail"); name != "com.example/mail" || requests != 2 {
+		t.Errorf("List(mail) = %s after %d requests, want com.example/mail after 2", name, requests)
+	}
+
+	// Entries expire after the TTL
+	clock.Advance(time.Minute)
+	if _, resp := list("weather"); resp.Cached || requests != 3 {
+		t.Errorf("List after TTL: Cached %v after %d requests, want uncached after 3", resp.Cached, requests)
+	}
+
+	// Context tokens bypass the cache
+	if _, _, err := client.Servers.List(ContextWithToken(ctx, "tenant"), &ServerListOptions{Search: "weather"}); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("server received %d requests, want 4 after context token request", requests)
+	}
+
+	// Writes clear the cache
+	req, _ := client.NewRequest("POST", "v0.1/publish", map[string]string{})
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if _, resp := list("weather"); resp.Cached || requests != 5 {
+		t.Errorf("List after write: Cached %v after %d requests, want uncached after 5", resp.Cached, requests)
+	}
+}
+
+func TestWithListCache_UndecodableResponse(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithListCache(time.Minute)(client); err != nil {
+		t.Fatalf("WithListCache returned error: %v", err)
+	}
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			fmt.Fprint(w, `<html>proxy error</html>`)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/weather","version":"1.0.0"}}],"metadata":{}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.List(ctx, nil); err == nil {
+		t.Fatal("List of an HTML page returned nil error")
+	}
+	for i, wantCached := range []bool{false, true} {
+		page, resp, err := client.Servers.List(ctx, nil)
+		if err != nil {
+			t.Fatalf("List #%d returned error: %v", i, err)
+		}
+		if len(page.Servers) != 1 || resp.Cached != wantCached {
+			t.Errorf("List #%d = %d servers, Cached %v; want 1, Cached %v", i, len(page.Servers), resp.Cached, wantCached)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("server received %d requests, want 2", requests)
+	}
+}
+
+func TestWithVersionCache_TruncatedResponse(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithVersionCache(time.Minute)(client); err != nil {
+		t.Fatalf("WithVersionCache returned error: %v", err)
+	}
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body := `{"server":{"name":"com.example/weather","version":"1.0.0"}}`
+		if requests == 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			body = body[:20]
+		}
+		fmt.Fprint(w, body)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.Get(ctx, "com.example/weather", nil); err == nil {
+		t.Fatal("Get of a truncated response returned nil error")
+	}
+	server, resp, err := client.Servers.Get(ctx, "com.example/weather", nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if server.Version != "1.0.0" || resp.Cached {
+		t.Errorf("Get = %s, Cached %v; want 1.0.0 from the registry", server.Version, resp.Cached)
+	}
+	if requests != 2 {
+		t.Errorf("server received %d requests, want 2", requests)
+	}
+}
+
+func TestWithListCache_Invalid(t *testing.T) {
+	if _, err := NewClient(nil, WithListCache(0)); err == nil {
+		t.Error("WithListCache(0) returned nil error")
+	}
+}
+
+func TestWithVersionCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithVersionCache(time.Minute)(client); err != nil {
+		t.Fatalf("WithVersionCache returned error: %v", err)
+	}
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"server":{"name":"com.example/weather","version":"1.0.0"}}`)
+	})
+
+	ctx := context.Background()
+	for i, wantCached := range []bool{false, true} {
+		server, resp, err := client.Servers.Get(ctx, "com.example/weather", nil)
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if server.Version != "1.0.0" || resp.Cached != wantCached {
+			t.Errorf("Get #%d = %s, Cached %v; want 1.0.0, Cached %v", i, server.Version, resp.Cached, wantCached)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want 1", requests)
+	}
+
+	if _, err := NewClient(nil, WithVersionCache(0)); err == nil {
+		t.Error("WithVersionCache(0) returned nil error")
+	}
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CallOptions specifies the optional parameters to Client.Call.
+type CallOptions struct {
+	// Query holds the URL query parameters, as url.Values or as a struct
+	// whose fields may contain "url" tags, like the options of the
+	// built-in services.
+	Query any
+
+	// Body, if set, is JSON encoded as the request body.
+	Body any
+}
+
+// Call sends a request for an arbitrary registry path, so new registry
+// endpoints can be used before the SDK wraps them:
+//
+//	var out struct{ Count int `json:"count"` }
+//	_, err := client.Call(ctx, "GET", "v0.1/stats", nil, &out)
+//
+// path is relative to BaseURL, like the paths of NewRequest; a leading slash
+// is ignored and absolute URLs are rejected, so the client's token is never
+// sent to another host. opts may be nil. The response is decoded into v and
+// errors are returned as by Do, so the request shares the client's
+// authentication, retries, rate limit tracking and error handling.
+func (c *Client) Call(ctx context.Context, method, path string, opts *CallOptions, v any, reqOpts ...RequestOption) (*Response, error) {
+	if opts == nil {
+		opts = &CallOptions{}
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	if u.IsAbs() || u.Host != "" {
+		return nil, fmt.Errorf("call path %q must be relative to BaseURL", path)
+	}
+	path = strings.TrimLeft(path, "/")
+
+	switch q := opts.Query.(type) {
+	case nil:
+	case url.Values:
+		if encoded := q.Encode(); encoded != "" {
+			if strings.Contains(path, "?") {
+				path += "&" + encoded
+			} else {
+				path += "?" + encoded
+			}
+		}
+	default:
+		if path, err = addOptions(path, q); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := c.NewRequest(method, path, opts.Body)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(ctx, req, v, reqOpts...)
+}
+
+// DoRequest sends req with client.Do and returns the response body decoded
+// into a new T, for registry endpoints the SDK does not wrap yet:
+//
+//	req, err := client.NewRequest("GET", "v0.1/servers?limit=5", nil)
+//	...
+//	list, resp, err := mcp.DoRequest[registryv0.ServerListResponse](ctx, client, req)
+//
+// If the request fails or the response cannot be decoded, the result is nil
+// and the error is returned as by Do. An empty body decodes to the zero T.
+func DoRequest[T any](ctx context.Context, client *Client, req *http.Request, opts ...RequestOption) (*T, *Response, error) {
+	v := new(T)
+	resp, err := client.Do(ctx, req, v, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
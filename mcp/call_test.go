@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestDoRequest(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"server":{"name":"com.example/weather","version":"1.0.0"}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmissing/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Server not found"}`)
+	})
+
+	ctx := context.Background()
+	req, err := client.NewRequest("GET", "v0.1/servers/com.example%2Fweather/versions/latest", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	got, resp, err := DoRequest[registryv0.ServerResponse](ctx, client, req)
+	if err != nil {
+		t.Fatalf("DoRequest returned error: %v", err)
+	}
+	if got.Server.Name != "com.example/weather" || got.Server.Version != "1.0.0" {
+		t.Errorf("DoRequest returned %+v", got.Server)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+
+	req, err = client.NewRequest("GET", "v0.1/servers/com.example%2Fmissing/versions/latest", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	got, _, err = DoRequest[registryv0.ServerResponse](ctx, client, req)
+	if got != nil {
+		t.Errorf("DoRequest returned %+v on error, want nil", got)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("DoRequest error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Call(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/stats", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testFormValues(t, r, values{"scope": "all", "limit": "5"})
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"name":"com.example/weather"}` + "\n"; string(body) != want {
+			t.Errorf("request body = %q, want %q", body, want)
+		}
+		fmt.Fprint(w, `{"count":3}`)
+	})
+
+	ctx := context.Background()
+	var out struct {
+		Count int `json:"count"`
+	}
+	type query struct {
+		Limit int `url:"limit"`
+	}
+	for _, q := range []any{url.Values{"limit": {"5"}}, query{Limit: 5}} {
+		out.Count = 0
+		opts := &CallOptions{Query: q, Body: map[string]string{"name": "com.example/weather"}}
+		if _, err := client.Call(ctx, "POST", "/v0.1/stats?scope=all", opts, &out); err != nil {
+			t.Fatalf("Call returned error: %v", err)
+		}
+		if out.Count != 3 {
+			t.Errorf("Call decoded count %d, want 3", out.Count)
+		}
+	}
+
+	if _, err := client.Call(ctx, "GET", "https://example.com/v0.1/stats", nil, &out); err == nil {
+		t.Error("Call with an absolute URL returned no error")
+	}
+	if _, err := client.Call(ctx, "GET", "v0.1/missing", nil, &out); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Call of a missing path returned %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Channel is a release channel, derived from the prerelease tag of a
+// semantic version by convention, so hosts can let users choose how stable
+// the servers they install should be.
+type Channel string
+
+// Release channels, from most to least stable.
+const (
+	ChannelStable Channel = "stable" // no prerelease tag, e.g. 1.2.0
+	ChannelRC     Channel = "rc"     // e.g. 1.2.0-rc.1
+	ChannelBeta   Channel = "beta"   // e.g. 1.2.0-beta.2
+	ChannelAlpha  Channel = "alpha"  // e.g. 1.2.0-alpha
+)
+
+// channelRank orders the known channels by stability.
+var channelRank = map[Channel]int{
+	ChannelStable: 0,
+	ChannelRC:     1,
+	ChannelBeta:   2,
+	ChannelAlpha:  3,
+}
+
+// VersionChannel returns the channel of version: ChannelStable for versions
+// without a prerelease tag, and otherwise the leading letters of the
+// prerelease tag, lowercased, so "1.0.0-beta.2" and "1.0.0-Beta2" are both
+// ChannelBeta and "1.0.0-dev.5" is Channel("dev"). It returns "" if version
+// is not a semantic version or its prerelease tag does not start with a
+// letter; such versions belong to no channel.
+func VersionChannel(version string) Channel {
+	v, err := parseVersion(version)
+	if err != nil {
+		return ""
+	}
+
+	pre := v.Prerelease()
+	if pre == "" {
+		return ChannelStable
+	}
+
+	end := strings.IndexFunc(pre, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end >= 0 {
+		pre = pre[:end]
+	}
+	return Channel(strings.ToLower(pre))
+}
+
+// Includes reports whether users following channel c accept versions from
+// channel other. Each known channel includes itself and the more stable
+// channels, like prerelease opt-ins of package managers: ChannelBeta includes
+// ChannelStable, ChannelRC and ChannelBeta. Unknown channels include
+// ChannelStable and themselves.
+func (c Channel) Includes(other Channel) bool {
+	if other == "" {
+		return false
+	}
+	if c == other || other == ChannelStable {
+		return true
+	}
+
+	rank, ok := channelRank[c]
+	otherRank, otherOK := channelRank[other]
+	return ok && otherOK && otherRank <= rank
+}
+
+// ResolveChannel returns the highest active version of the server named name
+// whose channel is included in channel, as reported by Channel.Includes.
+// Versions that are not semantic versions are ignored. Returns nil if no
+// version qualifies, or a *NotFoundError if the client uses NotFoundAsError;
+// see WithNotFoundPolicy. Use ResolveVersion to learn why none qualifies.
+func (s *ServersService) ResolveChannel(ctx context.Context, name string, channel Channel, opts ...RequestOption) (*registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+	if channel == "" {
+		return nil, nil, fmt.Errorf("channel cannot be empty")
+	}
+
+	server, _, resp, err := s.resolve(ctx, name, &ResolveOptions{Channel: channel})
+	if err == nil && server == nil {
+		err = s.missing(ctx, name, "")
+	}
+	return server, resp, err
+}
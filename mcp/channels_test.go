@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestVersionChannel(t *testing.T) {
+	tests := map[string]Channel{
+		"1.2.0":          ChannelStable,
+		"v1.2.0":         ChannelStable,
+		"1.2.0-rc.1":     ChannelRC,
+		"1.2.0-RC1":      ChannelRC,
+		"1.2.0-beta.2":   ChannelBeta,
+		"1.2.0-alpha":    ChannelAlpha,
+		"1.2.0-dev.5":    Channel("dev"),
+		"1.2.0-20250101": Channel(""),
+		"latest":         Channel(""),
+	}
+
+	for version, want := range tests {
+		if got := VersionChannel(version); got != want {
+			t.Errorf("VersionChannel(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestChannel_Includes(t *testing.T) {
+	tests := []struct {
+		channel Channel
+		other   Channel
+		want    bool
+	}{
+		{ChannelStable, ChannelStable, true},
+		{ChannelStable, ChannelRC, false},
+		{ChannelBeta, ChannelStable, true},
+		{ChannelBeta, ChannelRC, true},
+		{ChannelBeta, ChannelBeta, true},
+		{ChannelBeta, ChannelAlpha, false},
+		{ChannelAlpha, ChannelBeta, true},
+		{Channel("dev"), Channel("dev"), true},
+		{Channel("dev"), ChannelStable, true},
+		{Channel("dev"), ChannelBeta, false},
+		{ChannelAlpha, Channel("dev"), false},
+		{ChannelAlpha, Channel(""), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.channel.Includes(tt.other); got != tt.want {
+			t.Errorf("%q.Includes(%q) = %v, want %v", tt.channel, tt.other, got, tt.want)
+		}
+	}
+}
+
+func TestServersService_ResolveChannel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"com.example/weather","version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+			{"server":{"name":"com.example/weather","version":"1.1.0-rc.1"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+			{"server":{"name":"com.example/weather","version":"1.2.0-beta.1"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+			{"server":{"name":"com.example/weather","version":"1.3.0-beta.1"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}},
+			{"server":{"name":"com.example/weather","version":"2.0.0-alpha"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}}
+		],"metadata":{"count":5}}`)
+	})
+
+	tests := map[Channel]string{
+		ChannelStable: "1.0.0",
+		ChannelRC:     "1.1.0-rc.1",
+		ChannelBeta:   "1.2.0-beta.1",
+		ChannelAlpha:  "2.0.0-alpha",
+		"nightly":     "1.0.0",
+	}
+
+	for channel, want := range tests {
+		server, _, err := client.Servers.ResolveChannel(context.Background(), "com.example/weather", channel)
+		if err != nil {
+			t.Fatalf("ResolveChannel(%q) returned error: %v", channel, err)
+		}
+		if server == nil || server.Version != want {
+			t.Errorf("ResolveChannel(%q) = %+v, want version %s", channel, server, want)
+		}
+	}
+
+	if _, _, err := client.Servers.ResolveChannel(context.Background(), "com.example/weather", ""); err == nil {
+		t.Error("ResolveChannel with empty channel returned nil error")
+	}
+}
@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock is the source of time used by a Client, for example when waiting for
+// a rate budget or measuring request durations. Tests can substitute a fake
+// clock with WithClock, such as the one provided by package mcptest, to run
+// time-dependent behavior instantly and deterministically.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by package time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// WithClock returns an Option that makes the client use clock instead of the
+// system clock.
+func WithClock(clock Clock) Option {
+	return func(c *Client) error {
+		if clock == nil {
+			return fmt.Errorf("clock cannot be nil")
+		}
+		c.clock = clock
+		return nil
+	}
+}
+
+// Clock returns the clock used by the client.
+func (c *Client) Clock() Clock {
+	return c.clock
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func TestWithClock_RateBudget(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	clock := mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	var events []AuditEvent
+	for _, opt := range []Option{
+		WithClock(clock),
+		WithRateBudget(NewRateBudget(1, time.Minute)),
+		WithAuditHook(func(e AuditEvent) { events = append(events, e) }),
+	} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("first List returned error: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := client.Servers.List(context.Background(), nil)
+		done <- err
+	}()
+
+	// The second request waits for the budget to refill on the fake clock
+	clock.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("second List returned before the clock advanced")
+	default:
+	}
+
+	clock.Advance(time.Minute)
+	if err := <-done; err != nil {
+		t.Fatalf("second List returned error: %v", err)
+	}
+
+	if len(events) != 2 || events[1].Duration != time.Minute {
+		t.Errorf("audit events = %+v, want second request to take one minute of clock time", events)
+	}
+}
+
+func TestWithClock_Nil(t *testing.T) {
+	if _, err := NewClient(nil, WithClock(nil)); err == nil {
+		t.Error("NewClient with nil clock returned nil error")
+	}
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"gopkg.in/yaml.v3"
+)
+
+// Collection represents a curated list of servers, such as a featured list
+// or a category in an internal catalog.
+//
+// The MCP Registry does not expose collections yet, so collections are
+// maintained client-side and loaded with LoadCollections.
+type Collection struct {
+	// Name is the unique identifier of the collection, e.g. "featured".
+	Name string `yaml:"name" json:"name"`
+
+	// Title is the human-readable title of the collection.
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+
+	// Description describes the purpose of the collection.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Servers lists the members of the collection, in display order.
+	Servers []CollectionEntry `yaml:"servers" json:"servers"`
+}
+
+// CollectionEntry identifies a server that is a member of a Collection.
+type CollectionEntry struct {
+	// Name is the server name, e.g. "ai.waystation/gmail".
+	Name string `yaml:"name" json:"name"`
+
+	// Version pins the member to a specific version.
+	// If empty, the latest version is used.
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+}
+
+// UnmarshalYAML allows collection entries to be written either as a plain
+// server name or as a mapping with name and version fields.
+func (e *CollectionEntry) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		e.Name = value.Value
+		e.Version = ""
+		return nil
+	}
+
+	type entry CollectionEntry
+	return value.Decode((*entry)(e))
+}
+
+// collectionFile is the document format read by LoadCollections.
+type collectionFile struct {
+	Collections []Collection `yaml:"collections"`
+}
+
+// LoadCollections reads collections from a YAML document of the form:
+//
+//	collections:
+//	  - name: featured
+//	    title: Featured servers
+//	    servers:
+//	      - ai.waystation/gmail
+//	      - name: io.github.example/weather
+//	        version: 1.2.0
+//
+// Every collection must have a unique name, and every entry a server name.
+func LoadCollections(r io.Reader) ([]Collection, error) {
+	var file collectionFile
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid collections document: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, c := range file.Collections {
+		if c.Name == "" {
+			return nil, fmt.Errorf("collection %d has no name", i)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("duplicate collection name: %s", c.Name)
+		}
+		seen[c.Name] = true
+
+		for j, entry := range c.Servers {
+			if entry.Name == "" {
+				return nil, fmt.Errorf("collection %s: entry %d has no server name", c.Name, j)
+			}
+		}
+	}
+
+	return file.Collections, nil
+}
+
+// Resolve retrieves the registry entry of every member of the collection, in
+// collection order, using the pinned version of each entry or the latest
+// version when none is pinned.
+func (c *Collection) Resolve(ctx context.Context, client *Client) ([]registryv0.ServerJSON, *Response, error) {
+	var servers []registryv0.ServerJSON
+	var lastResp *Response
+
+	for _, entry := range c.Servers {
+		var opts *ServerGetOptions
+		if entry.Version != "" {
+			opts = &ServerGetOptions{Version: entry.Version}
+		}
+
+		server, resp, err := client.Servers.Get(ctx, entry.Name, opts)
+		if err != nil {
+			return servers, resp, fmt.Errorf("collection %s: %s: %w", c.Name, entry.Name, err)
+		}
+
+		lastResp = resp
+		if server != nil {
+			servers = append(servers, *server)
+		}
+	}
+
+	return servers, lastResp, nil
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadCollections(t *testing.T) {
+	doc := `
+collections:
+  - name: featured
+    title: Featured servers
+    servers:
+      - ai.waystation/gmail
+      - name: io.github.example/weather
+        version: 1.2.0
+  - name: empty
+`
+
+	got, err := LoadCollections(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("LoadCollections returned error: %v", err)
+	}
+
+	want := []Collection{
+		{
+			Name:  "featured",
+			Title: "Featured servers",
+			Servers: []CollectionEntry{
+				{Name: "ai.waystation/gmail"},
+				{Name: "io.github.example/weather", Version: "1.2.0"},
+			},
+		},
+		{Name: "empty"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadCollections = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadCollections_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			doc:     "collections:\n  - title: x\n",
+			wantErr: "has no name",
+		},
+		{
+			name:    "duplicate name",
+			doc:     "collections:\n  - name: a\n  - name: a\n",
+			wantErr: "duplicate collection name",
+		},
+		{
+			name:    "entry without server name",
+			doc:     "collections:\n  - name: a\n    servers:\n      - version: 1.0.0\n",
+			wantErr: "has no server name",
+		},
+		{
+			name:    "unknown field",
+			doc:     "collections:\n  - name: a\n    color: red\n",
+			wantErr: "invalid collections document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadCollections(strings.NewReader(tt.doc))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadCollections error = %v, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCollection_Resolve(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/ai.waystation%2Fgmail/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server":{"name":"ai.waystation/gmail","version":"2.0.0"}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/io.github.example%2Fweather/versions/1.2.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server":{"name":"io.github.example/weather","version":"1.2.0"}}`)
+	})
+
+	c := &Collection{
+		Name: "featured",
+		Servers: []CollectionEntry{
+			{Name: "ai.waystation/gmail"},
+			{Name: "io.github.example/weather", Version: "1.2.0"},
+		},
+	}
+
+	servers, _, err := c.Resolve(context.Background(), client)
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+
+	if len(servers) != 2 || servers[0].Version != "2.0.0" || servers[1].Version != "1.2.0" {
+		t.Errorf("Resolve = %+v, want gmail 2.0.0 and weather 1.2.0", servers)
+	}
+}
+
+func TestCollection_Resolve_Error(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	c := &Collection{Name: "featured", Servers: []CollectionEntry{{Name: "missing/server"}}}
+
+	_, _, err := c.Resolve(context.Background(), client)
+	if err == nil || !strings.Contains(err.Error(), "collection featured: missing/server") {
+		t.Errorf("Resolve error = %v, want collection context", err)
+	}
+}
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ManifestDiff is the result of CompareWithRegistry.
+type ManifestDiff struct {
+	Name    string
+	Version string
+
+	// Published reports whether the registry has the version at all. If it
+	// does not, Diffs is empty.
+	Published bool
+
+	// Diffs describes each difference as a JSON path followed by what
+	// changed from the local manifest to the registry entry, in the form
+	// used by ContractError: "dropped" for fields missing from the entry,
+	// "added" for fields only the entry has, and "X became Y" for values
+	// that differ, e.g. `$.description: "Weather" became "Forecasts"`.
+	Diffs []string
+}
+
+// Equal reports whether the registry has the version and its entry matches
+// the local manifest.
+func (d *ManifestDiff) Equal() bool {
+	return d.Published && len(d.Diffs) == 0
+}
+
+// CompareWithRegistry compares the local server.json manifest with the
+// registry entry of the same name and version, so publishers can check
+// that the registry reflects the intended manifest before publishing, to
+// see whether the version is already there, and after, to confirm it was
+// stored as sent. A version the registry does not have is reported with
+// Published false rather than as an error.
+func CompareWithRegistry(ctx context.Context, client *Client, local *registryv0.ServerJSON, opts ...RequestOption) (*ManifestDiff, *Response, error) {
+	if local == nil {
+		return nil, nil, fmt.Errorf("server cannot be nil")
+	}
+	if local.Name == "" || local.Version == "" {
+		return nil, nil, fmt.Errorf("server name and version cannot be empty")
+	}
+
+	diff := &ManifestDiff{Name: local.Name, Version: local.Version}
+	remote, resp, err := client.Servers.GetByNameExactVersion(ctx, local.Name, local.Version, opts...)
+	if errors.Is(err, ErrNotFound) {
+		return diff, resp, nil
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	if remote == nil {
+		return diff, resp, nil
+	}
+	diff.Published = true
+
+	want, err := manifestJSON(local)
+	if err != nil {
+		return nil, resp, err
+	}
+	got, err := manifestJSON(remote)
+	if err != nil {
+		return nil, resp, err
+	}
+	diffJSON("$", want, got, &diff.Diffs)
+
+	return diff, resp, nil
+}
+
+// manifestJSON returns server as generic JSON values.
+func manifestJSON(server *registryv0.ServerJSON) (any, error) {
+	data, err := json.Marshal(server)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONValue(data)
+}
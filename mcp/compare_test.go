@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestCompareWithRegistry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"server":{
+			"name":"com.example/weather","version":"1.0.0","description":"Forecasts",
+			"packages":[{"registryType":"npm","identifier":"weather","version":"1.0.0","transport":{"type":"stdio"}}]
+		}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/2.0.0", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Server not found"}`)
+	})
+
+	local := &registryv0.ServerJSON{
+		Name:        "com.example/weather",
+		Version:     "1.0.0",
+		Description: "Weather",
+		Packages: []model.Package{{
+			RegistryType: "npm",
+			Identifier:   "weather",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: "stdio"},
+		}},
+		WebsiteURL: "https://example.com/weather",
+	}
+
+	ctx := context.Background()
+	diff, _, err := CompareWithRegistry(ctx, client, local)
+	if err != nil {
+		t.Fatalf("CompareWithRegistry returned error: %v", err)
+	}
+	want := &ManifestDiff{
+		Name:      "com.example/weather",
+		Version:   "1.0.0",
+		Published: true,
+		Diffs: []string{
+			`$.description: "Weather" became "Forecasts"`,
+			`$.websiteUrl: dropped`,
+		},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("CompareWithRegistry returned %+v, want %+v", diff, want)
+	}
+	if diff.Equal() {
+		t.Error("Equal returned true for differing manifests")
+	}
+
+	local.Version = "2.0.0"
+	diff, _, err = CompareWithRegistry(ctx, client, local)
+	if err != nil {
+		t.Fatalf("CompareWithRegistry returned error for unpublished version: %v", err)
+	}
+	if diff.Published || diff.Equal() || len(diff.Diffs) > 0 {
+		t.Errorf("CompareWithRegistry returned %+v for unpublished version, want unpublished", diff)
+	}
+
+	if _, _, err := CompareWithRegistry(ctx, client, nil); err == nil {
+		t.Error("CompareWithRegistry(nil) returned no error")
+	}
+}
@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithCompression returns an Option that controls response compression.
+// If enabled, every request asks for gzip-compressed responses and the
+// client decompresses them itself, even through custom transports that
+// would otherwise return compressed bodies, which cuts the bandwidth of
+// large ListAll syncs. If disabled, every request asks for uncompressed
+// responses, for example to measure payload sizes or to work around a
+// proxy that mangles compressed bodies.
+//
+// Without this option, http.Transport requests and decompresses gzip
+// responses on its own. Responses with a gzip Content-Encoding are always
+// decompressed, whichever transport fetched them. An Accept-Encoding header
+// set with WithRequestHeader takes precedence.
+func WithCompression(enabled bool) Option {
+	return func(c *Client) error {
+		if enabled {
+			c.acceptEncoding = "gzip"
+		} else {
+			c.acceptEncoding = "identity"
+		}
+		return nil
+	}
+}
+
+// setAcceptEncoding sets the Accept-Encoding header of req configured with
+// WithCompression, unless req already has one. req must be a copy owned by
+// the caller.
+func (c *Client) setAcceptEncoding(req *http.Request) {
+	if c.acceptEncoding == "" || req.Header.Get("Accept-Encoding") != "" {
+		return
+	}
+
+	req.Header = req.Header.Clone()
+	req.Header.Set("Accept-Encoding", c.acceptEncoding)
+}
+
+// decompress replaces the body of resp with its decompressed content if it
+// is gzip-encoded, and updates the headers of resp as http.Transport does.
+func decompress(resp *http.Response) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipBody decompresses a gzip response body. The gzip header is read on
+// the first Read, so that empty bodies, such as those of HEAD requests,
+// can be closed without error.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil && b.err == nil {
+		b.zr, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
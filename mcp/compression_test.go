@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWithCompression(t *testing.T) {
+	tests := []struct {
+		name               string
+		opts               []Option
+		wantAcceptEncoding string
+	}{
+		{name: "enabled", opts: []Option{WithCompression(true)}, wantAcceptEncoding: "gzip"},
+		{name: "disabled", opts: []Option{WithCompression(false)}, wantAcceptEncoding: "identity"},
+		{
+			// A transport that passes compressed bodies through
+			name: "custom transport",
+			opts: []Option{
+				WithCompression(true),
+				WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+					return RoundTripperFunc(next.RoundTrip)
+				}),
+			},
+			wantAcceptEncoding: "gzip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			for _, opt := range tt.opts {
+				if err := opt(client); err != nil {
+					t.Fatalf("option returned error: %v", err)
+				}
+			}
+			if err := client.applyMiddleware(); err != nil {
+				t.Fatalf("applyMiddleware returned error: %v", err)
+			}
+
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept-Encoding"); got != tt.wantAcceptEncoding {
+					t.Errorf("Accept-Encoding = %q, want %q", got, tt.wantAcceptEncoding)
+				}
+				body := `{"servers":[{"server":{"name":"com.example/weather","version":"1.0.0"}}],"metadata":{}}`
+				if r.Header.Get("Accept-Encoding") != "gzip" {
+					fmt.Fprint(w, body)
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				zw := gzip.NewWriter(w)
+				fmt.Fprint(zw, body)
+				zw.Close()
+			})
+
+			list, resp, err := client.Servers.List(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("List returned error: %v", err)
+			}
+			if len(list.Servers) != 1 || list.Servers[0].Server.Name != "com.example/weather" {
+				t.Errorf("List returned %+v, want com.example/weather", list.Servers)
+			}
+			if got := resp.Header.Get("Content-Encoding"); got != "" {
+				t.Errorf("Content-Encoding = %q, want it removed after decompression", got)
+			}
+		})
+	}
+}
+
+func TestWithCompression_RequestHeaderTakesPrecedence(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithCompression(true)(client); err != nil {
+		t.Fatalf("WithCompression returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "identity" {
+			t.Errorf("Accept-Encoding = %q, want identity", got)
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	if _, _, err := client.Servers.List(context.Background(), nil, WithRequestHeader("Accept-Encoding", "identity")); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+}
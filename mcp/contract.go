@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ContractError reports the differences found by CheckRoundTrip.
+type ContractError struct {
+	// Diffs describes each difference as a JSON path followed by what
+	// changed, e.g. `$.servers[0].server.title: dropped`.
+	Diffs []string
+}
+
+func (e *ContractError) Error() string {
+	return fmt.Sprintf("round trip lost data: %s", strings.Join(e.Diffs, "; "))
+}
+
+// CheckRoundTrip decodes the JSON document data into v, encodes v again, and
+// returns a *ContractError if the result is not equivalent to data. It is
+// used to check that SDK types decode registry responses losslessly, so that
+// fields added to the registry API are noticed instead of silently dropped.
+//
+// Fields that only appear after the round trip with zero values, such as
+// empty structs without omitempty support, are not reported. Timestamps are
+// compared as instants rather than strings.
+func CheckRoundTrip(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	want, err := decodeJSONValue(data)
+	if err != nil {
+		return err
+	}
+	got, err := decodeJSONValue(out)
+	if err != nil {
+		return err
+	}
+
+	var diffs []string
+	diffJSON("$", want, got, &diffs)
+	if len(diffs) > 0 {
+		return &ContractError{Diffs: diffs}
+	}
+
+	return nil
+}
+
+// decodeJSONValue decodes data into generic JSON values, keeping numbers in
+// their original representation.
+func decodeJSONValue(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// diffJSON appends the differences between want and got at path to diffs.
+func diffJSON(path string, want, got any, diffs *[]string) {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: object became %s", path, jsonKind(got)))
+			return
+		}
+		for _, key := range sortedKeys(w) {
+			gv, ok := g[key]
+			if !ok {
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: dropped", path, key))
+				continue
+			}
+			diffJSON(path+"."+key, w[key], gv, diffs)
+		}
+		for _, key := range sortedKeys(g) {
+			if _, ok := w[key]; !ok && !isZeroJSON(g[key]) {
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: added", path, key))
+			}
+		}
+
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: array became %s", path, jsonKind(got)))
+			return
+		}
+		if len(w) != len(g) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: length %d became %d", path, len(w), len(g)))
+			return
+		}
+		for i := range w {
+			diffJSON(fmt.Sprintf("%s[%d]", path, i), w[i], g[i], diffs)
+		}
+
+	case json.Number:
+		g, ok := got.(json.Number)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: number became %s", path, jsonKind(got)))
+			return
+		}
+		wf, werr := w.Float64()
+		gf, gerr := g.Float64()
+		if werr != nil || gerr != nil || wf != gf {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %s became %s", path, w, g))
+		}
+
+	case string:
+		g, ok := got.(string)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: string became %s", path, jsonKind(got)))
+			return
+		}
+		if w != g && !sameInstant(w, g) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %q became %q", path, w, g))
+		}
+
+	default:
+		if want != got {
+			*diffs = append(*diffs, fmt.Sprintf("%s: %v became %v", path, want, got))
+		}
+	}
+}
+
+// sameInstant reports whether a and b are RFC 3339 timestamps of the same
+// instant.
+func sameInstant(a, b string) bool {
+	ta, err := time.Parse(time.RFC3339Nano, a)
+	if err != nil {
+		return false
+	}
+	tb, err := time.Parse(time.RFC3339Nano, b)
+	if err != nil {
+		return false
+	}
+	return ta.Equal(tb)
+}
+
+// isZeroJSON reports whether v is a zero JSON value: null, false, 0, "", or
+// an array or object containing only zero values.
+func isZeroJSON(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case []any:
+		for _, e := range v {
+			if !isZeroJSON(e) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		for _, e := range v {
+			if !isZeroJSON(e) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// jsonKind returns the name of the JSON kind of v.
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,155 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// contractFixtures maps each fixture in testdata/contract to the SDK type its
+// response decodes into. Set REFRESH_CONTRACT_FIXTURES=true to re-record the
+// fixtures from the live registry before checking them.
+var contractFixtures = map[string]func() any{
+	"list-servers.json":        func() any { return new(registryv0.ServerListResponse) },
+	"get-server-version.json":  func() any { return new(registryv0.ServerResponse) },
+	"get-server-versions.json": func() any { return new(registryv0.ServerListResponse) },
+}
+
+func TestContract_Fixtures(t *testing.T) {
+	if os.Getenv("REFRESH_CONTRACT_FIXTURES") == "true" {
+		refreshContractFixtures(t)
+	}
+
+	for name, newValue := range contractFixtures {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "contract", name))
+			if err != nil {
+				t.Fatalf("reading fixture: %v", err)
+			}
+			if err := CheckRoundTrip(data, newValue()); err != nil {
+				t.Errorf("CheckRoundTrip: %v", err)
+			}
+		})
+	}
+}
+
+// refreshContractFixtures records the contract fixtures from the live
+// registry.
+func refreshContractFixtures(t *testing.T) {
+	t.Helper()
+
+	client, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	record := func(file, route string, params map[string]string, opts any) []byte {
+		req, err := client.NewRouteRequest(route, params, opts, nil)
+		if err != nil {
+			t.Fatalf("building %s request: %v", route, err)
+		}
+		var buf bytes.Buffer
+		if _, err := client.Do(ctx, req, &buf); err != nil {
+			t.Fatalf("recording %s: %v", route, err)
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+			t.Fatalf("formatting %s: %v", route, err)
+		}
+		indented.WriteByte('\n')
+		if err := os.WriteFile(filepath.Join("testdata", "contract", file), indented.Bytes(), 0o644); err != nil {
+			t.Fatalf("writing %s: %v", file, err)
+		}
+		return buf.Bytes()
+	}
+
+	data := record("list-servers.json", RouteListServers, nil, &ServerListOptions{ListOptions: ListOptions{Limit: 5}})
+
+	var list registryv0.ServerListResponse
+	if err := json.Unmarshal(data, &list); err != nil || len(list.Servers) == 0 {
+		t.Fatalf("recorded server list is empty or invalid: %v", err)
+	}
+	server := list.Servers[0].Server
+
+	record("get-server-version.json", RouteGetServerVersion, map[string]string{"serverName": server.Name, "version": "latest"}, nil)
+	record("get-server-versions.json", RouteGetServerVersions, map[string]string{"serverName": server.Name}, nil)
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	type known struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+		Count   int    `json:"count"`
+	}
+
+	tests := []struct {
+		name      string
+		data      string
+		wantErr   bool
+		wantDiffs []string
+	}{
+		{
+			name: "lossless",
+			data: `{"name":"a","version":"1.0.0","count":2}`,
+		},
+		{
+			name: "zero value added",
+			data: `{"name":"a"}`,
+		},
+		{
+			name:      "unknown fields dropped",
+			data:      `{"name":"a","title":"A","icons":[{"src":"x"}]}`,
+			wantDiffs: []string{"$.icons: dropped", "$.title: dropped"},
+		},
+		{
+			name:    "undecodable",
+			data:    `{"name":"a","count":1.5}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRoundTrip([]byte(tt.data), new(known))
+
+			var contractErr *ContractError
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Error("CheckRoundTrip returned nil error")
+				}
+			case tt.wantDiffs == nil:
+				if err != nil {
+					t.Errorf("CheckRoundTrip returned error: %v", err)
+				}
+			case !errors.As(err, &contractErr):
+				t.Errorf("CheckRoundTrip error = %v, want *ContractError", err)
+			default:
+				if len(contractErr.Diffs) != len(tt.wantDiffs) {
+					t.Fatalf("Diffs = %v, want %v", contractErr.Diffs, tt.wantDiffs)
+				}
+				for i := range tt.wantDiffs {
+					if contractErr.Diffs[i] != tt.wantDiffs[i] {
+						t.Errorf("Diffs[%d] = %q, want %q", i, contractErr.Diffs[i], tt.wantDiffs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRoundTrip_Timestamps(t *testing.T) {
+	var v registryv0.RegistryExtensions
+	data := `{"status":"active","publishedAt":"2025-01-01T00:00:00.000000Z","updatedAt":"2025-01-01T00:00:00Z","isLatest":true}`
+	if err := CheckRoundTrip([]byte(data), &v); err != nil {
+		t.Errorf("CheckRoundTrip returned error: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Credentials are the credentials a client uses for one registry host. Each
+// field corresponds to the option of the same purpose: Token and TokenSource
+// to WithAuthToken and WithTokenSource, APIKeyHeader and APIKey to
+// WithAPIKey, and BasicUser and BasicPassword to WithBasicAuth. Empty fields
+// are ignored.
+type Credentials struct {
+	Token       string      `json:"token,omitempty"`
+	TokenSource TokenSource `json:"-"`
+
+	APIKeyHeader string `json:"apiKeyHeader,omitempty"`
+	APIKey       string `json:"apiKey,omitempty"`
+
+	BasicUser     string `json:"basicUser,omitempty"`
+	BasicPassword string `json:"basicPassword,omitempty"`
+}
+
+// CredentialResolver supplies the credentials for registry hosts, so that a
+// single configuration can drive authentication for several clients, such
+// as one for the official registry and one for a corporate mirror.
+type CredentialResolver interface {
+	// Credentials returns the credentials for host, or nil if there are
+	// none. host is the host of the client's base URL, including the port
+	// if the URL has one.
+	Credentials(host string) (*Credentials, error)
+}
+
+// HostCredentials is a CredentialResolver backed by a map from registry host
+// to credentials. Hosts are matched case-insensitively, first with and then
+// without the port. It can be decoded from a JSON configuration file:
+//
+//	{
+//		"registry.modelcontextprotocol.io": {"token": "..."},
+//		"mcp.corp.example.com": {"basicUser": "ci", "basicPassword": "..."}
+//	}
+type HostCredentials map[string]*Credentials
+
+// Credentials implements CredentialResolver.
+func (h HostCredentials) Credentials(host string) (*Credentials, error) {
+	host = strings.ToLower(host)
+	if creds, ok := h.lookup(host); ok {
+		return creds, nil
+	}
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		if creds, ok := h.lookup(hostname); ok {
+			return creds, nil
+		}
+	}
+	return nil, nil
+}
+
+// lookup returns the credentials for host, comparing keys case-insensitively.
+func (h HostCredentials) lookup(host string) (*Credentials, bool) {
+	if creds, ok := h[host]; ok {
+		return creds, true
+	}
+	for key, creds := range h {
+		if strings.EqualFold(key, host) {
+			return creds, true
+		}
+	}
+	return nil, false
+}
+
+// WithCredentials returns an Option that configures the client with the
+// credentials resolver returns for the host of its base URL. The credentials
+// are resolved once, when NewClient has applied all other options, so the
+// order of WithCredentials and WithBaseURL does not matter. Credentials set
+// with dedicated options such as WithAuthToken take precedence over resolved
+// credentials of the same kind.
+func WithCredentials(resolver CredentialResolver) Option {
+	return func(c *Client) error {
+		if resolver == nil {
+			return fmt.Errorf("credential resolver cannot be nil")
+		}
+		c.credentials = resolver
+		return nil
+	}
+}
+
+// applyCredentials configures the client with the credentials resolved for
+// the host of its base URL, if any.
+func (c *Client) applyCredentials() error {
+	host := c.BaseURL.Host
+	creds, err := c.credentials.Credentials(host)
+	if err != nil {
+		return fmt.Errorf("resolving credentials for %s: %w", host, err)
+	}
+	if creds == nil {
+		return nil
+	}
+
+	if c.tokenSource == nil {
+		switch {
+		case creds.TokenSource != nil:
+			c.tokenSource = creds.TokenSource
+		case creds.Token != "":
+			c.tokenSource = staticTokenSource(creds.Token)
+		}
+	}
+	if c.apiKey == "" && creds.APIKey != "" {
+		if creds.APIKeyHeader == "" {
+			return fmt.Errorf("credentials for %s: API key header cannot be empty", host)
+		}
+		c.apiKeyHeader, c.apiKey = creds.APIKeyHeader, creds.APIKey
+	}
+	if c.basicUser == "" && creds.BasicUser != "" {
+		c.basicUser, c.basicPassword = creds.BasicUser, creds.BasicPassword
+	}
+
+	return nil
+}
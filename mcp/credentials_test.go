@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestHostCredentials(t *testing.T) {
+	var creds HostCredentials
+	err := json.Unmarshal([]byte(`{
+		"Registry.Example.com": {"token": "official"},
+		"mirror.example.com:8443": {"basicUser": "ci", "basicPassword": "s3cret"}
+	}`), &creds)
+	if err != nil {
+		t.Fatalf("decoding credentials: %v", err)
+	}
+
+	tests := map[string]string{
+		"registry.example.com":     "official",
+		"registry.example.com:443": "official",
+		"mirror.example.com:8443":  "ci",
+		"mirror.example.com":       "",
+		"unknown.example.com":      "",
+	}
+	for host, want := range tests {
+		got, err := creds.Credentials(host)
+		if err != nil {
+			t.Fatalf("Credentials(%q) returned error: %v", host, err)
+		}
+		var gotID string
+		if got != nil {
+			gotID = got.Token + got.BasicUser
+		}
+		if gotID != want {
+			t.Errorf("Credentials(%q) = %q, want %q", host, gotID, want)
+		}
+	}
+}
+
+func TestWithCredentials(t *testing.T) {
+	var gotAuth, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth, gotKey = r.Header.Get("Authorization"), r.Header.Get("X-Gateway-Key")
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	creds := HostCredentials{
+		u.Host:                 {Token: "mirror-token", APIKeyHeader: "X-Gateway-Key", APIKey: "gw-123"},
+		"registry.example.com": {Token: "official-token"},
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantAuth string
+	}{
+		{
+			name:     "resolved for base URL",
+			opts:     []Option{WithCredentials(creds), WithBaseURL(srv.URL)},
+			wantAuth: "Bearer mirror-token",
+		},
+		{
+			name:     "explicit token takes precedence",
+			opts:     []Option{WithBaseURL(srv.URL), WithAuthToken("explicit"), WithCredentials(creds)},
+			wantAuth: "Bearer explicit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(nil, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+				t.Fatalf("List returned error: %v", err)
+			}
+			if gotAuth != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", gotAuth, tt.wantAuth)
+			}
+			if gotKey != "gw-123" {
+				t.Errorf("X-Gateway-Key = %q, want gw-123", gotKey)
+			}
+		})
+	}
+}
+
+type failingResolver struct{}
+
+func (failingResolver) Credentials(string) (*Credentials, error) {
+	return nil, errors.New("vault unavailable")
+}
+
+func TestWithCredentials_Errors(t *testing.T) {
+	opts := []Option{
+		WithCredentials(nil),
+		WithCredentials(failingResolver{}),
+		WithCredentials(HostCredentials{"registry.modelcontextprotocol.io": {APIKey: "key"}}),
+	}
+	for i, opt := range opts {
+		if _, err := NewClient(nil, opt); err == nil {
+			t.Errorf("option %d: NewClient returned nil error", i)
+		}
+	}
+}
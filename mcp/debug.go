@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secrets in debug dumps.
+const redacted = "REDACTED"
+
+// debugWriter is the destination of debug dumps.
+type debugWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// WithDebug returns an Option that dumps every request the client sends
+// and every response it receives to w, including headers and bodies, to
+// troubleshoot mismatches with a registry's API. Credentials are redacted:
+// the Authorization, Cookie and API key headers, and JSON body fields whose
+// name mentions a token, secret, password or signature, such as the
+// registry_token returned by token exchanges. Dumps are written one exchange
+// at a time, so w need not be safe for concurrent use.
+//
+// Use Client.SetDebug to turn dumping on or off at runtime.
+func WithDebug(w io.Writer) Option {
+	return func(c *Client) error {
+		if w == nil {
+			return fmt.Errorf("debug writer cannot be nil")
+		}
+		c.SetDebug(w)
+		return nil
+	}
+}
+
+// SetDebug starts dumping requests and responses to w as described by
+// WithDebug, or stops dumping if w is nil. It is safe to call while
+// requests are in flight; they are dumped to the writer set when they
+// complete.
+func (c *Client) SetDebug(w io.Writer) {
+	if w == nil {
+		c.debug.Store(nil)
+		return
+	}
+	c.debug.Store(&debugWriter{w: w})
+}
+
+// dumpExchange writes req and resp to the debug writer, if any. The body
+// of resp is read and replaced so it can still be decoded. err is the
+// transport error, if the request failed.
+func (c *Client) dumpExchange(req *http.Request, resp *http.Response, err error) {
+	d := c.debug.Load()
+	if d == nil {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "> %s %s\n", req.Method, sanitizeURL(req.URL))
+	c.dumpHeader(&b, "> ", req.Header)
+	if req.GetBody != nil {
+		if body, bodyErr := req.GetBody(); bodyErr == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			dumpBody(&b, "> ", data)
+		}
+	}
+
+	switch {
+	case err != nil:
+		fmt.Fprintf(&b, "< error: %v\n", err)
+	case resp != nil:
+		fmt.Fprintf(&b, "< %s %s\n", resp.Proto, resp.Status)
+		c.dumpHeader(&b, "< ", resp.Header)
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), errReader{readErr}))
+		dumpBody(&b, "< ", data)
+	}
+	b.WriteString("\n")
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	io.WriteString(d.w, b.String())
+}
+
+// dumpHeader writes header to b in sorted order, one line per value, with
+// credential headers redacted.
+func (c *Client) dumpHeader(b *strings.Builder, prefix string, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range header[key] {
+			if c.isSecretHeader(key) {
+				value = redacted
+			}
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, key, value)
+		}
+	}
+}
+
+// isSecretHeader reports whether the header key carries credentials.
+func (c *Client) isSecretHeader(key string) bool {
+	switch http.CanonicalHeaderKey(key) {
+	case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+		return true
+	}
+	return c.apiKeyHeader != "" && strings.EqualFold(key, c.apiKeyHeader) ||
+		strings.EqualFold(key, c.authHeader)
+}
+
+// dumpBody writes the body data to b, with secrets in JSON bodies redacted.
+func dumpBody(b *strings.Builder, prefix string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	if v, err := decodeJSONValue(data); err == nil {
+		if clean, err := json.Marshal(redactJSON(v)); err == nil {
+			data = clean
+		}
+	}
+
+	b.WriteString(prefix + "\n")
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+}
+
+// redactJSON returns v with the string values of secret fields redacted.
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if _, ok := value.(string); ok && isSecretField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(value)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+	}
+	return v
+}
+
+// isSecretField reports whether the JSON field key holds credentials.
+func isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range []string{"token", "secret", "password", "signature"} {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// errReader returns err on every Read, or io.EOF if err is nil.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithDebug(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var dump bytes.Buffer
+	for _, opt := range []Option{WithAuthToken("secret-token"), WithAPIKey("X-Api-Key", "secret-key"), WithDebug(&dump)} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/weather","version":"1.0.0"}}],"metadata":{"nextCursor":"","registry_token":"secret-registry-token"}}`)
+	})
+
+	list, _, err := client.Servers.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(list.Servers) != 1 {
+		t.Errorf("List returned %d servers after dumping, want 1", len(list.Servers))
+	}
+
+	got := dump.String()
+	for _, want := range []string{
+		"> GET " + client.BaseURL.String() + "v0.1/servers\n",
+		"> Authorization: REDACTED\n",
+		"> X-Api-Key: REDACTED\n",
+		"< HTTP/1.1 200 OK\n",
+		"< Content-Type: application/json\n",
+		`"name":"com.example/weather"`,
+		`"registry_token":"REDACTED"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("dump does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "secret-") {
+		t.Errorf("dump leaks a secret:\n%s", got)
+	}
+
+	// Dumping can be turned off at runtime.
+	client.SetDebug(nil)
+	dump.Reset()
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if dump.Len() != 0 {
+		t.Errorf("dump after SetDebug(nil) = %q, want empty", dump.String())
+	}
+}
+
+func TestWithDebug_Nil(t *testing.T) {
+	if _, err := NewClient(nil, WithDebug(nil)); err == nil {
+		t.Error("WithDebug(nil) returned no error")
+	}
+}
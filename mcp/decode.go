@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WithStrictDecoding returns an Option that makes the client reject
+// responses with fields the SDK types do not declare, instead of ignoring
+// them. Running tests or CI jobs against the registry with strict decoding
+// catches registry schema changes early, before they silently drop data in
+// lenient production clients. Error responses are always decoded leniently.
+func WithStrictDecoding() Option {
+	return func(c *Client) error {
+		c.strictDecoding = true
+		return nil
+	}
+}
+
+// decodeJSON decodes the JSON response body r into v, rejecting unknown
+// fields if configured with WithStrictDecoding. An empty body is not an
+// error.
+func (c *Client) decodeJSON(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if c.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// WithRawJSON returns an Option that keeps the body of every successful
+// response in Response.RawJSON, so callers can log payloads or decode them
+// again into their own or newer types without sending a second request.
+// Helpers that fetch several pages return the body of the last one.
+func WithRawJSON() Option {
+	return func(c *Client) error {
+		c.rawJSON = true
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithStrictDecoding(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server":{"name":"com.example/weather","version":"1.0.0","newField":true}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmissing/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title":"Not Found","status":404,"detail":"Server not found"}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.Get(ctx, "com.example/weather", nil); err != nil {
+		t.Fatalf("lenient Get returned error: %v", err)
+	}
+
+	for _, opt := range []Option{WithStrictDecoding(), WithVersionCache(time.Minute)} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+	for range 2 { // the second call is served from the cache
+		_, _, err := client.Servers.Get(ctx, "com.example/weather", nil)
+		if err == nil || !strings.Contains(err.Error(), `unknown field "newField"`) {
+			t.Errorf("strict Get error = %v, want unknown field error", err)
+		}
+	}
+
+	_, _, err := client.Servers.Get(ctx, "com.example/missing", nil)
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Errorf("strict Get of missing server error = %v, want *ErrorResponse", err)
+	}
+}
+
+func TestWithRawJSON(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const body = `{"server":{"name":"com.example/weather","version":"1.0.0","newField":true}}`
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	ctx := context.Background()
+	_, resp, err := client.Servers.Get(ctx, "com.example/weather", nil)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if resp.RawJSON != nil {
+		t.Errorf("RawJSON = %s without WithRawJSON, want nil", resp.RawJSON)
+	}
+
+	for _, opt := range []Option{WithRawJSON(), WithVersionCache(time.Minute)} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+	for range 2 { // the second call is served from the cache
+		got, resp, err := client.Servers.Get(ctx, "com.example/weather", nil)
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if got.Version != "1.0.0" {
+			t.Errorf("Get version = %q, want 1.0.0", got.Version)
+		}
+		if string(resp.RawJSON) != body {
+			t.Errorf("RawJSON = %s, want %s", resp.RawJSON, body)
+		}
+	}
+}
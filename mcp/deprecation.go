@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// deprecationMetaKey is the publisher-provided metadata key carrying a
+// deprecation notice.
+const deprecationMetaKey = "deprecation"
+
+// replacementMetaKeys lists the publisher-provided metadata keys inspected
+// for a successor, in order of preference.
+var replacementMetaKeys = []string{"replacedBy", "successor"}
+
+// Deprecation describes why a server version is deprecated.
+type Deprecation struct {
+	// Message is the publisher's explanation, if any.
+	Message string
+
+	// ReplacedBy is the server version to move to, if the publisher named
+	// one.
+	ReplacedBy *Replacement
+}
+
+// Replacement identifies the successor of a deprecated server version.
+type Replacement struct {
+	// Name is the server name of the successor.
+	Name string
+
+	// Version is the successor's version, or "" for its latest version.
+	Version string
+}
+
+// String returns the replacement as "name" or "name@version".
+func (r *Replacement) String() string {
+	if r.Version == "" {
+		return r.Name
+	}
+	return r.Name + "@" + r.Version
+}
+
+// ParseDeprecation returns the deprecation notice of a registry entry, or nil
+// if the entry is not deprecated. The notice is read from the
+// publisher-provided metadata, where publishers may describe it as:
+//
+//	"deprecation": "Use com.example/weather-v2 instead"
+//	"deprecation": {"message": "...", "replacedBy": "com.example/weather-v2@2.0.0"}
+//	"replacedBy": {"name": "com.example/weather-v2", "version": "2.0.0"}
+//
+// "successor" is accepted in place of "replacedBy". A deprecated entry
+// without a notice yields an empty Deprecation.
+func ParseDeprecation(entry *registryv0.ServerResponse) *Deprecation {
+	if entry == nil || entryStatus(entry) != model.StatusDeprecated {
+		return nil
+	}
+
+	d := &Deprecation{}
+	if entry.Server.Meta == nil || entry.Server.Meta.PublisherProvided == nil {
+		return d
+	}
+	meta := entry.Server.Meta.PublisherProvided
+
+	switch v := meta[deprecationMetaKey].(type) {
+	case string:
+		d.Message = v
+	case map[string]any:
+		d.Message, _ = v["message"].(string)
+		d.ReplacedBy = parseReplacement(v, replacementMetaKeys)
+	}
+	if d.ReplacedBy == nil {
+		d.ReplacedBy = parseReplacement(meta, replacementMetaKeys)
+	}
+
+	return d
+}
+
+// parseReplacement reads a replacement from the first of keys present in m.
+// Values may be "name", "name@version", or an object with name and version
+// fields.
+func parseReplacement(m map[string]any, keys []string) *Replacement {
+	for _, key := range keys {
+		switch v := m[key].(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			name, version, _ := strings.Cut(v, "@")
+			return &Replacement{Name: name, Version: version}
+		case map[string]any:
+			name, _ := v["name"].(string)
+			if name == "" {
+				continue
+			}
+			version, _ := v["version"].(string)
+			return &Replacement{Name: name, Version: version}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+		want  *Deprecation
+	}{
+		{
+			name:  "active",
+			entry: `{"server":{"_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"deprecation":"Old"}}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}}`,
+			want:  nil,
+		},
+		{
+			name:  "deprecated without notice",
+			entry: `{"server":{},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}}`,
+			want:  &Deprecation{},
+		},
+		{
+			name:  "message string",
+			entry: `{"server":{"_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"deprecation":"Use the v2 server"}}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}}`,
+			want:  &Deprecation{Message: "Use the v2 server"},
+		},
+		{
+			name:  "notice object",
+			entry: `{"server":{"_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"deprecation":{"message":"Moved","replacedBy":"com.example/v2@2.1.0"}}}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}}`,
+			want:  &Deprecation{Message: "Moved", ReplacedBy: &Replacement{Name: "com.example/v2", Version: "2.1.0"}},
+		},
+		{
+			name:  "top-level successor object",
+			entry: `{"server":{"_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"deprecation":"Moved","successor":{"name":"com.example/v2"}}}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}}`,
+			want:  &Deprecation{Message: "Moved", ReplacedBy: &Replacement{Name: "com.example/v2"}},
+		},
+		{
+			name:  "invalid replacement ignored",
+			entry: `{"server":{"_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"replacedBy":{"version":"2.0.0"}}}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}}`,
+			want:  &Deprecation{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entry registryv0.ServerResponse
+			if err := json.Unmarshal([]byte(tt.entry), &entry); err != nil {
+				t.Fatalf("decoding entry: %v", err)
+			}
+			if got := ParseDeprecation(&entry); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDeprecation = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if ParseDeprecation(nil) != nil {
+		t.Error("ParseDeprecation(nil) returned non-nil")
+	}
+}
+
+func TestReplacement_String(t *testing.T) {
+	if got := (&Replacement{Name: "com.example/v2"}).String(); got != "com.example/v2" {
+		t.Errorf("String() = %q, want %q", got, "com.example/v2")
+	}
+	if got := (&Replacement{Name: "com.example/v2", Version: "2.0.0"}).String(); got != "com.example/v2@2.0.0" {
+		t.Errorf("String() = %q, want %q", got, "com.example/v2@2.0.0")
+	}
+}
@@ -19,9 +19,80 @@
 //
 // # Authentication
 //
-// The MCP Registry API currently supports read-only operations without
-// authentication. Future versions of this SDK will support authentication
-// for write operations such as publishing and updating servers.
+// Read operations on the MCP Registry API do not require authentication.
+// Write operations such as publishing, and reads from private registries,
+// require a registry token, sent as a Bearer credential with every request:
+//
+//    client, err := mcp.NewClient(nil, mcp.WithAuthToken(registryToken))
+//    created, _, err := client.Servers.Publish(ctx, server)
+//
+// Tokens that expire can be supplied by a TokenSource configured with
+// WithTokenSource, which is consulted before each request. If the source is a
+// RefreshableTokenSource, a request rejected with 401 Unauthorized is retried
+// once with a refreshed token.
+//
+// Private registries fronted by API gateways may expect credentials in
+// another header. WithAuthScheme changes the header and prefix tokens are
+// sent with, WithAPIKey sends a gateway key alongside registry tokens, and
+// WithBasicAuth sends Basic credentials to a reverse proxy.
+//
+// Programs talking to several registries, such as the official registry and
+// a corporate mirror, can keep all credentials in one HostCredentials map, or
+// any other CredentialResolver, and pass it to every client with
+// WithCredentials. Each client uses the credentials of its base URL's host.
+//
+// Internal registries that require mutual TLS can be reached with
+// WithClientCertificate, and WithTLSConfig trusts a private certificate
+// authority:
+//
+//    client, err := mcp.NewClient(nil,
+//        mcp.WithBaseURL("https://registry.internal.example.com"),
+//        mcp.WithTLSConfig(&tls.Config{RootCAs: internalCAs}),
+//        mcp.WithClientCertificate("client.crt", "client.key"))
+//
+// Package auth implements the registry's token exchange flows, such as
+// exchanging a GitHub Actions OIDC token for a registry token in CI.
+//
+// Multi-tenant services can execute a single request on behalf of a tenant
+// by attaching the tenant's token to the request context:
+//
+//    ctx := mcp.ContextWithToken(context.Background(), tenantToken)
+//    servers, _, err := client.Servers.List(ctx, nil)
+//
+// A context token takes precedence over client-level credentials, and an
+// empty context token sends the request without credentials.
+//
+// Service methods also accept request options, which apply to every request
+// of a single call. WithRequestToken overrides both context and client
+// credentials, for processes that publish to several namespaces with
+// different tokens:
+//
+//    _, _, err := client.Servers.Publish(ctx, server, mcp.WithRequestToken(dnsToken))
+//
+// WithRequestHeader adds a header to one call, WithNoCache skips the list
+// cache configured with WithListCache, and WithCallTimeout bounds the whole
+// call, including every page and retry.
+//
+// Client.Auth.WhoAmI decodes the token a context would be authenticated
+// with, so tooling can check its namespaces and expiry before publishing:
+//
+//    id, err := client.Auth.WhoAmI(ctx)
+//    if err == nil && !id.CanPublish(server.Name) {
+//        return fmt.Errorf("token cannot publish %s", server.Name)
+//    }
+//
+// Services acting for end users can identify them with
+// ContextWithOnBehalfOf, which sends the X-On-Behalf-Of header so registry
+// operators can audit who triggered each request. WithAuditHook records every
+// request, including the acting end user, on the client side.
+//
+// Services can run Preflight at startup to check connectivity, API version
+// support, the configured token and rate limit headroom in one call, and
+// exit early if its report has errors:
+//
+//    if err := mcp.Preflight(ctx, client, nil).Err(); err != nil {
+//        log.Fatal(err)
+//    }
 //
 // # Usage
 //
@@ -53,6 +124,15 @@
 //        log.Fatal(err)
 //    }
 //
+// Requests use the v0.1 API by default. Older self-hosted registries may
+// need WithAPIVersion(mcp.APIVersionV0), or the client can probe the
+// registry with NegotiateAPIVersion right after it is created:
+//
+//    version, err := client.NegotiateAPIVersion(ctx)
+//
+// A unix base URL, such as unix:///var/run/registry.sock, reaches a
+// registry listening on a Unix domain socket, such as a sidecar mirror.
+//
 // List servers:
 //
 //    servers, resp, err := client.Servers.List(context.Background(), nil)
@@ -72,6 +152,53 @@
 //    }
 //    servers, resp, err := client.Servers.List(context.Background(), opts)
 //
+// Registries that disable the search parameter reject such requests. Clients
+// created with WithSearchFallback filter listed servers client-side instead,
+// and report it in Response.SearchFallback.
+//
+// Interactive UIs that repeat the same searches can cache list pages for a
+// short time with WithListCache; cached responses set Response.Cached.
+// Services that look up the same servers repeatedly can cache Get and
+// ListVersionsByName with WithVersionCache, and fill the caches at startup
+// with Client.Warmup for the servers they are known to use.
+//
+// Responses are decoded leniently, ignoring fields the SDK types do not
+// declare. CI jobs can create clients with WithStrictDecoding to fail on such
+// fields instead and notice registry schema changes early. With WithRawJSON,
+// Response.RawJSON keeps the body of each response for logging or for
+// decoding it into newer types.
+//
+// Large syncs can request gzip-compressed responses with
+// WithCompression(true), which the client decompresses even through custom
+// transports.
+//
+// Search boxes can use a LiveSearch, which debounces queries as the user
+// types, cancels superseded requests, and delivers only the latest result:
+//
+//    ls := mcp.NewLiveSearch(client, 250*time.Millisecond, nil)
+//    defer ls.Close()
+//    ls.Query(input)
+//    for result := range ls.Results() {
+//        render(result.Servers)
+//    }
+//
+// Catalog views can use a Prefetcher to fetch the versions of the servers on
+// the current page before the user opens one:
+//
+//    p := mcp.NewPrefetcher(client, 4)
+//    p.Prefetch(page.Servers)
+//    versions, err := p.Versions(ctx, selected)
+//
+// Long-running hosts can resolve the servers they run through a
+// ServerRepository, which caches resolutions by version constraint, refreshes
+// them in the background and reports newly selected versions:
+//
+//    repo := mcp.NewServerRepository(client, &mcp.ServerRepositoryOptions{
+//        OnChange: func(c mcp.ResolutionChange) { reload(c.New) },
+//    })
+//    defer repo.Close()
+//    server, err := repo.GetResolved(ctx, "io.github.example/weather", "^1.2")
+//
 // Get a specific server by name:
 //
 //    server, resp, err := client.Servers.Get(context.Background(), "ai.waystation/gmail", nil)
@@ -172,6 +299,52 @@
 //        log.Fatal(err)
 //    }
 //
+// Errors can also be matched by status with errors.Is and the sentinel
+// errors ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited and
+// others, without type-asserting the concrete error types:
+//
+//    if errors.Is(err, mcp.ErrNotFound) {
+//        // The server or version does not exist.
+//    }
+//
+// ErrorTaxonomy lists every kind of error the client returns with its
+// status, sentinel, types and whether it is retried, and ClassifyError maps
+// an error to its code, for exhaustive handling and alerting rules.
+// ErrorTaxonomyJSON and ErrorTaxonomyMarkdown render the table for tooling
+// and documentation.
+//
+// CLIs and chat bots can show errors to end users with Humanize, or with a
+// Humanizer with custom messages and a client for "did you mean"
+// suggestions from ServersService.SuggestNames:
+//
+//    h := &mcp.Humanizer{Client: client}
+//    fmt.Println(h.Message(ctx, err)) // Server io.github.example/wether not found. Did you mean io.github.example/weather?
+//
+// Lookups report missing servers as documented by each method: Get returns
+// the 404 *ErrorResponse, while the search-based helpers return nil. Clients
+// created with WithNotFoundPolicy(NotFoundAsNil) or
+// WithNotFoundPolicy(NotFoundAsError) handle them the same way across all
+// ServersService lookups.
+// With WithNotFoundSuggestions as well, a *NotFoundError for a misspelled
+// name carries the closest existing names in its Suggestions.
+//
+// Write operations on a specific server version return a *NotFoundError or
+// *ForbiddenError for 404 and 403 responses. Both wrap the underlying
+// *ErrorResponse, so they can be matched with errors.As.
+//
+// Response, ErrorResponse and RateLimitError carry the RequestID the registry
+// returned in the X-Request-Id or a tracing header, and error messages end
+// with it, so it can be quoted when reporting registry problems.
+//
+// ResolveVersion returns a *NoActiveVersionError when the only matching
+// versions are deprecated or deleted, listing them so installers can explain
+// why nothing resolved. Its ReplacedBy method returns the successor named by
+// a publisher's deprecation notice, as parsed by ParseDeprecation.
+//
+// Servers republished under a new name can be followed with WithAliases,
+// using an alias map maintained by hand or inferred from shared repositories
+// with DetectRenames, so long-lived pins keep resolving after a rename.
+//
 // # Rate Limiting
 //
 // Rate limit information is tracked and available in response objects:
@@ -183,6 +356,34 @@
 //        fmt.Printf("Reset at: %v\n", resp.Rate.Reset)
 //    }
 //
+// Multiple clients sending requests to the same registry can share a
+// RateBudget to coordinate their combined request rate:
+//
+//    budget := mcp.NewRateBudget(100, time.Minute)
+//    tenantA, _ := mcp.NewClient(nil, mcp.WithRateBudget(budget))
+//    tenantB, _ := mcp.NewClient(nil, mcp.WithRateBudget(budget))
+//
+// Batch jobs can be warned before they run out of requests with
+// WithRateForecast, which extrapolates the request rate observed in the
+// current rate limit window; LogRateForecasts logs the warnings with slog:
+//
+//    client, err := mcp.NewClient(nil, mcp.WithRateForecast(mcp.LogRateForecasts(slog.Default())))
+//
+// WithRetry retries reads that fail with 5xx or 429 responses or transient
+// transport errors, backing off exponentially with jitter and honoring
+// Retry-After:
+//
+//    client, err := mcp.NewClient(nil, mcp.WithRetry(4, 500*time.Millisecond))
+//
+// Clients of registries served from several regions can list the
+// equivalent endpoints with WithEndpoints. Writes go to the first; reads go
+// to the fastest healthy one, measured periodically in the background:
+//
+//    client, err := mcp.NewClient(nil, mcp.WithEndpoints([]string{
+//        "https://registry.example.com",
+//        "https://eu.registry.example.com",
+//    }, nil))
+//
 // # Service Architecture
 //
 // The client follows a service-oriented architecture where different API
@@ -190,6 +391,7 @@
 //
 //    // Available services
 //    client.Servers  // Server-related operations
+//    client.Auth     // Credential introspection
 //
 // Each service provides methods for different operations:
 //
@@ -199,9 +401,103 @@
 //    ListVersionsByName(ctx, name) ([]ServerJSON, *Response, error)
 //    ListAll(ctx, opts) ([]ServerJSON, *Response, error)                        // Helper - fetches all pages
 //    ListByUpdatedSince(ctx, since) ([]ServerJSON, *Response, error)            // Helper - filters by update time
+//    ListByUpdatedBetween(ctx, from, to) ([]ServerJSON, *Response, error)       // Helper - filters by update time range
+//    CrawlByUpdateWindow(ctx, opts, fn) error                                   // Helper - checkpointed sync in time windows
 //    GetLatestVersion(ctx, name) (*ServerJSON, *Response, error)                // Helper - latest version via API
 //    GetExactVersion(ctx, name, version) (*ServerJSON, *Response, error)        // Helper - specific version via API
 //    GetLatestActiveVersion(ctx, name) (*ServerJSON, *Response, error)          // Helper - latest active by semver
+//    ResolveVersion(ctx, name, opts) (*ServerJSON, *Response, error)            // Helper - latest stable by semver, skipping inactive
+//    ResolveChannel(ctx, name, channel) (*ServerJSON, *Response, error)         // Helper - latest active in a release channel
+//    EstimateCrawl(ctx, opts) (*CrawlEstimate, *Response, error)                // Helper - sizes a full crawl
+//    Publish(ctx, server) (*ServerResponse, *Response, error)                   // Requires a registry token
+//    Update(ctx, name, version, server) (*ServerResponse, *Response, error)     // Requires a registry token
+//    Delete(ctx, name, version) (*Response, error)                              // Requires a registry token
+//    SetStatus(ctx, name, version, status) (*ServerResponse, *Response, error)  // Requires a registry token
+//    Deprecate(ctx, name, version) (*ServerResponse, *Response, error)          // Helper - sets status deprecated
+//    Undeprecate(ctx, name, version) (*ServerResponse, *Response, error)        // Helper - sets status active
+//
+//    // AdminService methods, enabled with WithAdmin
+//    SetStatus(ctx, name, version, status) (*ServerResponse, *Response, error)
+//    Takedown(ctx, name, version) (*ServerResponse, *Response, error)
+//    Restore(ctx, name, version) (*ServerResponse, *Response, error)
+//    ImportSeed(ctx, r, opts) (*SeedImport, *Response, error)                  // Publishes a seed file
+//    ListAll(ctx, opts) ([]ServerResponse, *Response, error)                    // Includes deleted entries
+//    Moderation(ctx, name, version) (*RegistryExtensions, *Response, error)
+//
+//    // AuthService methods
+//    WhoAmI(ctx) (*Identity, error)                                             // Decodes the configured token
+//
+// # Testing
+//
+// Package mcptest provides helpers for testing code built on this package.
+// FaultTransport injects realistic failures such as dropped connections and
+// 429 bursts, and Clock is a fake clock that can be installed with WithClock
+// so that rate budget waits run instantly and deterministically:
+//
+//    clock := mcptest.NewClock(time.Now())
+//    client, _ := mcp.NewClient(nil, mcp.WithClock(clock))
+//    clock.Advance(time.Minute)
+//
+// # Custom Services
+//
+// Cross-cutting behavior such as logging, caching, or header injection can
+// be added with WithMiddleware, which wraps the client's transport without
+// replacing its http.Client. Middleware sees requests after credentials have
+// been added. Static headers, such as gateway routing headers, can simply be
+// set with WithDefaultHeaders.
+//
+// To troubleshoot API mismatches, WithDebug dumps every request and
+// response, with credentials redacted, and Client.SetDebug turns dumping on
+// or off at runtime.
+//
+// Package metrics provides a Prometheus collector of request counts,
+// latencies, errors and rate limits per endpoint, installed with its
+// Option method. It is a separate module, so the SDK itself does not
+// depend on Prometheus:
+//
+//    go get github.com/lujin3/go-mcp-registry/mcp/metrics
+//
+// Middleware can label requests by endpoint with RouteFromContext.
+//
+// The request plumbing used by the built-in services is exported so that
+// additional registry extensions, such as a private collections endpoint,
+// can be implemented as services on top of Client without forking:
+//
+//    type CollectionsService struct {
+//        client *mcp.Client
+//    }
+//
+//    func (s *CollectionsService) List(ctx context.Context, opts *CollectionListOptions) (*CollectionList, *mcp.Response, error) {
+//        req, err := s.client.NewRouteRequest("list-collections", nil, opts, nil)
+//        if err != nil {
+//            return nil, nil, err
+//        }
+//
+//        var list *CollectionList
+//        resp, err := s.client.Do(ctx, req, &list)
+//        if err != nil {
+//            return nil, resp, err
+//        }
+//        return list, resp, nil
+//    }
+//
+//    client, err := mcp.NewClient(nil, mcp.WithRoutes(map[string]mcp.Route{
+//        "list-collections": {Method: http.MethodGet, Path: "collections"},
+//    }))
+//    collections := &CollectionsService{client: client}
+//
+// NewRequest and AddOptions can be used instead of routes when building
+// URLs by hand. Requests sent through Client.Do share the client's rate
+// limit tracking and error handling. DoRequest decodes the response into a
+// new value of a type parameter, which saves declaring the decode target:
+//
+//    list, resp, err := mcp.DoRequest[CollectionList](ctx, client, req)
+//
+// For one-off calls of endpoints the SDK does not wrap yet, Client.Call
+// builds and sends the request in one step:
+//
+//    var stats Stats
+//    _, err := client.Call(ctx, "GET", "v0.1/stats", nil, &stats)
 //
 // # Type Reuse
 //
@@ -224,10 +520,12 @@
 //
 //    mcp.String("value")    // Returns *string
 //    mcp.Int(42)           // Returns *int
+//    mcp.Float64(0.5)      // Returns *float64
 //    mcp.Bool(true)        // Returns *bool
 //
 //    mcp.StringValue(ptr)  // Returns string value or ""
 //    mcp.IntValue(ptr)     // Returns int value or 0
+//    mcp.Float64Value(ptr) // Returns float64 value or 0
 //    mcp.BoolValue(ptr)    // Returns bool value or false
 //
 // # Examples
@@ -0,0 +1,279 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of EndpointOptions.
+const (
+	defaultProbeInterval = time.Minute
+	defaultProbeTimeout  = 5 * time.Second
+	defaultHysteresis    = 0.2
+)
+
+// EndpointOptions specifies the optional parameters to WithEndpoints.
+type EndpointOptions struct {
+	// ProbeInterval is how often the latency of the endpoints is measured.
+	// Defaults to 1 minute.
+	ProbeInterval time.Duration
+
+	// ProbeTimeout bounds each measurement; endpoints that do not answer
+	// in time are unhealthy. Defaults to 5 seconds.
+	ProbeTimeout time.Duration
+
+	// Hysteresis is the fraction by which another endpoint must be faster
+	// than the current one for reads to move to it, so that endpoints of
+	// similar latency do not alternate. Defaults to 0.2 if nil; 0 always
+	// moves reads to the fastest endpoint.
+	Hysteresis *float64
+
+	// OnSwitch, if set, is called when reads move to another endpoint.
+	OnSwitch func(from, to *url.URL)
+}
+
+// WithEndpoints returns an Option that spreads the client over several
+// equivalent registry endpoints, such as regional mirrors. The first URL
+// becomes BaseURL and receives every write; reads (GET and HEAD requests)
+// are sent to the fastest healthy endpoint.
+//
+// The endpoints are probed with the health route when the client starts
+// reading and then every ProbeInterval, in the background. An endpoint is
+// healthy if its probe succeeds; an endpoint that fails a read with a
+// transport error or a 5xx response is unhealthy until its next successful
+// probe. Reads only move to a faster endpoint if it is faster by the
+// Hysteresis fraction, or if the current endpoint is unhealthy. While no
+// endpoint is healthy, reads go to BaseURL.
+//
+// Middleware and debug output see requests addressed to BaseURL; the
+// endpoint is chosen as they are sent. URLs must be HTTP or HTTPS URLs.
+// opts may be nil.
+func WithEndpoints(baseURLs []string, opts *EndpointOptions) Option {
+	return func(c *Client) error {
+		if len(baseURLs) == 0 {
+			return fmt.Errorf("endpoints cannot be empty")
+		}
+		if opts == nil {
+			opts = &EndpointOptions{}
+		}
+		hysteresis := defaultHysteresis
+		if opts.Hysteresis != nil {
+			hysteresis = *opts.Hysteresis
+		}
+		if hysteresis < 0 || hysteresis >= 1 {
+			return fmt.Errorf("endpoint hysteresis must be in [0, 1), got %v", hysteresis)
+		}
+
+		urls := make([]*url.URL, len(baseURLs))
+		for i, raw := range baseURLs {
+			// Validate and normalize the URL like BaseURL.
+			var parsed Client
+			if err := WithBaseURL(raw)(&parsed); err != nil {
+				return err
+			}
+			if parsed.socketPath != "" {
+				return fmt.Errorf("endpoint %q: unix sockets are not supported", raw)
+			}
+			urls[i] = parsed.BaseURL
+		}
+
+		s := &endpointSelector{
+			client:        c,
+			probeInterval: opts.ProbeInterval,
+			probeTimeout:  opts.ProbeTimeout,
+			hysteresis:    hysteresis,
+			onSwitch:      opts.OnSwitch,
+		}
+		if s.probeInterval <= 0 {
+			s.probeInterval = defaultProbeInterval
+		}
+		if s.probeTimeout <= 0 {
+			s.probeTimeout = defaultProbeTimeout
+		}
+		for _, u := range urls {
+			s.endpoints = append(s.endpoints, &endpoint{url: u})
+		}
+
+		c.BaseURL = urls[0]
+		c.endpoints = s
+		return nil
+	}
+}
+
+// endpoint is a registry endpoint of an endpointSelector.
+type endpoint struct {
+	url      *url.URL
+	latency  time.Duration // smoothed probe latency
+	measured bool          // whether latency holds a measurement
+	healthy  bool
+}
+
+// endpointSelector routes reads to the fastest healthy endpoint configured
+// with WithEndpoints.
+type endpointSelector struct {
+	client        *Client
+	next          http.RoundTripper
+	probeInterval time.Duration
+	probeTimeout  time.Duration
+	hysteresis    float64
+	onSwitch      func(from, to *url.URL)
+
+	mu        sync.Mutex
+	endpoints []*endpoint // endpoints[0] is BaseURL
+	current   int
+	lastProbe time.Time
+	probing   bool
+}
+
+// wrap returns a transport sending reads through the selector and
+// everything else unchanged through next.
+func (s *endpointSelector) wrap(next http.RoundTripper) http.RoundTripper {
+	s.next = next
+	return RoundTripperFunc(s.roundTrip)
+}
+
+// roundTrip sends req to the current endpoint if it is a read addressed to
+// BaseURL.
+func (s *endpointSelector) roundTrip(req *http.Request) (*http.Response, error) {
+	primary := s.endpoints[0].url.String()
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || !strings.HasPrefix(req.URL.String(), primary) {
+		return s.next.RoundTrip(req)
+	}
+
+	s.mu.Lock()
+	if !s.probing && !s.client.clock.Now().Before(s.lastProbe.Add(s.probeInterval)) {
+		s.probing = true
+		go s.probe(context.Background())
+	}
+	e := s.endpoints[s.current]
+	s.mu.Unlock()
+
+	out := req
+	if e != s.endpoints[0] {
+		u, err := url.Parse(e.url.String() + strings.TrimPrefix(req.URL.String(), primary))
+		if err != nil {
+			return nil, err
+		}
+		out = req.Clone(req.Context())
+		out.URL = u
+		out.Host = ""
+	}
+
+	resp, err := s.next.RoundTrip(out)
+	if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+		s.mu.Lock()
+		e.healthy = false
+		switched := s.selectLocked()
+		s.mu.Unlock()
+		switched()
+	}
+	return resp, err
+}
+
+// probe measures the latency and health of every endpoint and selects the
+// endpoint for reads.
+func (s *endpointSelector) probe(ctx context.Context) {
+	type result struct {
+		latency time.Duration
+		healthy bool
+	}
+	results := make([]result, len(s.endpoints))
+	// Without a health route, every endpoint is unhealthy.
+	path, pathErr := s.client.RoutePath(RouteGetHealth, nil, nil)
+	var wg sync.WaitGroup
+	for i, e := range s.endpoints {
+		if pathErr != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, s.probeTimeout)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url.String()+path, nil)
+			if err != nil {
+				return
+			}
+			req.Header.Set("User-Agent", s.client.UserAgent)
+			start := time.Now()
+			resp, err := s.next.RoundTrip(req)
+			if err != nil {
+				return
+			}
+			resp.Body.Close()
+			results[i] = result{latency: time.Since(start), healthy: resp.StatusCode < http.StatusMultipleChoices}
+		}()
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	for i, e := range s.endpoints {
+		r := results[i]
+		e.healthy = r.healthy
+		if !r.healthy {
+			continue
+		}
+		if e.measured {
+			e.latency = (e.latency + r.latency) / 2
+		} else {
+			e.latency, e.measured = r.latency, true
+		}
+	}
+	s.lastProbe = s.client.clock.Now()
+	s.probing = false
+	switched := s.selectLocked()
+	s.mu.Unlock()
+	switched()
+}
+
+// selectLocked moves reads to the fastest healthy endpoint, if it is faster
+// than the current one by the hysteresis fraction or the current one is
+// unhealthy. It returns a function reporting the switch to OnSwitch, to be
+// called once s.mu is released. s.mu must be held.
+func (s *endpointSelector) selectLocked() func() {
+	best := -1
+	for i, e := range s.endpoints {
+		if e.healthy && (best < 0 || e.latency < s.endpoints[best].latency) {
+			best = i
+		}
+	}
+
+	current := s.endpoints[s.current]
+	switch {
+	case best < 0:
+		best = 0
+	case current.healthy && best != s.current &&
+		float64(s.endpoints[best].latency) >= float64(current.latency)*(1-s.hysteresis):
+		return func() {}
+	}
+	if best == s.current || s.onSwitch == nil {
+		s.current = best
+		return func() {}
+	}
+
+	from, to := current.url, s.endpoints[best].url
+	s.current = best
+	return func() { s.onSwitch(from, to) }
+}
+
+// applyEndpoints replaces the client's http.Client with a copy whose
+// transport sends reads to the endpoint chosen by the client's selector.
+func (c *Client) applyEndpoints() error {
+	if c.BaseURL != c.endpoints.endpoints[0].url {
+		return fmt.Errorf("WithBaseURL cannot be combined with WithEndpoints")
+	}
+	transport := c.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	client := *c.client
+	client.Transport = c.endpoints.wrap(transport)
+	c.client = &client
+	return nil
+}
@@ -0,0 +1,192 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+// endpointServer is a registry endpoint for WithEndpoints tests.
+type endpointServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	delay   time.Duration // delay of health probes
+	failing bool          // whether reads fail with 503
+	methods []string      // methods of the non-probe requests received
+}
+
+func newEndpointServer(delay time.Duration) *endpointServer {
+	s := &endpointServer{delay: delay}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		delay, failing := s.delay, s.failing
+		if r.URL.Path != "/v0.1/health" {
+			s.methods = append(s.methods, r.Method)
+		}
+		s.mu.Unlock()
+
+		if r.URL.Path == "/v0.1/health" {
+			time.Sleep(delay)
+			fmt.Fprint(w, `{"status":"ok"}`)
+			return
+		}
+		if failing && r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	}))
+	return s
+}
+
+func (s *endpointServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	methods := s.methods
+	s.methods = nil
+	return methods
+}
+
+func TestWithEndpoints(t *testing.T) {
+	primary := newEndpointServer(50 * time.Millisecond)
+	defer primary.Close()
+	mirror := newEndpointServer(0)
+	defer mirror.Close()
+
+	var switches []string
+	client, err := NewClient(nil,
+		WithClock(mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))),
+		WithEndpoints([]string{primary.URL, mirror.URL}, &EndpointOptions{
+			OnSwitch: func(from, to *url.URL) { switches = append(switches, from.Host+" -> "+to.Host) },
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if got, want := client.BaseURL.String(), primary.URL+"/"; got != want {
+		t.Errorf("BaseURL = %q, want %q", got, want)
+	}
+
+	// Probe now, so the test clock keeps reads from starting another probe.
+	ctx := context.Background()
+	client.endpoints.probe(ctx)
+	if want := primary.Listener.Addr().String() + " -> " + mirror.Listener.Addr().String(); len(switches) != 1 || switches[0] != want {
+		t.Errorf("switches = %v, want [%s]", switches, want)
+	}
+
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	req, err := client.NewRequest(http.MethodPost, "v0.1/publish", struct{}{})
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got := mirror.received(); len(got) != 1 || got[0] != http.MethodGet {
+		t.Errorf("mirror received %v, want the read", got)
+	}
+	if got := primary.received(); len(got) != 1 || got[0] != http.MethodPost {
+		t.Errorf("primary received %v, want the write", got)
+	}
+
+	// A failing mirror sends reads back to the primary.
+	mirror.mu.Lock()
+	mirror.failing = true
+	mirror.mu.Unlock()
+	if _, _, err := client.Servers.List(ctx, nil); err == nil {
+		t.Fatal("List from the failing mirror returned no error")
+	}
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got := primary.received(); len(got) != 1 {
+		t.Errorf("primary received %v after the mirror failed, want the read", got)
+	}
+}
+
+func TestEndpointSelector_hysteresis(t *testing.T) {
+	s := &endpointSelector{hysteresis: 0.2}
+	for _, latency := range []time.Duration{100, 90, 70} {
+		s.endpoints = append(s.endpoints, &endpoint{latency: latency * time.Millisecond, measured: true, healthy: true})
+	}
+
+	s.selectLocked()
+	if s.current != 2 {
+		t.Fatalf("current = %d, want the fastest endpoint 2", s.current)
+	}
+
+	// Endpoint 1 becomes slightly faster than endpoint 2: reads stay.
+	s.endpoints[1].latency = 65 * time.Millisecond
+	s.selectLocked()
+	if s.current != 2 {
+		t.Errorf("current = %d after a small change, want 2", s.current)
+	}
+
+	// Endpoint 1 becomes much faster: reads move.
+	s.endpoints[1].latency = 50 * time.Millisecond
+	s.selectLocked()
+	if s.current != 1 {
+		t.Errorf("current = %d after a large change, want 1", s.current)
+	}
+
+	// Without hysteresis, reads move to any faster endpoint.
+	s.hysteresis = 0
+	s.endpoints[2].latency = 49 * time.Millisecond
+	s.selectLocked()
+	if s.current != 2 {
+		t.Errorf("current = %d without hysteresis, want 2", s.current)
+	}
+
+	// No healthy endpoint: reads go to the primary.
+	for _, e := range s.endpoints {
+		e.healthy = false
+	}
+	s.selectLocked()
+	if s.current != 0 {
+		t.Errorf("current = %d without healthy endpoints, want 0", s.current)
+	}
+}
+
+func TestWithEndpoints_invalid(t *testing.T) {
+	tests := map[string][]Option{
+		"empty":      {WithEndpoints(nil, nil)},
+		"scheme":     {WithEndpoints([]string{"ftp://example.com"}, nil)},
+		"socket":     {WithEndpoints([]string{"unix:///tmp/registry.sock"}, nil)},
+		"hysteresis": {WithEndpoints([]string{"https://example.com"}, &EndpointOptions{Hysteresis: Float64(1)})},
+		"base URL":   {WithEndpoints([]string{"https://example.com"}, nil), WithBaseURL("https://other.example.com")},
+	}
+	for name, opts := range tests {
+		if _, err := NewClient(nil, opts...); err == nil {
+			t.Errorf("%s: NewClient returned no error", name)
+		}
+	}
+}
+
+func TestWithEndpoints_hysteresis(t *testing.T) {
+	for _, tt := range []struct {
+		hysteresis *float64
+		want       float64
+	}{
+		{nil, defaultHysteresis},
+		{Float64(0), 0},
+		{Float64(0.5), 0.5},
+	} {
+		client, err := NewClient(nil, WithEndpoints([]string{"https://example.com"}, &EndpointOptions{Hysteresis: tt.hysteresis}))
+		if err != nil {
+			t.Fatalf("NewClient returned error: %v", err)
+		}
+		if got := client.endpoints.hysteresis; got != tt.want {
+			t.Errorf("hysteresis %v: selector uses %v, want %v", Float64Value(tt.hysteresis), got, tt.want)
+		}
+	}
+}
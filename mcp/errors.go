@@ -2,17 +2,65 @@ package mcp
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 )
 
+// Sentinel errors matching API errors by status code with errors.Is, so
+// callers need not inspect the status of an *ErrorResponse:
+//
+//	if errors.Is(err, mcp.ErrNotFound) { ... }
+//
+// They match *ErrorResponse, *RateLimitError and the errors wrapping them,
+// such as *NotFoundError and *ForbiddenError.
+var (
+	ErrBadRequest   = errors.New("bad request")           // 400
+	ErrUnauthorized = errors.New("unauthorized")          // 401
+	ErrForbidden    = errors.New("forbidden")             // 403
+	ErrNotFound     = errors.New("not found")             // 404
+	ErrConflict     = errors.New("conflict")              // 409
+	ErrInvalid      = errors.New("unprocessable entity")  // 422
+	ErrRateLimited  = errors.New("rate limited")          // 429
+	ErrServer       = errors.New("registry server error") // 5xx
+)
+
+// statusError returns the sentinel error matching the HTTP status code, or
+// nil if there is none.
+func statusError(code int) error {
+	switch code {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnprocessableEntity:
+		return ErrInvalid
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	if code >= 500 && code <= 599 {
+		return ErrServer
+	}
+	return nil
+}
+
 // ErrorResponse represents an error response from the MCP Registry API.
 type ErrorResponse struct {
 	Response *http.Response // HTTP response that caused this error
 	Message  string         `json:"message,omitempty"`
 	Errors   []Error        `json:"errors,omitempty"`
+
+	// RequestID is the identifier the registry assigned to the request,
+	// as in Response.RequestID.
+	RequestID string `json:"-"`
 }
 
 // Error represents a single error detail in an API response.
@@ -25,20 +73,34 @@ type Error struct {
 
 func (r *ErrorResponse) Error() string {
 	if r.Message != "" {
-		return fmt.Sprintf("%v %v: %d %v",
+		return fmt.Sprintf("%v %v: %d %v%s",
 			r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-			r.Response.StatusCode, r.Message)
+			r.Response.StatusCode, r.Message, requestIDSuffix(r.RequestID))
 	}
 
 	if len(r.Errors) > 0 {
-		return fmt.Sprintf("%v %v: %d %+v",
+		return fmt.Sprintf("%v %v: %d %+v%s",
 			r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-			r.Response.StatusCode, r.Errors)
+			r.Response.StatusCode, r.Errors, requestIDSuffix(r.RequestID))
 	}
 
-	return fmt.Sprintf("%v %v: %d",
+	return fmt.Sprintf("%v %v: %d%s",
 		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-		r.Response.StatusCode)
+		r.Response.StatusCode, requestIDSuffix(r.RequestID))
+}
+
+// Is reports whether target is the sentinel error for the status code of
+// the response, such as ErrNotFound for a 404.
+func (r *ErrorResponse) Is(target error) bool {
+	return r.Response != nil && target != nil && statusError(r.Response.StatusCode) == target
+}
+
+// requestIDSuffix returns the request ID annotation of error messages.
+func requestIDSuffix(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " [request ID " + id + "]"
 }
 
 // RateLimitError occurs when the API rate limit is exceeded.
@@ -46,17 +108,25 @@ type RateLimitError struct {
 	Rate     Rate           // Rate specifies the current rate limit information
 	Response *http.Response // HTTP response that caused this error
 	Message  string         `json:"message,omitempty"`
+
+	// RequestID is the identifier the registry assigned to the request,
+	// as in Response.RequestID.
+	RequestID string
 }
 
 func (r *RateLimitError) Error() string {
-	return fmt.Sprintf("%v %v: %d %v (rate limit: %d/%d, reset at %v)",
+	return fmt.Sprintf("%v %v: %d %v (rate limit: %d/%d, reset at %v)%s",
 		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
 		r.Response.StatusCode, r.Message,
-		r.Rate.Remaining, r.Rate.Limit, r.Rate.Reset)
+		r.Rate.Remaining, r.Rate.Limit, r.Rate.Reset, requestIDSuffix(r.RequestID))
 }
 
-// Is returns whether the provided error equals this error.
+// Is returns whether the provided error equals this error. Every
+// RateLimitError matches ErrRateLimited.
 func (r *RateLimitError) Is(target error) bool {
+	if target == ErrRateLimited {
+		return true
+	}
 	v, ok := target.(*RateLimitError)
 	if !ok {
 		return false
@@ -69,6 +139,83 @@ func (r *RateLimitError) Is(target error) bool {
 		sanitizeURL(r.Response.Request.URL) == sanitizeURL(v.Response.Request.URL)
 }
 
+// NotFoundError occurs when a server version does not exist in the registry.
+// ErrorResponse is nil if the server was looked up by listing rather than
+// by a request that failed with 404, as with WithNotFoundPolicy.
+type NotFoundError struct {
+	*ErrorResponse
+
+	Name    string // Name of the server
+	Version string // Version of the server, or "" for any version
+
+	// Suggestions are names of similar servers, the closest first, if the
+	// client is configured with WithNotFoundSuggestions.
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	msg := "server " + e.Name
+	if e.Version != "" {
+		msg += " version " + e.Version
+	}
+	msg += " not found"
+	if len(e.Suggestions) > 0 {
+		msg += " (did you mean " + joinOr(e.Suggestions) + "?)"
+	}
+	if e.ErrorResponse != nil {
+		msg += ": " + e.ErrorResponse.Error()
+	}
+	return msg
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Unwrap returns the underlying ErrorResponse, if any.
+func (e *NotFoundError) Unwrap() error {
+	if e.ErrorResponse == nil {
+		return nil
+	}
+	return e.ErrorResponse
+}
+
+// ForbiddenError occurs when the credentials of a request do not grant
+// permission for the operation on a server.
+type ForbiddenError struct {
+	*ErrorResponse
+
+	Name string // Name of the server
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("permission denied for server %s: %v", e.Name, e.ErrorResponse)
+}
+
+// Unwrap returns the underlying ErrorResponse.
+func (e *ForbiddenError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// serverError converts an *ErrorResponse for the given server version into a
+// *NotFoundError or *ForbiddenError where applicable. Other errors are
+// returned unchanged.
+func serverError(err error, name, version string) error {
+	errResp, ok := err.(*ErrorResponse)
+	if !ok || errResp.Response == nil {
+		return err
+	}
+
+	switch errResp.Response.StatusCode {
+	case http.StatusNotFound:
+		return &NotFoundError{ErrorResponse: errResp, Name: name, Version: version}
+	case http.StatusForbidden:
+		return &ForbiddenError{ErrorResponse: errResp, Name: name}
+	}
+	return err
+}
+
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
 // API error responses are expected to have either no response body, or a JSON
@@ -78,7 +225,7 @@ func CheckResponse(r *http.Response) error {
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{Response: r, RequestID: parseRequestID(r)}
 	data, err := io.ReadAll(r.Body)
 	if err == nil && data != nil && len(data) > 0 {
 		if err := json.Unmarshal(data, errorResponse); err != nil {
@@ -90,9 +237,10 @@ func CheckResponse(r *http.Response) error {
 	// Check for rate limit error
 	if r.StatusCode == http.StatusTooManyRequests {
 		return &RateLimitError{
-			Rate:     parseRate(r),
-			Response: r,
-			Message:  errorResponse.Message,
+			Rate:      parseRate(r),
+			Response:  r,
+			Message:   errorResponse.Message,
+			RequestID: errorResponse.RequestID,
 		}
 	}
 
@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// Defaults used by EstimateCrawl.
+const (
+	defaultEstimateSamplePages = 3
+	defaultEstimatePageSize    = 100
+)
+
+// nameAlphabet is the ordered set of characters used when mapping server
+// names onto [0, 1) to extrapolate crawl sizes from a sample.
+const nameAlphabet = "-./0123456789_abcdefghijklmnopqrstuvwxyz"
+
+// CrawlEstimateOptions specifies the optional parameters to the
+// ServersService.EstimateCrawl method.
+type CrawlEstimateOptions struct {
+	// SamplePages is the number of pages fetched to build the estimate.
+	// Defaults to 3.
+	SamplePages int
+
+	// PageSize is the page size used for sampling and for the estimated
+	// number of requests. Defaults to 100.
+	PageSize int
+
+	// ListOptions filters the crawl being estimated, e.g. with Search or
+	// UpdatedSince. Its pagination fields are ignored.
+	ListOptions ServerListOptions
+}
+
+// CrawlEstimate describes the expected size of a full crawl of the registry,
+// as performed by ListAll.
+type CrawlEstimate struct {
+	// SampledPages and SampledEntries describe the sample taken.
+	SampledPages   int
+	SampledEntries int
+
+	// Exact reports whether the sample covered the whole crawl, in which
+	// case the estimate is exact.
+	Exact bool
+
+	// Entries is the estimated number of server versions returned.
+	Entries int
+
+	// Servers is the estimated number of distinct server names.
+	Servers int
+
+	// VersionsPerServer is the average number of versions per server name
+	// observed in the sample.
+	VersionsPerServer float64
+
+	// AvgEntryBytes is the average JSON size of one entry in the sample.
+	AvgEntryBytes int
+
+	// PayloadBytes is the estimated JSON payload size of the full crawl.
+	PayloadBytes int64
+
+	// Requests is the estimated number of list requests needed at PageSize.
+	Requests int
+}
+
+// EstimateCrawl samples the first pages of the server list to estimate the
+// number of servers, versions, payload bytes, and requests a full crawl would
+// take, so operators can plan memory, storage, and rate limit budgets before
+// running sync jobs.
+//
+// The registry does not report total counts, so when the sample does not
+// reach the end of the list the totals are extrapolated from how far through
+// the name-ordered list the sample got. The result is a rough estimate
+// intended for capacity planning; Exact reports whether no extrapolation was
+// needed.
+func (s *ServersService) EstimateCrawl(ctx context.Context, opts *CrawlEstimateOptions, reqOpts ...RequestOption) (*CrawlEstimate, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
+	if opts == nil {
+		opts = &CrawlEstimateOptions{}
+	}
+	samplePages := opts.SamplePages
+	if samplePages <= 0 {
+		samplePages = defaultEstimateSamplePages
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultEstimatePageSize
+	}
+
+	listOpts := opts.ListOptions
+	listOpts.Limit = pageSize
+	listOpts.Cursor = ""
+
+	est := &CrawlEstimate{}
+	names := make(map[string]bool)
+	var totalBytes int64
+	var firstName, lastName string
+	var lastResp *Response
+
+	for est.SampledPages < samplePages {
+		list, resp, err := s.List(ctx, &listOpts)
+		if err != nil {
+			return nil, resp, err
+		}
+		lastResp = resp
+		est.SampledPages++
+
+		for _, serverResponse := range list.Servers {
+			if firstName == "" {
+				firstName = serverResponse.Server.Name
+			}
+			lastName = serverResponse.Server.Name
+			names[serverResponse.Server.Name] = true
+			est.SampledEntries++
+
+			if data, err := json.Marshal(serverResponse); err == nil {
+				totalBytes += int64(len(data))
+			}
+		}
+
+		if list.Metadata.NextCursor == "" {
+			est.Exact = true
+			break
+		}
+		listOpts.Cursor = list.Metadata.NextCursor
+	}
+
+	if est.SampledEntries == 0 {
+		est.Exact = true
+		est.Requests = est.SampledPages
+		return est, lastResp, nil
+	}
+
+	est.VersionsPerServer = float64(est.SampledEntries) / float64(len(names))
+	est.AvgEntryBytes = int(totalBytes / int64(est.SampledEntries))
+
+	est.Entries = est.SampledEntries
+	if !est.Exact {
+		if covered := nameSpan(firstName, lastName); covered > 0 && covered < 1 {
+			est.Entries = int(math.Ceil(float64(est.SampledEntries) / covered))
+		}
+	}
+
+	est.Servers = int(math.Ceil(float64(est.Entries) / est.VersionsPerServer))
+	est.PayloadBytes = int64(est.Entries) * int64(est.AvgEntryBytes)
+	est.Requests = int(math.Ceil(float64(est.Entries) / float64(pageSize)))
+	if est.Exact {
+		est.Requests = est.SampledPages
+	}
+
+	return est, lastResp, nil
+}
+
+// nameSpan returns the fraction of the remaining name space, starting at
+// first, that lies before last.
+func nameSpan(first, last string) float64 {
+	start := namePosition(first)
+	if start >= 1 {
+		return 0
+	}
+	return (namePosition(last) - start) / (1 - start)
+}
+
+// namePosition maps name onto [0, 1) preserving lexical order of the
+// characters that appear in server names.
+func namePosition(name string) float64 {
+	base := float64(len(nameAlphabet) + 1)
+	pos, scale := 0.0, 1.0
+	for i := 0; i < len(name) && i < 8; i++ {
+		scale /= base
+		idx := strings.IndexByte(nameAlphabet, lowerASCII(name[i]))
+		pos += float64(idx+1) * scale
+	}
+	return pos
+}
+
+// lowerASCII lowercases an ASCII letter.
+func lowerASCII(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b + ('a' - 'A')
+	}
+	return b
+}
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestServersService_EstimateCrawl_Exact(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("cursor") == "" {
+			testFormValues(t, r, values{"limit": "2", "search": "mail"})
+			fmt.Fprint(w, `{"servers":[
+				{"server":{"name":"com.example/mail","version":"1.0.0"}},
+				{"server":{"name":"com.example/mail","version":"2.0.0"}}
+			],"metadata":{"nextCursor":"next","count":2}}`)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"com.example/mailer","version":"1.0.0"}}
+		],"metadata":{"count":1}}`)
+	})
+
+	est, _, err := client.Servers.EstimateCrawl(context.Background(), &CrawlEstimateOptions{
+		PageSize:    2,
+		ListOptions: ServerListOptions{Search: "mail"},
+	})
+	if err != nil {
+		t.Fatalf("EstimateCrawl returned error: %v", err)
+	}
+
+	if !est.Exact {
+		t.Error("EstimateCrawl Exact = false, want true")
+	}
+	if est.SampledPages != 2 || est.Entries != 3 || est.Servers != 2 || est.Requests != 2 {
+		t.Errorf("EstimateCrawl = %+v, want 2 pages, 3 entries, 2 servers, 2 requests", est)
+	}
+	if est.VersionsPerServer != 1.5 {
+		t.Errorf("EstimateCrawl VersionsPerServer = %v, want 1.5", est.VersionsPerServer)
+	}
+	if est.AvgEntryBytes <= 0 || est.PayloadBytes != int64(est.AvgEntryBytes*3) {
+		t.Errorf("EstimateCrawl bytes = %d avg, %d total; want consistent positive values", est.AvgEntryBytes, est.PayloadBytes)
+	}
+}
+
+func TestServersService_EstimateCrawl_Extrapolated(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"a.example/one","version":"1.0.0"}},
+			{"server":{"name":"f.example/two","version":"1.0.0"}}
+		],"metadata":{"nextCursor":"more","count":2}}`)
+	})
+
+	est, _, err := client.Servers.EstimateCrawl(context.Background(), &CrawlEstimateOptions{SamplePages: 1})
+	if err != nil {
+		t.Fatalf("EstimateCrawl returned error: %v", err)
+	}
+
+	if est.Exact {
+		t.Error("EstimateCrawl Exact = true, want false")
+	}
+	if est.SampledEntries != 2 || est.Entries <= est.SampledEntries {
+		t.Errorf("EstimateCrawl Entries = %d from %d sampled, want extrapolated total", est.Entries, est.SampledEntries)
+	}
+	if est.Requests < 1 {
+		t.Errorf("EstimateCrawl Requests = %d, want at least 1", est.Requests)
+	}
+}
+
+func TestServersService_EstimateCrawl_Empty(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[],"metadata":{"count":0}}`)
+	})
+
+	est, _, err := client.Servers.EstimateCrawl(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("EstimateCrawl returned error: %v", err)
+	}
+
+	if !est.Exact || est.Entries != 0 || est.Requests != 1 {
+		t.Errorf("EstimateCrawl = %+v, want exact empty estimate", est)
+	}
+}
+
+func TestNamePosition_Ordered(t *testing.T) {
+	names := []string{"ai.example/a", "com.example/b", "io.github.user/c", "zz/top"}
+	for i := 1; i < len(names); i++ {
+		if namePosition(names[i-1]) >= namePosition(names[i]) {
+			t.Errorf("namePosition(%q) >= namePosition(%q)", names[i-1], names[i])
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// minForecastRequests is the number of requests a rate limit window must
+// have used before its consumption rate is extrapolated.
+const minForecastRequests = 5
+
+// RateForecast warns that the current request rate will exhaust the rate
+// limit before it resets.
+type RateForecast struct {
+	// Rate is the rate limit reported by the latest response.
+	Rate Rate
+
+	// RequestRate is the observed request rate in the current window, in
+	// requests per second.
+	RequestRate float64
+
+	// ExhaustedAt is when the rate limit runs out at RequestRate.
+	ExhaustedAt time.Time
+
+	// SustainableRate is the request rate, in requests per second, that
+	// lasts until the rate limit resets.
+	SustainableRate float64
+}
+
+// RateForecastHook is called when a RateForecast is made.
+type RateForecastHook func(RateForecast)
+
+// WithRateForecast returns an Option that watches the rate limit reported
+// by responses and calls hook when the request rate observed since the
+// window started will exhaust it before it resets, so batch jobs can slow
+// down before requests fail with a RateLimitError. The hook is called at
+// most once per rate limit window, synchronously from Client.Do, and
+// should return quickly.
+//
+// The forecast follows a single rate limit window; it suits registries that
+// apply one limit to all requests of a client.
+func WithRateForecast(hook RateForecastHook) Option {
+	return func(c *Client) error {
+		if hook == nil {
+			return fmt.Errorf("rate forecast hook cannot be nil")
+		}
+		c.rateForecaster = &rateForecaster{hook: hook}
+		return nil
+	}
+}
+
+// LogRateForecasts returns a RateForecastHook that logs forecasts to logger
+// as warnings.
+func LogRateForecasts(logger *slog.Logger) RateForecastHook {
+	return func(f RateForecast) {
+		logger.Warn("registry rate limit will be exhausted before reset",
+			slog.Int("limit", f.Rate.Limit),
+			slog.Int("remaining", f.Rate.Remaining),
+			slog.Time("reset", f.Rate.Reset),
+			slog.Time("exhausted_at", f.ExhaustedAt),
+			slog.Float64("request_rate", f.RequestRate),
+			slog.Float64("sustainable_rate", f.SustainableRate),
+		)
+	}
+}
+
+// rateForecaster tracks the consumption of the current rate limit window.
+type rateForecaster struct {
+	hook RateForecastHook
+
+	mu             sync.Mutex
+	reset          time.Time // reset time identifying the window
+	start          time.Time // first observation of the window
+	startRemaining int
+	remaining      int
+	warned         bool
+}
+
+// observe records rate, reported by a response received at now, and calls
+// the hook if the window will be exhausted before it resets.
+func (f *rateForecaster) observe(rate Rate, now time.Time) {
+	if rate.Limit == 0 || rate.Reset.IsZero() {
+		return
+	}
+
+	f.mu.Lock()
+	if !rate.Reset.Equal(f.reset) || rate.Remaining > f.remaining {
+		f.reset, f.start = rate.Reset, now
+		f.startRemaining, f.remaining = rate.Remaining, rate.Remaining
+		f.warned = false
+		f.mu.Unlock()
+		return
+	}
+	f.remaining = rate.Remaining
+
+	used := f.startRemaining - rate.Remaining
+	elapsed := now.Sub(f.start)
+	if f.warned || used < minForecastRequests || elapsed <= 0 {
+		f.mu.Unlock()
+		return
+	}
+	requestRate := float64(used) / elapsed.Seconds()
+	exhaustedAt := now.Add(time.Duration(float64(rate.Remaining) / requestRate * float64(time.Second)))
+	if !exhaustedAt.Before(rate.Reset) {
+		f.mu.Unlock()
+		return
+	}
+	f.warned = true
+	f.mu.Unlock()
+
+	forecast := RateForecast{Rate: rate, RequestRate: requestRate, ExhaustedAt: exhaustedAt}
+	if untilReset := rate.Reset.Sub(now); untilReset > 0 {
+		forecast.SustainableRate = float64(rate.Remaining) / untilReset.Seconds()
+	}
+	f.hook(forecast)
+}
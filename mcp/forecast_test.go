@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func TestWithRateForecast(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := mcptest.NewClock(start)
+	reset := start.Add(time.Minute)
+	var forecasts []RateForecast
+	for _, opt := range []Option{WithClock(clock), WithRateForecast(func(f RateForecast) { forecasts = append(forecasts, f) })} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	remaining := 30
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		// Each request takes a second and uses one of 30 requests per minute.
+		clock.Advance(time.Second)
+		remaining--
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+		w.Header().Set("X-RateLimit-Reset", reset.Format(time.RFC3339))
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	for range 10 {
+		if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+
+	if len(forecasts) != 1 {
+		t.Fatalf("got %d forecasts, want 1", len(forecasts))
+	}
+	f := forecasts[0]
+	// Warned after 5 requests used in 5 seconds, with 24 remaining.
+	if f.Rate.Remaining != 24 || f.RequestRate != 1 {
+		t.Errorf("forecast = %+v, want 24 remaining at 1 request per second", f)
+	}
+	if want := start.Add(30 * time.Second); !f.ExhaustedAt.Equal(want) {
+		t.Errorf("ExhaustedAt = %v, want %v", f.ExhaustedAt, want)
+	}
+	if want := 24.0 / 54; f.SustainableRate != want {
+		t.Errorf("SustainableRate = %v, want %v", f.SustainableRate, want)
+	}
+
+	if err := WithRateForecast(nil)(client); err == nil {
+		t.Error("WithRateForecast(nil) returned no error")
+	}
+}
+
+func TestRateForecaster_observe(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var forecasts int
+	f := &rateForecaster{hook: func(RateForecast) { forecasts++ }}
+
+	// 10 requests a minute last until the reset in an hour.
+	reset := start.Add(time.Hour)
+	for i := range 10 {
+		f.observe(Rate{Limit: 1000, Remaining: 1000 - i, Reset: reset}, start.Add(time.Duration(i)*6*time.Second))
+	}
+	if forecasts != 0 {
+		t.Errorf("got %d forecasts for a sustainable rate, want 0", forecasts)
+	}
+
+	// 10 requests a second do not, and are warned about once per window.
+	for i := range 20 {
+		f.observe(Rate{Limit: 1000, Remaining: 1000 - i, Reset: reset.Add(time.Hour)}, start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	if forecasts != 1 {
+		t.Errorf("got %d forecasts, want 1", forecasts)
+	}
+	for i := range 20 {
+		f.observe(Rate{Limit: 1000, Remaining: 1000 - i, Reset: reset.Add(2 * time.Hour)}, start.Add(time.Duration(i)*100*time.Millisecond))
+	}
+	if forecasts != 2 {
+		t.Errorf("got %d forecasts after the window reset, want 2", forecasts)
+	}
+}
+
+func TestLogRateForecasts(t *testing.T) {
+	var buf bytes.Buffer
+	hook := LogRateForecasts(slog.New(slog.NewTextHandler(&buf, nil)))
+	hook(RateForecast{Rate: Rate{Limit: 30, Remaining: 24}, RequestRate: 1})
+
+	for _, want := range []string{"level=WARN", "limit=30", "remaining=24", "request_rate=1"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log %q does not contain %q", buf.String(), want)
+		}
+	}
+}
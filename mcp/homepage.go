@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"net/url"
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Homepage source values reported on Homepage.Source.
+const (
+	HomepageSourceWebsite    = "website"
+	HomepageSourcePublisher  = "publisher"
+	HomepageSourceRepository = "repository"
+	HomepageSourceRemote     = "remote"
+)
+
+// homepageMetaKeys lists the publisher-provided metadata keys inspected for
+// a homepage, in order of preference.
+var homepageMetaKeys = []string{"homepage", "homepageUrl", "website", "websiteUrl"}
+
+// Homepage represents the homepage URL derived for a server.
+type Homepage struct {
+	// URL is the homepage URL.
+	URL string
+
+	// Source describes the field the URL was derived from.
+	Source string
+}
+
+// ResolveHomepage derives a homepage URL for server from its available fields.
+// The precedence is:
+//
+//  1. The server's websiteUrl field
+//  2. A homepage or website URL in the publisher-provided metadata
+//  3. The repository URL
+//  4. The scheme and host of the first remote transport URL
+//
+// Only absolute HTTP or HTTPS URLs are considered.
+// Returns nil if no homepage can be derived.
+func ResolveHomepage(server *registryv0.ServerJSON) *Homepage {
+	if server == nil {
+		return nil
+	}
+
+	if isWebURL(server.WebsiteURL) {
+		return &Homepage{URL: server.WebsiteURL, Source: HomepageSourceWebsite}
+	}
+
+	if server.Meta != nil && server.Meta.PublisherProvided != nil {
+		for _, key := range homepageMetaKeys {
+			if v, ok := server.Meta.PublisherProvided[key].(string); ok && isWebURL(v) {
+				return &Homepage{URL: v, Source: HomepageSourcePublisher}
+			}
+		}
+	}
+
+	if server.Repository.URL != "" {
+		if info, err := ParseRepository(server.Repository.URL); err == nil && info.Forge != ForgeOther {
+			return &Homepage{URL: info.URL(), Source: HomepageSourceRepository}
+		}
+		if repo := strings.TrimSuffix(server.Repository.URL, ".git"); isWebURL(repo) {
+			return &Homepage{URL: repo, Source: HomepageSourceRepository}
+		}
+	}
+
+	for _, remote := range server.Remotes {
+		if !isWebURL(remote.URL) {
+			continue
+		}
+		u, _ := url.Parse(remote.URL)
+		return &Homepage{URL: u.Scheme + "://" + u.Host, Source: HomepageSourceRemote}
+	}
+
+	return nil
+}
+
+// isWebURL reports whether s is an absolute HTTP or HTTPS URL.
+func isWebURL(s string) bool {
+	if s == "" {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
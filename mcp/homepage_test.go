@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"reflect"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestResolveHomepage(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *registryv0.ServerJSON
+		want   *Homepage
+	}{
+		{
+			name:   "nil server",
+			server: nil,
+			want:   nil,
+		},
+		{
+			name: "website field wins",
+			server: &registryv0.ServerJSON{
+				WebsiteURL: "https://example.com",
+				Repository: model.Repository{URL: "https://github.com/example/repo"},
+			},
+			want: &Homepage{URL: "https://example.com", Source: HomepageSourceWebsite},
+		},
+		{
+			name: "publisher metadata",
+			server: &registryv0.ServerJSON{
+				Meta: &registryv0.ServerMeta{
+					PublisherProvided: map[string]any{"homepage": "https://docs.example.com"},
+				},
+				Repository: model.Repository{URL: "https://github.com/example/repo"},
+			},
+			want: &Homepage{URL: "https://docs.example.com", Source: HomepageSourcePublisher},
+		},
+		{
+			name: "repository fallback strips .git",
+			server: &registryv0.ServerJSON{
+				WebsiteURL: "not a url",
+				Repository: model.Repository{URL: "https://github.com/example/repo.git"},
+			},
+			want: &Homepage{URL: "https://github.com/example/repo", Source: HomepageSourceRepository},
+		},
+		{
+			name: "remote domain",
+			server: &registryv0.ServerJSON{
+				Remotes: []model.Transport{
+					{Type: "streamable-http", URL: "https://mcp.example.com/v1/mcp"},
+				},
+			},
+			want: &Homepage{URL: "https://mcp.example.com", Source: HomepageSourceRemote},
+		},
+		{
+			name:   "nothing available",
+			server: &registryv0.ServerJSON{},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveHomepage(tt.server); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveHomepage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
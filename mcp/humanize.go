@@ -0,0 +1,217 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultMessages are the messages of Humanizer by ErrorTaxonomy code.
+var defaultMessages = map[string]string{
+	ErrorCodeBadRequest:      "The registry rejected the request: {details}",
+	ErrorCodeUnauthorized:    "Your registry token is invalid or expired. Log in again and retry.",
+	ErrorCodeForbidden:       "Your registry token does not allow changing {name}.",
+	ErrorCodeNotFound:        "Server {name} not found.{didYouMean}",
+	ErrorCodeConflict:        "The registry already has this version. Bump the version and publish again.",
+	ErrorCodeInvalid:         "The registry rejected server.json: {details}",
+	ErrorCodeRateLimited:     "Registry rate limit hit, retry after {retry}.",
+	ErrorCodeServer:          "The registry is having problems. Try again later.",
+	ErrorCodeNoActiveVersion: "Server {name} has no active version.{didYouMean}",
+	ErrorCodeNoToken:         "You are not logged in to the registry. Log in and retry.",
+	ErrorCodeCanceled:        "The request to the registry timed out or was canceled.",
+	ErrorCodeNetwork:         "Could not reach the registry. Check your network connection.",
+}
+
+// unnamedMessages replace defaultMessages for errors that do not name the
+// server.
+var unnamedMessages = map[string]string{
+	ErrorCodeForbidden: "Your registry token does not allow this change.",
+	ErrorCodeNotFound:  "Server not found.",
+}
+
+// Humanizer converts errors returned by the SDK into short, actionable
+// messages for end users of CLIs and chat bots, such as
+//
+//	Registry rate limit hit, retry after 2m.
+//	Server io.github.example/wether not found. Did you mean io.github.example/weather?
+//
+// The zero Humanizer uses the default messages and makes no suggestions.
+type Humanizer struct {
+	// Client, if set, is used to look up "did you mean" suggestions for
+	// missing servers with ServersService.SuggestNames. Suggestions already
+	// attached to a NotFoundError are used without a lookup.
+	Client *Client
+
+	// Suggestions is the number of suggestions offered. Defaults to 3.
+	Suggestions int
+
+	// Messages replaces the default messages, keyed by ErrorTaxonomy code.
+	// Messages may contain the placeholders {name}, {version}, {retry},
+	// {details}, {didYouMean} and {error}, the latter being the error text.
+	// Errors of codes without a message are shown as their error text.
+	Messages map[string]string
+}
+
+// Humanize returns the message of the zero Humanizer for err, or "" if err
+// is nil.
+func Humanize(err error) string {
+	var h Humanizer
+	return h.Message(context.Background(), err)
+}
+
+// Message returns a user-facing message for err, or "" if err is nil.
+// Looking up suggestions uses ctx; if it fails, the message has none.
+func (h *Humanizer) Message(ctx context.Context, err error) string {
+	code := ClassifyError(err)
+	if code == "" {
+		return ""
+	}
+
+	var name, version string
+	var notFound *NotFoundError
+	var noActive *NoActiveVersionError
+	var forbidden *ForbiddenError
+	switch {
+	case errors.As(err, &notFound):
+		name, version = notFound.Name, notFound.Version
+	case errors.As(err, &noActive):
+		name = noActive.Name
+	case errors.As(err, &forbidden):
+		name = forbidden.Name
+	}
+
+	message, ok := h.Messages[code]
+	if !ok && name == "" {
+		message, ok = unnamedMessages[code]
+	}
+	if !ok {
+		message, ok = defaultMessages[code]
+	}
+	if !ok {
+		return err.Error()
+	}
+
+	var didYouMean string
+	if strings.Contains(message, "{didYouMean}") {
+		didYouMean = h.didYouMean(ctx, err, name)
+	}
+
+	return strings.NewReplacer(
+		"{name}", name,
+		"{version}", version,
+		"{retry}", h.retryWait(err),
+		"{details}", errorDetails(err),
+		"{didYouMean}", didYouMean,
+		"{error}", err.Error(),
+	).Replace(message)
+}
+
+// didYouMean returns the suggestion sentence for err, about the server
+// named name, or "" if there is nothing to suggest.
+func (h *Humanizer) didYouMean(ctx context.Context, err error, name string) string {
+	var noActive *NoActiveVersionError
+	if errors.As(err, &noActive) {
+		if r := noActive.ReplacedBy(); r != nil && r.Name != "" {
+			return " It was replaced by " + r.Name + "."
+		}
+		return ""
+	}
+
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || notFound.Name == "" {
+		return ""
+	}
+	if len(notFound.Suggestions) > 0 {
+		return " Did you mean " + joinOr(notFound.Suggestions) + "?"
+	}
+	if h.Client == nil {
+		return ""
+	}
+	n := h.Suggestions
+	if n <= 0 {
+		n = 3
+	}
+	names, suggestErr := h.Client.Servers.SuggestNames(ctx, name, n)
+	if suggestErr != nil || len(names) == 0 {
+		return ""
+	}
+	return " Did you mean " + joinOr(names) + "?"
+}
+
+// retryWait returns how long to wait before retrying after the rate limit
+// error err, for messages.
+func (h *Humanizer) retryWait(err error) string {
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		return "a while"
+	}
+
+	now := time.Now()
+	if h.Client != nil {
+		now = h.Client.clock.Now()
+	}
+	if rateErr.Response != nil {
+		if d, ok := retryAfter(rateErr.Response.Header.Get("Retry-After"), now); ok {
+			return humanWait(d)
+		}
+	}
+	if !rateErr.Rate.Reset.IsZero() {
+		return humanWait(rateErr.Rate.Reset.Sub(now))
+	}
+	return "a while"
+}
+
+// humanWait formats d coarsely, as in "45s", "2m" or "1h5m".
+func humanWait(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "a moment"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Round(time.Second).Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Round(time.Minute).Minutes()))
+	}
+	d = d.Round(time.Minute)
+	if m := int(d.Minutes()) % 60; m != 0 {
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), m)
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+// errorDetails returns the messages of the registry's field errors in err,
+// or its message, for messages.
+func errorDetails(err error) string {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		return err.Error()
+	}
+
+	var details []string
+	for _, e := range errResp.Errors {
+		switch {
+		case e.Field != "" && e.Message != "":
+			details = append(details, e.Field+": "+e.Message)
+		case e.Message != "":
+			details = append(details, e.Message)
+		case e.Code != "":
+			details = append(details, strings.TrimSpace(e.Field+" "+e.Code))
+		}
+	}
+	if len(details) == 0 && errResp.Message != "" {
+		details = append(details, errResp.Message)
+	}
+	if len(details) == 0 && errResp.Response != nil {
+		return fmt.Sprintf("status %d", errResp.Response.StatusCode)
+	}
+	return strings.Join(details, "; ")
+}
+
+// joinOr joins names as in "a", "a or b" and "a, b or c".
+func joinOr(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHumanize(t *testing.T) {
+	response := func(code int) *http.Response {
+		return &http.Response{StatusCode: code, Header: http.Header{}, Request: &http.Request{Method: "GET", URL: mustParseURL("https://registry.example.com/v0.1/servers")}}
+	}
+	rateLimited := &RateLimitError{Response: response(http.StatusTooManyRequests)}
+	rateLimited.Response.Header.Set("Retry-After", "120")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"rate limited", rateLimited, "Registry rate limit hit, retry after 2m."},
+		{"not found", &NotFoundError{Name: "io.github.example/weather", Version: "latest"}, "Server io.github.example/weather not found."},
+		{"unnamed not found", &ErrorResponse{Response: response(http.StatusNotFound)}, "Server not found."},
+		{"invalid", &ErrorResponse{Response: response(http.StatusUnprocessableEntity), Errors: []Error{{Field: "description", Message: "too long"}}}, "The registry rejected server.json: description: too long"},
+		{"no active version", &NoActiveVersionError{Name: "io.github.example/old", Excluded: []VersionStatus{{Version: "1.0.0", Deprecation: &Deprecation{ReplacedBy: &Replacement{Name: "io.github.example/new"}}}}}, "Server io.github.example/old has no active version. It was replaced by io.github.example/new."},
+		{"other", fmt.Errorf("boom"), "boom"},
+	}
+	for _, tt := range tests {
+		if got := Humanize(tt.err); got != tt.want {
+			t.Errorf("Humanize(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHumanizer_Message(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"io.github.example/weather","version":"1.0.0"}}],"metadata":{}}`)
+	})
+
+	h := &Humanizer{Client: client}
+	err := &NotFoundError{Name: "io.github.example/wether"}
+	want := "Server io.github.example/wether not found. Did you mean io.github.example/weather?"
+	if got := h.Message(context.Background(), err); got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+
+	h.Messages = map[string]string{ErrorCodeNotFound: "No such server: {name} ({error})"}
+	want = "No such server: io.github.example/wether (server io.github.example/wether not found)"
+	if got := h.Message(context.Background(), err); got != want {
+		t.Errorf("Message with custom message = %q, want %q", got, want)
+	}
+}
+
+func TestHumanWait(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                            "a moment",
+		45 * time.Second:             "45s",
+		119 * time.Second:            "2m",
+		time.Hour + 5*time.Minute:    "1h5m",
+		2*time.Hour + 10*time.Second: "2h",
+	}
+	for d, want := range tests {
+		if got := humanWait(d); got != want {
+			t.Errorf("humanWait(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// defaultMaxIconSize is the largest icon accepted when IconOptions.MaxBytes
+// is not set.
+const defaultMaxIconSize = 512 << 10
+
+// iconMetaKeys lists the publisher-provided metadata keys inspected for icon
+// references, in order of preference.
+var iconMetaKeys = []string{"icons", "icon", "iconUrl", "logo", "logoUrl"}
+
+// repoIconNames lists the repository files probed for icons, in order.
+var repoIconNames = []string{"icon.svg", "icon.png", "logo.svg", "logo.png", ".github/icon.png", ".github/logo.png"}
+
+// nonPublicPrefixes lists the special-purpose ranges that icons are not
+// fetched from, beyond those excluded by netip.Addr methods.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+}
+
+// Icon source values reported on Icon.Source.
+const (
+	IconSourcePublisher  = "publisher"
+	IconSourceRepository = "repository"
+)
+
+// Icon represents a validated image that can be displayed for a server.
+type Icon struct {
+	// URL is the location of the image.
+	URL string
+
+	// ContentType is the media type reported by the host, e.g. "image/png".
+	ContentType string
+
+	// Size is the size of the image in bytes.
+	Size int64
+
+	// Source describes where the reference was found, either
+	// IconSourcePublisher or IconSourceRepository.
+	Source string
+}
+
+// IconOptions specifies the optional parameters to ResolveIcons.
+type IconOptions struct {
+	// MaxBytes is the largest image accepted. Defaults to 512 KiB.
+	MaxBytes int64
+
+	// SkipRepository disables probing the repository for icon files when
+	// the publisher did not provide any.
+	SkipRepository bool
+
+	// AllowSVG accepts SVG images, which are rejected by default. SVG can
+	// carry script: display SVG icons only with <img>, never inline or with
+	// <object>, as with the HTML that SanitizeMarkdown escapes.
+	AllowSVG bool
+
+	// AllowPrivateNetworks lets icons be fetched from loopback, private,
+	// link-local and other non-public addresses. Icon URLs are supplied by
+	// publishers, so by default such connections are refused, to keep a
+	// catalog backend from being made to reach internal services. It must
+	// also be set to use an httpClient whose Transport is not an
+	// *http.Transport; that transport must then restrict connections itself.
+	AllowPrivateNetworks bool
+}
+
+// ResolveIcons returns the validated icons available for server. Icon
+// references are taken from the publisher-provided metadata first; if none
+// validate, well-known icon and logo files in the server repository are
+// probed. Every candidate is fetched to check that it is an image within the
+// configured size limit. If a nil httpClient is provided, http.DefaultClient
+// is used.
+//
+// Unless opts.AllowPrivateNetworks is set, icons are fetched through a copy
+// of httpClient that connects directly, without a proxy, and only to public
+// addresses, including on redirects. SVG images are only accepted with
+// opts.AllowSVG.
+//
+// Returns an empty slice if no icon could be resolved.
+func ResolveIcons(ctx context.Context, httpClient *http.Client, server *registryv0.ServerJSON, opts *IconOptions) ([]Icon, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context must be non-nil")
+	}
+	if server == nil {
+		return nil, fmt.Errorf("server must be non-nil")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if opts == nil {
+		opts = &IconOptions{}
+	}
+	if !opts.AllowPrivateNetworks {
+		var err error
+		if httpClient, err = publicOnlyClient(httpClient); err != nil {
+			return nil, err
+		}
+	}
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxIconSize
+	}
+
+	var icons []Icon
+	for _, ref := range publisherIconRefs(server) {
+		icon, ok, err := validateIcon(ctx, httpClient, ref, maxBytes, opts.AllowSVG)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			icon.Source = IconSourcePublisher
+			icons = append(icons, icon)
+		}
+	}
+
+	if len(icons) > 0 || opts.SkipRepository || server.Repository.URL == "" {
+		return icons, nil
+	}
+
+	names := repoIconNames
+	if !opts.AllowSVG {
+		names = nil
+		for _, name := range repoIconNames {
+			if !strings.HasSuffix(name, ".svg") {
+				names = append(names, name)
+			}
+		}
+	}
+	candidates, err := rawFileURLs(server.Repository.URL, server.Repository.Subfolder, names)
+	if err != nil {
+		// Repositories on unsupported hosts simply have no probeable icons
+		return icons, nil
+	}
+
+	for _, ref := range candidates {
+		icon, ok, err := validateIcon(ctx, httpClient, ref, maxBytes, opts.AllowSVG)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			icon.Source = IconSourceRepository
+			return append(icons, icon), nil
+		}
+	}
+
+	return icons, nil
+}
+
+// publisherIconRefs extracts icon URLs from the publisher-provided metadata.
+// Values may be a string, a list of strings, or a list of objects carrying
+// the URL in a "src" or "url" field.
+func publisherIconRefs(server *registryv0.ServerJSON) []string {
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return nil
+	}
+
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(v any) {
+		s, ok := v.(string)
+		if !ok || s == "" || seen[s] {
+			return
+		}
+		seen[s] = true
+		refs = append(refs, s)
+	}
+
+	for _, key := range iconMetaKeys {
+		switch v := server.Meta.PublisherProvided[key].(type) {
+		case string:
+			add(v)
+		case []any:
+			for _, item := range v {
+				switch item := item.(type) {
+				case string:
+					add(item)
+				case map[string]any:
+					if src, ok := item["src"]; ok {
+						add(src)
+					} else {
+						add(item["url"])
+					}
+				}
+			}
+		case map[string]any:
+			if src, ok := v["src"]; ok {
+				add(src)
+			} else {
+				add(v["url"])
+			}
+		}
+	}
+
+	return refs
+}
+
+// publicOnlyClient returns a copy of httpClient that connects directly, and
+// only to public addresses.
+func publicOnlyClient(httpClient *http.Client) (*http.Client, error) {
+	var transport *http.Transport
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, fmt.Errorf("cannot restrict icon fetches to public addresses with transport %T; restrict it and set AllowPrivateNetworks", t)
+	}
+
+	// A proxy would connect to the icon host unchecked
+	transport.Proxy = nil
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialPublicOnly}
+	transport.DialContext = dialer.DialContext
+	transport.DialTLSContext = nil
+
+	client := *httpClient
+	client.Transport = transport
+	return &client, nil
+}
+
+// dialPublicOnly is a net.Dialer Control function refusing connections to
+// addresses that are not public.
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if addr := addrPort.Addr().Unmap(); !isPublicAddr(addr) {
+		return fmt.Errorf("refusing to fetch icon from non-public address %s", addr)
+	}
+	return nil
+}
+
+// isPublicAddr reports whether addr is a public unicast address.
+func isPublicAddr(addr netip.Addr) bool {
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateIcon fetches ref and reports whether it is an image no larger than
+// maxBytes, and not an SVG image unless allowSVG is set. Unreachable or
+// invalid candidates are reported as not ok rather than as errors; only
+// context cancellation is returned as an error.
+func validateIcon(ctx context.Context, httpClient *http.Client, ref string, maxBytes int64, allowSVG bool) (Icon, bool, error) {
+	u, err := url.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Icon{}, false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Icon{}, false, nil
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return Icon{}, false, ctx.Err()
+		}
+		return Icon{}, false, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Icon{}, false, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "image/") || (mediaType == "image/svg+xml" && !allowSVG) {
+		return Icon{}, false, nil
+	}
+
+	if resp.ContentLength > maxBytes {
+		return Icon{}, false, nil
+	}
+
+	n, err := io.Copy(io.Discard, io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil || n > maxBytes {
+		return Icon{}, false, nil
+	}
+
+	return Icon{URL: u.String(), ContentType: mediaType, Size: n}, true, nil
+}
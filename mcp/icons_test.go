@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestResolveIcons(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	oldBase := githubRawBaseURL
+	githubRawBaseURL = server.URL + "/raw/"
+	defer func() { githubRawBaseURL = oldBase }()
+
+	mux.HandleFunc("/icon.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-bytes"))
+	})
+	mux.HandleFunc("/page.html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	})
+	mux.HandleFunc("/huge.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte(strings.Repeat("x", 64)))
+	})
+	mux.HandleFunc("/raw/example/repo/HEAD/logo.svg", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte("<svg/>"))
+	})
+
+	tests := []struct {
+		name string
+		srv  *registryv0.ServerJSON
+		opts *IconOptions
+		want []Icon
+	}{
+		{
+			name: "publisher icons validated",
+			srv: &registryv0.ServerJSON{
+				Meta: &registryv0.ServerMeta{
+					PublisherProvided: map[string]any{
+						"icons": []any{
+							map[string]any{"src": server.URL + "/icon.png"},
+							server.URL + "/page.html",
+							server.URL + "/huge.png",
+							"ftp://example.com/icon.png",
+						},
+					},
+				},
+			},
+			opts: &IconOptions{MaxBytes: 32, AllowPrivateNetworks: true},
+			want: []Icon{
+				{URL: server.URL + "/icon.png", ContentType: "image/png", Size: 9, Source: IconSourcePublisher},
+			},
+		},
+		{
+			name: "falls back to repository",
+			srv: &registryv0.ServerJSON{
+				Repository: model.Repository{URL: "https://github.com/example/repo"},
+			},
+			opts: &IconOptions{AllowSVG: true, AllowPrivateNetworks: true},
+			want: []Icon{
+				{URL: server.URL + "/raw/example/repo/HEAD/logo.svg", ContentType: "image/svg+xml", Size: 6, Source: IconSourceRepository},
+			},
+		},
+		{
+			name: "repository probing disabled",
+			srv: &registryv0.ServerJSON{
+				Repository: model.Repository{URL: "https://github.com/example/repo"},
+			},
+			opts: &IconOptions{SkipRepository: true, AllowPrivateNetworks: true},
+			want: nil,
+		},
+		{
+			name: "svg rejected by default",
+			srv: &registryv0.ServerJSON{
+				Meta: &registryv0.ServerMeta{
+					PublisherProvided: map[string]any{"icon": server.URL + "/raw/example/repo/HEAD/logo.svg"},
+				},
+				Repository: model.Repository{URL: "https://github.com/example/repo"},
+			},
+			opts: &IconOptions{AllowPrivateNetworks: true},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveIcons(context.Background(), server.Client(), tt.srv, tt.opts)
+			if err != nil {
+				t.Fatalf("ResolveIcons returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveIcons = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveIcons_NilServer(t *testing.T) {
+	if _, err := ResolveIcons(context.Background(), nil, nil, nil); err == nil {
+		t.Error("ResolveIcons expected error for nil server")
+	}
+}
+
+func TestResolveIcons_PrivateNetwork(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-bytes"))
+	}))
+	defer server.Close()
+
+	srv := &registryv0.ServerJSON{
+		Meta: &registryv0.ServerMeta{
+			PublisherProvided: map[string]any{"icon": server.URL + "/icon.png"},
+		},
+	}
+	got, err := ResolveIcons(context.Background(), server.Client(), srv, nil)
+	if err != nil {
+		t.Fatalf("ResolveIcons returned error: %v", err)
+	}
+	if got != nil || requests != 0 {
+		t.Errorf("ResolveIcons = %+v after %d requests, want no icon from a loopback address", got, requests)
+	}
+
+	custom := &http.Client{Transport: RoundTripperFunc(http.DefaultTransport.RoundTrip)}
+	if _, err := ResolveIcons(context.Background(), custom, srv, nil); err == nil {
+		t.Error("ResolveIcons with a custom transport returned nil error")
+	}
+	got, err = ResolveIcons(context.Background(), custom, srv, &IconOptions{AllowPrivateNetworks: true})
+	if err != nil || len(got) != 1 {
+		t.Errorf("ResolveIcons with AllowPrivateNetworks = %+v, %v; want the icon", got, err)
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700::1", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+
+	if err := dialPublicOnly("tcp", "[::ffff:127.0.0.1]:80", nil); err == nil {
+		t.Error("dialPublicOnly accepted an IPv4-mapped loopback address")
+	}
+	if err := dialPublicOnly("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("dialPublicOnly returned error for a public address: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// LiveSearchResult is the outcome of a query sent by LiveSearch.
+type LiveSearchResult struct {
+	// Query is the search text the result is for.
+	Query string
+
+	// Servers is the first page of matching servers.
+	Servers []registryv0.ServerResponse
+
+	// Response is the response to the list request, or nil for an empty
+	// query.
+	Response *Response
+
+	// Err is the error returned by the list request, if any.
+	Err error
+}
+
+// LiveSearch searches the registry as a user types, as needed by search boxes
+// in terminal and web UIs. Queries are debounced, so only a query that is not
+// replaced within the debounce delay is sent; a new query cancels the
+// request of the one before; and only the result of the latest query is
+// delivered.
+//
+// A LiveSearch is safe for concurrent use. Close it when the search box goes
+// away to cancel any pending request.
+type LiveSearch struct {
+	client   *Client
+	debounce time.Duration
+	opts     ServerListOptions
+	results  chan LiveSearchResult
+
+	mu     sync.Mutex
+	gen    int                // incremented by each Query
+	cancel context.CancelFunc // cancels the latest query
+	closed bool
+}
+
+// NewLiveSearch returns a LiveSearch that lists servers with client after
+// each query has been left unchanged for debounce. opts, if not nil, sets the
+// other list parameters, such as Limit; its Search and Cursor are ignored.
+func NewLiveSearch(client *Client, debounce time.Duration, opts *ServerListOptions) *LiveSearch {
+	ls := &LiveSearch{
+		client:   client,
+		debounce: debounce,
+		results:  make(chan LiveSearchResult, 1),
+	}
+	if opts != nil {
+		ls.opts = *opts
+	}
+	return ls
+}
+
+// Results returns the channel on which results are delivered. It holds at
+// most one result: a result that has not been received when a newer one is
+// ready is discarded. The channel is closed by Close.
+func (ls *LiveSearch) Results() <-chan LiveSearchResult {
+	return ls.results
+}
+
+// Query replaces the current search text with query. It returns immediately;
+// the search runs once query has not been replaced for the debounce delay.
+// An empty query cancels any pending search and delivers an empty result
+// without contacting the registry.
+func (ls *LiveSearch) Query(query string) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if ls.closed {
+		return
+	}
+
+	if ls.cancel != nil {
+		ls.cancel()
+	}
+	ls.gen++
+	gen := ls.gen
+
+	if query == "" {
+		ls.cancel = nil
+		ls.deliver(LiveSearchResult{})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ls.cancel = cancel
+	go ls.search(ctx, gen, query)
+}
+
+// Close cancels any pending search and closes the results channel. Queries
+// made after Close are ignored.
+func (ls *LiveSearch) Close() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if ls.closed {
+		return
+	}
+
+	if ls.cancel != nil {
+		ls.cancel()
+	}
+	ls.closed = true
+	close(ls.results)
+}
+
+// search runs query gen after the debounce delay, unless ctx is canceled
+// first because a newer query was made.
+func (ls *LiveSearch) search(ctx context.Context, gen int, query string) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-ls.client.clock.After(ls.debounce):
+	}
+
+	opts := ls.opts
+	opts.Search = query
+	opts.Cursor = ""
+	page, resp, err := ls.client.Servers.List(ctx, &opts)
+	if ctx.Err() != nil {
+		return
+	}
+
+	result := LiveSearchResult{Query: query, Response: resp, Err: err}
+	if page != nil {
+		result.Servers = page.Servers
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if gen == ls.gen && !ls.closed {
+		ls.deliver(result)
+	}
+}
+
+// deliver makes result the pending result, replacing any undelivered one.
+// ls.mu must be held.
+func (ls *LiveSearch) deliver(result LiveSearchResult) {
+	select {
+	case <-ls.results:
+	default:
+	}
+	ls.results <- result
+}
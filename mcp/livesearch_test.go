@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func TestLiveSearch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	clock := mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := WithClock(clock)(client); err != nil {
+		t.Fatalf("WithClock returned error: %v", err)
+	}
+
+	var mu sync.Mutex
+	var searches []string
+	inFlight := make(chan struct{})
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		search := r.URL.Query().Get("search")
+		mu.Lock()
+		searches = append(searches, search)
+		mu.Unlock()
+
+		if search == "slow" {
+			// Block until the request is canceled by a newer query
+			close(inFlight)
+			<-r.Context().Done()
+			return
+		}
+		testFormValues(t, r, values{"search": search, "limit": "5"})
+		fmt.Fprintf(w, `{"servers":[{"server":{"name":"com.example/%s","version":"1.0.0"}}],"metadata":{}}`, search)
+	})
+
+	ls := NewLiveSearch(client, 200*time.Millisecond, &ServerListOptions{ListOptions: ListOptions{Limit: 5}})
+	defer ls.Close()
+
+	// Rapid queries are debounced into one request
+	ls.Query("w")
+	ls.Query("we")
+	ls.Query("wea")
+	clock.BlockUntil(3)
+	clock.Advance(200 * time.Millisecond)
+
+	result := <-ls.Results()
+	if result.Err != nil {
+		t.Fatalf("result error: %v", result.Err)
+	}
+	if result.Query != "wea" || len(result.Servers) != 1 || result.Servers[0].Server.Name != "com.example/wea" {
+		t.Errorf("result = %+v, want com.example/wea for query wea", result)
+	}
+
+	// A newer query cancels the request in flight
+	ls.Query("slow")
+	clock.BlockUntil(1)
+	clock.Advance(200 * time.Millisecond)
+	<-inFlight
+	ls.Query("mail")
+	clock.BlockUntil(1)
+	clock.Advance(200 * time.Millisecond)
+
+	result = <-ls.Results()
+	if result.Query != "mail" || result.Err != nil {
+		t.Errorf("result = %+v, want result for mail", result)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"wea", "slow", "mail"}; fmt.Sprint(searches) != fmt.Sprint(want) {
+		t.Errorf("searches = %q, want %q", searches, want)
+	}
+}
+
+func TestLiveSearch_EmptyQueryAndClose(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ls := NewLiveSearch(client, time.Hour, nil)
+	ls.Query("weather")
+	ls.Query("")
+
+	result := <-ls.Results()
+	if result.Query != "" || result.Servers != nil || result.Response != nil {
+		t.Errorf("empty query result = %+v, want empty result", result)
+	}
+
+	ls.Close()
+	ls.Query("weather")
+	ls.Close()
+	if _, ok := <-ls.Results(); ok {
+		t.Error("Results channel open after Close")
+	}
+}
@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NameMatcher reports whether the server named name matches the name a
+// helper was asked for, query.
+type NameMatcher func(query, name string) bool
+
+// Name matchers for use with WithNameMatcher.
+var (
+	// MatchExact matches names equal to the query. It is the default.
+	MatchExact NameMatcher = func(query, name string) bool {
+		return name == query
+	}
+
+	// MatchCaseInsensitive matches names equal to the query, ignoring case.
+	MatchCaseInsensitive NameMatcher = strings.EqualFold
+
+	// MatchPrefix matches names starting with the query, e.g. every server
+	// of a namespace for the query "com.example/".
+	MatchPrefix NameMatcher = func(query, name string) bool {
+		return strings.HasPrefix(name, query)
+	}
+)
+
+// WithNameMatcher returns an Option that sets how the name-based helpers
+// ListByName, GetByNameLatest and GetByNameLatestActiveVersion match server
+// names, so registries with different naming conventions can reuse them.
+//
+// The helpers search the registry for the query before matching, and the
+// registry's search matches substrings of names, ignoring case. Matchers
+// therefore only see names that contain the query.
+func WithNameMatcher(m NameMatcher) Option {
+	return func(c *Client) error {
+		if m == nil {
+			return fmt.Errorf("name matcher cannot be nil")
+		}
+		c.nameMatcher = m
+		return nil
+	}
+}
+
+// matchName reports whether name matches query according to the client's
+// name matcher.
+func (c *Client) matchName(query, name string) bool {
+	if c.nameMatcher == nil {
+		return MatchExact(query, name)
+	}
+	return c.nameMatcher(query, name)
+}
@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNameMatchers(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher NameMatcher
+		query   string
+		server  string
+		want    bool
+	}{
+		{"exact", MatchExact, "com.example/server", "com.example/server", true},
+		{"exact case", MatchExact, "com.example/server", "com.example/Server", false},
+		{"case-insensitive", MatchCaseInsensitive, "com.example/server", "com.example/Server", true},
+		{"case-insensitive substring", MatchCaseInsensitive, "com.example/server", "com.example/server2", false},
+		{"prefix", MatchPrefix, "com.example/", "com.example/server", true},
+		{"prefix mismatch", MatchPrefix, "com.example/", "com.other/server", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher(tt.query, tt.server); got != tt.want {
+				t.Errorf("matcher(%q, %q) = %v, want %v", tt.query, tt.server, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNameMatcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher NameMatcher
+		want    []string
+	}{
+		{"default", nil, []string{"com.example/weather"}},
+		{"case-insensitive", MatchCaseInsensitive, []string{"com.example/weather", "com.example/Weather"}},
+		{"prefix", MatchPrefix, []string{"com.example/weather", "com.example/weather-alerts"}},
+		{"custom", func(query, name string) bool {
+			return strings.HasSuffix(name, "-alerts")
+		}, []string{"com.example/weather-alerts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			if tt.matcher != nil {
+				if err := WithNameMatcher(tt.matcher)(client); err != nil {
+					t.Fatalf("WithNameMatcher returned error: %v", err)
+				}
+			}
+
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"servers":[
+					{"server":{"name":"com.example/weather","version":"1.0.0"}},
+					{"server":{"name":"com.example/Weather","version":"1.0.0"}},
+					{"server":{"name":"com.example/weather-alerts","version":"1.0.0"}}
+				],"metadata":{"count":3}}`)
+			})
+
+			servers, _, err := client.Servers.ListByName(context.Background(), "com.example/weather")
+			if err != nil {
+				t.Fatalf("ListByName returned error: %v", err)
+			}
+			var got []string
+			for _, s := range servers {
+				got = append(got, s.Name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("ListByName = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithNameMatcher_Nil(t *testing.T) {
+	if _, err := NewClient(nil, WithNameMatcher(nil)); err == nil {
+		t.Error("NewClient with nil name matcher returned nil error")
+	}
+}
@@ -26,6 +26,12 @@ type Option func(*Client) error
 // WithBaseURL returns an Option that sets the base URL for the client.
 // The URL must be a valid HTTP or HTTPS URL. If the URL doesn't end with
 // a trailing slash, one will be added automatically.
+//
+// A unix URL, such as unix:///var/run/registry.sock, connects to a registry
+// listening on that Unix domain socket, such as a local instance or a
+// sidecar mirror. Requests are sent over plain HTTP with the host
+// localhost, and the client's transport is configured as described by
+// WithTLSConfig.
 func WithBaseURL(baseURL string) Option {
     return func(c *Client) error {
         if baseURL == "" {
@@ -42,6 +48,15 @@ func WithBaseURL(baseURL string) Option {
         if parsedURL.Scheme == "" {
             return fmt.Errorf("invalid base URL: missing scheme")
         }
+        c.socketPath = ""
+        if parsedURL.Scheme == "unix" {
+            if parsedURL.Path == "" {
+                return fmt.Errorf("invalid base URL: missing socket path")
+            }
+            c.socketPath = parsedURL.Path
+            c.BaseURL = &url.URL{Scheme: "http", Host: "localhost", Path: "/"}
+            return nil
+        }
         if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
             return fmt.Errorf("base URL must use HTTP or HTTPS scheme, got: %s", parsedURL.Scheme)
         }
@@ -62,10 +77,33 @@ func WithBaseURL(baseURL string) Option {
     }
 }
 
+// WithDefaultHeaders returns an Option that adds header to every request
+// built by NewRequest, such as tracing or corporate gateway headers. The
+// Accept, Content-Type and User-Agent headers set by the client take
+// precedence over defaults with the same name. Calling WithDefaultHeaders
+// again adds to the headers configured before.
+func WithDefaultHeaders(header http.Header) Option {
+    return func(c *Client) error {
+        if len(header) == 0 {
+            return fmt.Errorf("default headers cannot be empty")
+        }
+        if c.defaultHeaders == nil {
+            c.defaultHeaders = make(http.Header)
+        }
+        for key, values := range header {
+            for _, value := range values {
+                c.defaultHeaders.Add(key, value)
+            }
+        }
+        return nil
+    }
+}
+
 // NewClient returns a new MCP Registry API client. If a nil httpClient is
 // provided, a new http.Client will be used. To use API methods which require
-// authentication, provide an http.Client that will perform the authentication
-// for you (such as that provided by the golang.org/x/oauth2 library).
+// authentication, configure a registry token with WithAuthToken, or provide
+// an http.Client that will perform the authentication for you (such as that
+// provided by the golang.org/x/oauth2 library).
 //
 // Options can be provided to configure the client behavior, such as setting
 // a custom base URL with WithBaseURL.
@@ -86,11 +124,20 @@ func NewClient(httpClient *http.Client, opts ...Option) (*Client, error) {
         client:     httpClient,
         BaseURL:    baseURL,
         UserAgent:  userAgent,
+        authHeader: "Authorization",
+        authPrefix: "Bearer ",
+        apiVersion: defaultAPIVersion,
+        clock:      systemClock{},
+        routes:     make(map[string]Route, len(defaultRoutes)),
         rateLimits: make(map[string]Rate),
     }
+    for name, r := range defaultRoutes {
+        c.routes[name] = r
+    }
 
     c.common.client = c
     c.Servers = (*ServersService)(&c.common)
+    c.Auth = (*AuthService)(&c.common)
 
     // Apply provided options
     for _, opt := range opts {
@@ -98,6 +145,26 @@ func NewClient(httpClient *http.Client, opts ...Option) (*Client, error) {
             return nil, err
         }
     }
+    if c.socketPath != "" {
+        if err := c.dialSocket(); err != nil {
+            return nil, err
+        }
+    }
+    if c.credentials != nil {
+        if err := c.applyCredentials(); err != nil {
+            return nil, err
+        }
+    }
+    if c.endpoints != nil {
+        if err := c.applyEndpoints(); err != nil {
+            return nil, err
+        }
+    }
+    if len(c.middleware) > 0 {
+        if err := c.applyMiddleware(); err != nil {
+            return nil, err
+        }
+    }
 
     return c, nil
 }
@@ -132,6 +199,9 @@ func (c *Client) NewRequest(method, urlStr string, body any) (*http.Request, err
         return nil, err
     }
 
+    for key, values := range c.defaultHeaders {
+        req.Header[key] = append([]string(nil), values...)
+    }
     if body != nil {
         req.Header.Set("Content-Type", mediaTypeJSON)
     }
@@ -149,9 +219,33 @@ func (c *Client) NewRequest(method, urlStr string, body any) (*http.Request, err
 func newResponse(r *http.Response) *Response {
     response := &Response{Response: r}
     response.Rate = parseRate(r)
+    response.RequestID = parseRequestID(r)
     return response
 }
 
+// requestIDHeaders are the headers that carry the identifier a registry or
+// its proxies assign to a request, in order of preference.
+var requestIDHeaders = []string{
+    "X-Request-Id",
+    "X-Correlation-Id",
+    "X-Trace-Id",
+    "Traceparent",
+    "X-Amzn-Trace-Id",
+    "X-Cloud-Trace-Context",
+    "Cf-Ray",
+}
+
+// parseRequestID returns the request ID of the response, or "" if it has
+// none.
+func parseRequestID(r *http.Response) string {
+    for _, h := range requestIDHeaders {
+        if id := r.Header.Get(h); id != "" {
+            return id
+        }
+    }
+    return ""
+}
+
 // parseRate parses rate limit headers from the response.
 func parseRate(r *http.Response) Rate {
     var rate Rate
@@ -173,21 +267,56 @@ func parseRate(r *http.Response) Rate {
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response body will be written to v, without attempting to first
-// decode it.
+// decode it; an error reading the body, such as on a truncated response, is
+// returned.
 //
 // The provided ctx must be non-nil. If it is canceled or times out,
-// ctx.Err() will be returned.
-func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, error) {
+// ctx.Err() will be returned. Request options, such as WithRequestToken,
+// apply to this request only.
+func (c *Client) Do(ctx context.Context, req *http.Request, v any, opts ...RequestOption) (*Response, error) {
     if ctx == nil {
         return nil, fmt.Errorf("context must be non-nil")
     }
+    ctx, cancel := withRequestOptions(ctx, opts)
+    defer cancel()
+    if route, ok := RouteFromContext(req.Context()); ok {
+        ctx = contextWithRoute(ctx, route)
+    }
 
     req = req.WithContext(ctx)
+    fromSource, err := c.authenticate(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+    c.setAPIKey(req)
+    c.setBasicAuth(req)
+    setOnBehalfOf(ctx, req)
+    c.setAcceptEncoding(req)
+    setRequestHeaders(ctx, req)
+
+    start := c.clock.Now()
+    response, err := c.send(ctx, req, v)
+    if fromSource {
+        response, err = c.refreshAndRetry(ctx, req, v, response, err)
+    }
+    c.audit(ctx, req, response, start, err)
+    c.invalidateCache(req, err)
+
+    return response, err
+}
+
+// sendOnce performs req, which already carries ctx, and decodes the
+// response into v as described by Do.
+func (c *Client) sendOnce(ctx context.Context, req *http.Request, v any) (*Response, error) {
+    if c.rateBudget != nil {
+        if err := c.rateBudget.wait(ctx, c.clock); err != nil {
+            return nil, err
+        }
+    }
 
-    c.clientMu.Lock()
     resp, err := c.client.Do(req)
-    c.clientMu.Unlock()
     if err != nil {
+        c.dumpExchange(req, nil, err)
         // If we got an error, and the context has been canceled,
         // the context's error is probably more useful.
         select {
@@ -198,6 +327,8 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
         return nil, err
     }
     defer resp.Body.Close()
+    decompress(resp)
+    c.dumpExchange(req, resp, nil)
 
     response := newResponse(resp)
 
@@ -206,20 +337,35 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
     c.rateLimits[req.URL.Path] = response.Rate
     c.rateMu.Unlock()
 
+    if c.rateBudget != nil {
+        c.rateBudget.Observe(response.Rate)
+    }
+    if c.rateForecaster != nil {
+        c.rateForecaster.observe(response.Rate, c.clock.Now())
+    }
+
     err = CheckResponse(resp)
     if err != nil {
         return response, err
     }
 
+    body := io.Reader(resp.Body)
+    if c.rawJSON {
+        data, readErr := io.ReadAll(resp.Body)
+        if readErr != nil {
+            return response, readErr
+        }
+        response.RawJSON = data
+        body = bytes.NewReader(data)
+    }
+
     if v != nil {
         if w, ok := v.(io.Writer); ok {
-            io.Copy(w, resp.Body)
-        } else {
-            decErr := json.NewDecoder(resp.Body).Decode(v)
-            if decErr == io.EOF {
-                decErr = nil // ignore EOF errors caused by empty response body
+            if _, copyErr := io.Copy(w, body); copyErr != nil {
+                err = copyErr
             }
-            if decErr != nil {
+        } else {
+            if decErr := c.decodeJSON(body, v); decErr != nil {
                 err = decErr
             }
         }
@@ -228,6 +374,14 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, e
     return response, err
 }
 
+// AddOptions adds the parameters in opts as URL query parameters to s.
+// opts must be a struct whose fields may contain "url" tags, as used by
+// github.com/google/go-querystring. It is exported for custom services
+// built on top of Client; see the package documentation for an example.
+func AddOptions(s string, opts any) (string, error) {
+    return addOptions(s, opts)
+}
+
 // addOptions adds the parameters in opts as URL query parameters to s.
 // opts must be a struct whose fields may contain "url" tags.
 func addOptions(s string, opts any) (string, error) {
@@ -4,7 +4,9 @@ import (
     "bytes"
     "context"
     "encoding/json"
+    "errors"
     "fmt"
+    "io"
     "net/http"
     "net/http/httptest"
     "net/url"
@@ -301,6 +303,51 @@ func TestDo_CancelledContext(t *testing.T) {
     }
 }
 
+func TestDo_Concurrent(t *testing.T) {
+    // Each request waits until both have arrived, so the test only passes
+    // if Do sends them concurrently
+    const n = 2
+    arrived := make(chan struct{}, n)
+    release := make(chan struct{})
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        arrived <- struct{}{}
+        <-release
+        w.WriteHeader(200)
+    }))
+    defer server.Close()
+
+    client, err := NewClient(nil)
+    if err != nil {
+        t.Fatalf("NewClient() error = %v", err)
+    }
+    client.BaseURL, _ = url.Parse(server.URL + "/")
+
+    errs := make(chan error, n)
+    for i := 0; i < n; i++ {
+        go func() {
+            req, _ := client.NewRequest("GET", "test", nil)
+            _, err := client.Do(context.Background(), req, nil)
+            errs <- err
+        }()
+    }
+
+    for i := 0; i < n; i++ {
+        select {
+        case <-arrived:
+        case <-time.After(5 * time.Second):
+            close(release)
+            t.Fatalf("only %d of %d requests in flight; Do serializes requests", i, n)
+        }
+    }
+    close(release)
+
+    for i := 0; i < n; i++ {
+        if err := <-errs; err != nil {
+            t.Errorf("Do() unexpected error: %v", err)
+        }
+    }
+}
+
 func TestDo_IOWriter(t *testing.T) {
     responseBody := "raw response body"
     server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -327,6 +374,27 @@ func TestDo_IOWriter(t *testing.T) {
     }
 }
 
+func TestDo_IOWriterTruncated(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Length", "100")
+        fmt.Fprint(w, "truncated")
+    }))
+    defer server.Close()
+
+    client, err := NewClient(nil)
+    if err != nil {
+        t.Fatalf("NewClient() error = %v", err)
+    }
+    client.BaseURL, _ = url.Parse(server.URL + "/")
+    req, _ := client.NewRequest("GET", "test", nil)
+
+    var buf bytes.Buffer
+    _, err = client.Do(context.Background(), req, &buf)
+    if !errors.Is(err, io.ErrUnexpectedEOF) {
+        t.Errorf("Do() with truncated body error = %v, want io.ErrUnexpectedEOF", err)
+    }
+}
+
 func TestDo_EmptyResponse(t *testing.T) {
     server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
         w.WriteHeader(200)
@@ -454,6 +522,21 @@ func TestAddOptions_InvalidURL(t *testing.T) {
     }
 }
 
+func TestAddOptions_Exported(t *testing.T) {
+    type options struct {
+        Status string `url:"status,omitempty"`
+    }
+
+    got, err := AddOptions("v0.1/collections", &options{Status: "featured"})
+    if err != nil {
+        t.Fatalf("AddOptions() unexpected error: %v", err)
+    }
+
+    if want := "v0.1/collections?status=featured"; got != want {
+        t.Errorf("AddOptions() = %q, want %q", got, want)
+    }
+}
+
 func TestNewResponse(t *testing.T) {
     resetTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 
@@ -484,6 +567,63 @@ func TestNewResponse(t *testing.T) {
     }
 }
 
+func TestParseRequestID(t *testing.T) {
+    tests := []struct {
+        name   string
+        header http.Header
+        want   string
+    }{
+        {"none", http.Header{}, ""},
+        {"request id", http.Header{"X-Request-Id": {"req-1"}}, "req-1"},
+        {"traceparent", http.Header{"Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+        {"request id preferred", http.Header{"X-Request-Id": {"req-1"}, "Cf-Ray": {"8a1b2c3d"}}, "req-1"},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := parseRequestID(&http.Response{Header: tt.header}); got != tt.want {
+                t.Errorf("parseRequestID() = %q, want %q", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestDo_RequestID(t *testing.T) {
+    client, mux, _, teardown := setup()
+    defer teardown()
+
+    mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("X-Request-Id", "req-ok")
+        fmt.Fprint(w, `{}`)
+    })
+    mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("X-Request-Id", "req-fail")
+        http.Error(w, `{"message":"boom"}`, http.StatusInternalServerError)
+    })
+
+    req, _ := client.NewRequest("GET", "ok", nil)
+    resp, err := client.Do(context.Background(), req, nil)
+    if err != nil {
+        t.Fatalf("Do returned error: %v", err)
+    }
+    if resp.RequestID != "req-ok" {
+        t.Errorf("Response.RequestID = %q, want %q", resp.RequestID, "req-ok")
+    }
+
+    req, _ = client.NewRequest("GET", "fail", nil)
+    _, err = client.Do(context.Background(), req, nil)
+    errResp, ok := err.(*ErrorResponse)
+    if !ok {
+        t.Fatalf("Do returned error %T, want *ErrorResponse", err)
+    }
+    if errResp.RequestID != "req-fail" {
+        t.Errorf("ErrorResponse.RequestID = %q, want %q", errResp.RequestID, "req-fail")
+    }
+    if !strings.HasSuffix(err.Error(), "[request ID req-fail]") {
+        t.Errorf("Error() = %q, want request ID suffix", err.Error())
+    }
+}
+
 func TestNewClient(t *testing.T) {
     tests := []struct {
         name        string
@@ -692,3 +832,38 @@ func TestWithBaseURL(t *testing.T) {
         })
     }
 }
+
+func TestWithDefaultHeaders(t *testing.T) {
+    client, err := NewClient(nil,
+        WithDefaultHeaders(http.Header{"x-trace-id": {"trace-1"}, "Accept": {"text/html"}}),
+        WithDefaultHeaders(http.Header{"X-Gateway-Tenant": {"a", "b"}}),
+    )
+    if err != nil {
+        t.Fatalf("NewClient() error = %v", err)
+    }
+
+    req, err := client.NewRequest("GET", "v0.1/servers", nil)
+    if err != nil {
+        t.Fatalf("NewRequest() error = %v", err)
+    }
+    if got := req.Header.Get("X-Trace-Id"); got != "trace-1" {
+        t.Errorf("X-Trace-Id = %q, want %q", got, "trace-1")
+    }
+    if got := req.Header.Values("X-Gateway-Tenant"); len(got) != 2 {
+        t.Errorf("X-Gateway-Tenant = %q, want two values", got)
+    }
+    if got := req.Header.Get("Accept"); got != mediaTypeJSON {
+        t.Errorf("Accept = %q, want %q", got, mediaTypeJSON)
+    }
+
+    // Requests do not share header storage with the client
+    req.Header.Add("X-Gateway-Tenant", "c")
+    req, _ = client.NewRequest("GET", "v0.1/servers", nil)
+    if got := req.Header.Values("X-Gateway-Tenant"); len(got) != 2 {
+        t.Errorf("X-Gateway-Tenant after modifying a request = %q, want two values", got)
+    }
+
+    if _, err := NewClient(nil, WithDefaultHeaders(nil)); err == nil {
+        t.Error("WithDefaultHeaders(nil) expected error, got nil")
+    }
+}
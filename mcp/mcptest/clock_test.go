@@ -0,0 +1,55 @@
+package mcptest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(start)
+
+	short := clock.After(time.Second)
+	long := clock.After(time.Minute)
+	if got := clock.Waiters(); got != 2 {
+		t.Fatalf("Waiters() = %d, want 2", got)
+	}
+
+	clock.Advance(30 * time.Second)
+	select {
+	case got := <-short:
+		if want := start.Add(30 * time.Second); !got.Equal(want) {
+			t.Errorf("After(1s) sent %v, want %v", got, want)
+		}
+	default:
+		t.Error("After(1s) did not fire after advancing 30s")
+	}
+	select {
+	case <-long:
+		t.Error("After(1m) fired after advancing 30s")
+	default:
+	}
+
+	clock.Set(start.Add(time.Hour))
+	select {
+	case <-long:
+	default:
+		t.Error("After(1m) did not fire after setting the clock an hour ahead")
+	}
+
+	if got := clock.Waiters(); got != 0 {
+		t.Errorf("Waiters() = %d, want 0", got)
+	}
+	if got, want := clock.Now(), start.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+}
+
+func TestClock_AfterNonPositive(t *testing.T) {
+	clock := NewClock(time.Unix(0, 0))
+	select {
+	case <-clock.After(0):
+	default:
+		t.Error("After(0) did not fire immediately")
+	}
+}
@@ -0,0 +1,232 @@
+// Package mcptest provides utilities for testing code that uses the mcp
+// package against realistic registry failure modes.
+package mcptest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault is a failure injected by FaultTransport.
+type Fault string
+
+// Faults injected by FaultTransport.
+const (
+	// FaultNone passes the request through unchanged.
+	FaultNone Fault = "none"
+
+	// FaultDrop fails the request with ErrDropped, as if the connection was
+	// lost, without sending it.
+	FaultDrop Fault = "drop"
+
+	// FaultDelay waits for FaultTransport.Delay before sending the request.
+	FaultDelay Fault = "delay"
+
+	// FaultMalformedJSON sends the request and replaces the response body
+	// with invalid JSON.
+	FaultMalformedJSON Fault = "malformed-json"
+
+	// FaultTruncate sends the request and cuts the response body off
+	// halfway, ending it with io.ErrUnexpectedEOF.
+	FaultTruncate Fault = "truncate"
+
+	// FaultRateLimit answers the request, and the following
+	// FaultTransport.RateLimitBurst-1 requests, with 429 Too Many Requests
+	// without sending them.
+	FaultRateLimit Fault = "rate-limit"
+)
+
+// ErrDropped is returned for requests dropped with FaultDrop. It wraps
+// syscall.ECONNRESET, like the error of a connection reset by the peer.
+var ErrDropped = fmt.Errorf("mcptest: connection dropped by fault injection: %w", syscall.ECONNRESET)
+
+// Defaults used by FaultTransport.
+const (
+	defaultFaultDelay      = time.Second
+	defaultRateLimitBurst  = 1
+	defaultRateLimitPeriod = time.Minute
+)
+
+// FaultTransport is an http.RoundTripper that injects failures into the
+// requests it sends, so consumers can test their error handling and retry
+// logic:
+//
+//	transport := &mcptest.FaultTransport{
+//		Sequence: []mcptest.Fault{mcptest.FaultRateLimit, mcptest.FaultNone},
+//		Rates:    map[mcptest.Fault]float64{mcptest.FaultDrop: 0.1},
+//	}
+//	client, _ := mcp.NewClient(&http.Client{Transport: transport})
+//
+// The n-th request gets the n-th fault of Sequence. Once Sequence is
+// exhausted, each request gets each fault in Rates with the given
+// probability, checked in a fixed order. A FaultTransport is safe for
+// concurrent use.
+type FaultTransport struct {
+	// Base is the transport used to send requests. Defaults to
+	// http.DefaultTransport.
+	Base http.RoundTripper
+
+	// Sequence lists the faults of the first requests, in order.
+	Sequence []Fault
+
+	// Rates maps faults to the probability, between 0 and 1, of injecting
+	// them into each request after Sequence is exhausted.
+	Rates map[Fault]float64
+
+	// Delay is the delay injected by FaultDelay. Defaults to one second.
+	Delay time.Duration
+
+	// RateLimitBurst is the number of consecutive requests answered with
+	// 429 once FaultRateLimit is injected. Defaults to 1.
+	RateLimitBurst int
+
+	// Rand is the source of randomness for Rates. Defaults to a source with
+	// a fixed seed, so runs are reproducible.
+	Rand *rand.Rand
+
+	mu       sync.Mutex
+	requests int // number of requests seen
+	burst    int // remaining 429 responses of the current burst
+}
+
+// rateOrder is the order in which Rates are checked.
+var rateOrder = []Fault{FaultDrop, FaultRateLimit, FaultDelay, FaultMalformedJSON, FaultTruncate}
+
+// RoundTrip implements http.RoundTripper.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := t.next()
+
+	switch fault {
+	case FaultDrop:
+		return nil, ErrDropped
+	case FaultRateLimit:
+		return t.rateLimited(req), nil
+	case FaultDelay:
+		delay := t.Delay
+		if delay <= 0 {
+			delay = defaultFaultDelay
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fault {
+	case FaultMalformedJSON:
+		resp.Body.Close()
+		body := []byte(`{"servers": [{"server": {"name": `)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Del("Content-Length")
+	case FaultTruncate:
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data[:len(data)/2]), errReader{io.ErrUnexpectedEOF}))
+	}
+
+	return resp, nil
+}
+
+// Requests returns the number of requests the transport has seen.
+func (t *FaultTransport) Requests() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.requests
+}
+
+// next returns the fault to inject into the next request.
+func (t *FaultTransport) next() Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.requests
+	t.requests++
+
+	if t.burst > 0 {
+		t.burst--
+		return FaultRateLimit
+	}
+
+	fault := FaultNone
+	if n < len(t.Sequence) {
+		fault = t.Sequence[n]
+	} else if len(t.Rates) > 0 {
+		if t.Rand == nil {
+			t.Rand = rand.New(rand.NewSource(1))
+		}
+		for _, f := range rateOrder {
+			if p := t.Rates[f]; p > 0 && t.Rand.Float64() < p {
+				fault = f
+				break
+			}
+		}
+	}
+
+	if fault == FaultRateLimit {
+		burst := t.RateLimitBurst
+		if burst <= 0 {
+			burst = defaultRateLimitBurst
+		}
+		t.burst = burst - 1
+	}
+
+	return fault
+}
+
+// rateLimited returns a synthetic 429 response for req, with rate limit
+// headers in the format the registry uses.
+func (t *FaultTransport) rateLimited(req *http.Request) *http.Response {
+	body := `{"title":"Too Many Requests","status":429,"detail":"rate limit exceeded (fault injection)"}`
+	reset := time.Now().Add(defaultRateLimitPeriod).UTC()
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("X-RateLimit-Limit", "100")
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", reset.Format(time.RFC3339))
+	header.Set("Retry-After", strconv.Itoa(int(defaultRateLimitPeriod/time.Second)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests)),
+		StatusCode:    http.StatusTooManyRequests,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// base returns the transport used to send requests.
+func (t *FaultTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// errReader is an io.Reader that always fails with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -0,0 +1,118 @@
+package mcptest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func newFaultClient(t *testing.T, transport *mcptest.FaultTransport) *mcp.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/mail","version":"1.0.0"}}],"metadata":{"count":1}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := mcp.NewClient(&http.Client{Transport: transport})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client
+}
+
+func TestFaultTransport_Sequence(t *testing.T) {
+	transport := &mcptest.FaultTransport{
+		Sequence: []mcptest.Fault{
+			mcptest.FaultDrop,
+			mcptest.FaultRateLimit,
+			mcptest.FaultMalformedJSON,
+			mcptest.FaultTruncate,
+			mcptest.FaultNone,
+		},
+	}
+	client := newFaultClient(t, transport)
+	ctx := context.Background()
+
+	if _, _, err := client.Servers.List(ctx, nil); !errors.Is(err, mcptest.ErrDropped) {
+		t.Errorf("drop: error = %v, want ErrDropped", err)
+	}
+
+	var rateErr *mcp.RateLimitError
+	if _, _, err := client.Servers.List(ctx, nil); !errors.As(err, &rateErr) {
+		t.Errorf("rate limit: error = %v, want *mcp.RateLimitError", err)
+	} else if rateErr.Rate.Remaining != 0 || rateErr.Rate.Reset.IsZero() {
+		t.Errorf("rate limit: Rate = %+v, want exhausted rate with reset", rateErr.Rate)
+	}
+
+	if _, _, err := client.Servers.List(ctx, nil); err == nil {
+		t.Error("malformed JSON: error = nil, want decode error")
+	}
+
+	if _, _, err := client.Servers.List(ctx, nil); err == nil {
+		t.Error("truncate: error = nil, want read error")
+	}
+
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Errorf("none: error = %v, want nil", err)
+	}
+
+	if got := transport.Requests(); got != 5 {
+		t.Errorf("Requests() = %d, want 5", got)
+	}
+}
+
+func TestFaultTransport_RateLimitBurst(t *testing.T) {
+	transport := &mcptest.FaultTransport{
+		Sequence:       []mcptest.Fault{mcptest.FaultRateLimit},
+		RateLimitBurst: 3,
+	}
+	client := newFaultClient(t, transport)
+
+	for i := 0; i < 3; i++ {
+		var rateErr *mcp.RateLimitError
+		if _, _, err := client.Servers.List(context.Background(), nil); !errors.As(err, &rateErr) {
+			t.Errorf("request %d: error = %v, want *mcp.RateLimitError", i, err)
+		}
+	}
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Errorf("request after burst: error = %v, want nil", err)
+	}
+}
+
+func TestFaultTransport_Delay(t *testing.T) {
+	transport := &mcptest.FaultTransport{
+		Sequence: []mcptest.Fault{mcptest.FaultDelay},
+		Delay:    time.Hour,
+	}
+	client := newFaultClient(t, transport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := client.Servers.List(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestFaultTransport_Rates(t *testing.T) {
+	transport := &mcptest.FaultTransport{
+		Rates: map[mcptest.Fault]float64{mcptest.FaultDrop: 1},
+	}
+	client := newFaultClient(t, transport)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Servers.List(context.Background(), nil); !errors.Is(err, mcptest.ErrDropped) {
+			t.Errorf("request %d: error = %v, want ErrDropped", i, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package metrics exports Prometheus metrics about the requests sent by an
+// mcp.Client: request counts, latencies and errors per endpoint, and the
+// rate limit remaining reported by the registry. It is a separate module, so
+// that only programs importing it depend on Prometheus.
+//
+//	collector := metrics.NewCollector()
+//	prometheus.MustRegister(collector)
+//	client, err := mcp.NewClient(nil, collector.Option())
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// otherEndpoint labels requests not created by mcp.Client.NewRouteRequest.
+const otherEndpoint = "other"
+
+// Collector records the requests of the clients it is installed in and
+// exports them as Prometheus metrics. It implements prometheus.Collector,
+// so it can be registered with a prometheus.Registerer. Endpoints are
+// labeled with route names, such as "list-servers", which keeps the number
+// of series small.
+//
+// Every attempt is recorded, so a request retried by mcp.WithRetry counts
+// once per attempt. A Collector is safe for concurrent use and may be
+// installed in several clients.
+type Collector struct {
+	requests  *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	remaining *prometheus.GaugeVec
+}
+
+// NewCollector returns a Collector exporting the metrics
+//
+//	mcp_registry_client_requests_total{endpoint,method,code}
+//	mcp_registry_client_request_errors_total{endpoint}
+//	mcp_registry_client_request_duration_seconds{endpoint}
+//	mcp_registry_client_rate_limit_remaining{endpoint}
+//
+// The code label is "error" for requests that got no response. Requests
+// that got no response or an error status count as errors.
+func NewCollector() *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_registry_client_requests_total",
+			Help: "Requests sent to the MCP registry, by endpoint, method and status code.",
+		}, []string{"endpoint", "method", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_registry_client_request_errors_total",
+			Help: "Requests to the MCP registry that failed or returned an error status, by endpoint.",
+		}, []string{"endpoint"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_registry_client_request_duration_seconds",
+			Help:    "Time until the response headers of MCP registry requests were received, by endpoint.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		remaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcp_registry_client_rate_limit_remaining",
+			Help: "Requests remaining in the current rate limit window, as last reported by the MCP registry, by endpoint.",
+		}, []string{"endpoint"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.duration.Describe(ch)
+	c.remaining.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.duration.Collect(ch)
+	c.remaining.Collect(ch)
+}
+
+// Option returns an mcp.Option that installs the collector in a client.
+func (c *Collector) Option() mcp.Option {
+	return mcp.WithMiddleware(c.Middleware)
+}
+
+// Middleware is an mcp.Middleware that records the requests sent through
+// next, for use with mcp.WithMiddleware alongside other middleware.
+func (c *Collector) Middleware(next http.RoundTripper) http.RoundTripper {
+	return mcp.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		endpoint, ok := mcp.RouteFromContext(req.Context())
+		if !ok {
+			endpoint = otherEndpoint
+		}
+
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		c.duration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+
+		code := "error"
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+			if remaining, convErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); convErr == nil {
+				c.remaining.WithLabelValues(endpoint).Set(float64(remaining))
+			}
+		}
+		c.requests.WithLabelValues(endpoint, req.Method, code).Inc()
+		if err != nil || resp.StatusCode >= 400 {
+			c.errors.WithLabelValues(endpoint).Inc()
+		}
+
+		return resp, err
+	})
+}
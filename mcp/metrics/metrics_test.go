@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lujin3/go-mcp-registry/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions/9.9.9", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Server not found"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	collector := NewCollector()
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	client, err := mcp.NewClient(nil, mcp.WithBaseURL(server.URL), collector.Option())
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		if _, _, err := client.Servers.List(ctx, nil); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+	if _, _, err := client.Servers.GetByNameExactVersion(ctx, "com.example/weather", "9.9.9"); err == nil {
+		t.Fatal("GetByNameExactVersion returned no error")
+	}
+
+	want := `
+# HELP mcp_registry_client_rate_limit_remaining Requests remaining in the current rate limit window, as last reported by the MCP registry, by endpoint.
+# TYPE mcp_registry_client_rate_limit_remaining gauge
+mcp_registry_client_rate_limit_remaining{endpoint="list-servers"} 42
+# HELP mcp_registry_client_request_errors_total Requests to the MCP registry that failed or returned an error status, by endpoint.
+# TYPE mcp_registry_client_request_errors_total counter
+mcp_registry_client_request_errors_total{endpoint="get-server-version"} 1
+# HELP mcp_registry_client_requests_total Requests sent to the MCP registry, by endpoint, method and status code.
+# TYPE mcp_registry_client_requests_total counter
+mcp_registry_client_requests_total{code="200",endpoint="list-servers",method="GET"} 2
+mcp_registry_client_requests_total{code="404",endpoint="get-server-version",method="GET"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want),
+		"mcp_registry_client_requests_total",
+		"mcp_registry_client_request_errors_total",
+		"mcp_registry_client_rate_limit_remaining",
+	); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(collector, "mcp_registry_client_request_duration_seconds"); n != 2 {
+		t.Errorf("duration histograms = %d, want one per endpoint", n)
+	}
+}
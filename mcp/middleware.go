@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Middleware wraps the transport that sends the client's requests, for
+// example to log, cache, or add headers to them. It is called once, by
+// NewClient, with the next transport in the chain.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as
+// an http.RoundTripper, typically when writing a Middleware:
+//
+//	logging := func(next http.RoundTripper) http.RoundTripper {
+//		return mcp.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			log.Printf("%s %s", req.Method, req.URL)
+//			return next.RoundTrip(req)
+//		})
+//	}
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware returns an Option that routes every request through
+// middleware, without replacing the http.Client passed to NewClient. The
+// first middleware added is the outermost: it sees each request first and
+// each response last.
+//
+// Middleware runs for every attempt of a request, after Client.Do has added
+// credentials and other headers, so it observes requests exactly as sent.
+// It wraps the transport configured with WithTLSConfig or
+// WithClientCertificate regardless of the order of the options.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) error {
+		for _, mw := range middleware {
+			if mw == nil {
+				return fmt.Errorf("middleware cannot be nil")
+			}
+		}
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+// applyMiddleware replaces the client's http.Client with a copy whose
+// transport is wrapped in the client's middleware.
+func (c *Client) applyMiddleware() error {
+	transport := c.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		transport = c.middleware[i](transport)
+		if transport == nil {
+			return fmt.Errorf("middleware %d returned a nil transport", i)
+		}
+	}
+
+	client := *c.client
+	client.Transport = transport
+	c.client = &client
+	return nil
+}
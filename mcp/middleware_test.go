@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Trace-Id"); got != "trace-1" {
+			t.Errorf("X-Trace-Id = %q, want trace-1", got)
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+req.Header.Get("Authorization"))
+				resp, err := next.RoundTrip(req)
+				calls = append(calls, name+" done")
+				return resp, err
+			})
+		}
+	}
+	tracing := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return next.RoundTrip(req)
+		})
+	}
+
+	httpClient := &http.Client{}
+	client, err := NewClient(httpClient,
+		WithBaseURL(server.URL),
+		WithAuthToken("registry-jwt"),
+		WithMiddleware(record("outer"), record("inner")),
+		WithMiddleware(tracing),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if httpClient.Transport != nil {
+		t.Error("NewClient modified the caller's http.Client")
+	}
+
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	want := []string{
+		"outer Bearer registry-jwt",
+		"inner Bearer registry-jwt",
+		"inner done",
+		"outer done",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("middleware calls = %q, want %q", calls, want)
+	}
+}
+
+func TestWithMiddleware_Invalid(t *testing.T) {
+	nilTransport := func(http.RoundTripper) http.RoundTripper { return nil }
+	for _, opt := range []Option{WithMiddleware(nil), WithMiddleware(nilTransport)} {
+		if _, err := NewClient(nil, opt); err == nil {
+			t.Error("NewClient returned nil error")
+		}
+	}
+}
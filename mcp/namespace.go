@@ -0,0 +1,223 @@
+package mcp
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxWellKnownSize caps the size of a well-known auth document.
+const maxWellKnownSize = 4096
+
+// wellKnownAuthPath is the path at which publishers serve their MCP public
+// key for HTTP-based namespace authentication.
+const wellKnownAuthPath = "/.well-known/mcp-registry-auth"
+
+// Namespace verification methods reported on NamespaceVerification.Method.
+const (
+	VerificationMethodDNS  = "dns"
+	VerificationMethodHTTP = "http"
+)
+
+// mcpKeyRE matches an MCP public key record such as
+// "v=MCPv1; k=ed25519; p=<base64 key>".
+var mcpKeyRE = regexp.MustCompile(`v=MCPv1;\s*k=ed25519;\s*p=([A-Za-z0-9+/=]+)`)
+
+// DNSResolver looks up DNS TXT records. *net.Resolver satisfies this
+// interface.
+type DNSResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NamespaceVerifier checks whether the domain behind a reverse-DNS server
+// namespace (e.g. "com.example/*") publishes an MCP public key, mirroring the
+// checks the registry performs for DNS and HTTP authentication. Catalogs can
+// use it to display "domain-verified publisher" badges.
+//
+// The zero value is ready to use and performs real DNS and HTTPS lookups.
+type NamespaceVerifier struct {
+	// Resolver is used for DNS TXT lookups.
+	// If nil, net.DefaultResolver is used.
+	Resolver DNSResolver
+
+	// HTTPClient is used to fetch the well-known auth document.
+	// If nil, a client with a 10 second timeout that does not follow
+	// redirects is used.
+	HTTPClient *http.Client
+
+	// SkipDNS disables the DNS TXT record check.
+	SkipDNS bool
+
+	// SkipHTTP disables the well-known HTTP document check.
+	SkipHTTP bool
+}
+
+// NamespaceVerification represents the result of verifying a namespace.
+type NamespaceVerification struct {
+	// Namespace is the reverse-DNS namespace, e.g. "com.example".
+	Namespace string
+
+	// Domain is the domain the key was found on, e.g. "example.com".
+	// For DNS verification this may be a parent of the namespace domain.
+	Domain string
+
+	// Verified reports whether a valid MCP public key was found.
+	Verified bool
+
+	// Method is the verification method that succeeded, either
+	// VerificationMethodDNS or VerificationMethodHTTP.
+	Method string
+
+	// PublicKeys are the MCP public keys published by the domain.
+	PublicKeys []ed25519.PublicKey
+}
+
+// Verify checks the namespace of serverName. The DNS TXT records of the
+// namespace domain and its parent domains are checked first, since DNS
+// authentication grants subdomain namespaces. The well-known HTTP document
+// is checked next, on the exact namespace domain only.
+//
+// Namespaces that do not correspond to a domain, such as "io.github.user",
+// return an unverified result without performing any lookups.
+func (v *NamespaceVerifier) Verify(ctx context.Context, serverName string) (*NamespaceVerification, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context must be non-nil")
+	}
+
+	namespace, _, _ := strings.Cut(serverName, "/")
+	if namespace == "" || !strings.Contains(namespace, ".") {
+		return nil, fmt.Errorf("invalid server namespace: %q", serverName)
+	}
+
+	result := &NamespaceVerification{Namespace: namespace, Domain: ReverseDomain(namespace)}
+	if strings.HasPrefix(namespace, "io.github.") {
+		return result, nil
+	}
+
+	if !v.SkipDNS {
+		labels := strings.Split(result.Domain, ".")
+		for i := 0; i < len(labels)-1; i++ {
+			domain := strings.Join(labels[i:], ".")
+			records, err := v.resolver().LookupTXT(ctx, domain)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				continue
+			}
+			if keys := ParseMCPPublicKeys(records); len(keys) > 0 {
+				result.Domain = domain
+				result.Verified = true
+				result.Method = VerificationMethodDNS
+				result.PublicKeys = keys
+				return result, nil
+			}
+		}
+	}
+
+	if !v.SkipHTTP {
+		keys, err := v.fetchWellKnownKeys(ctx, result.Domain)
+		if err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if len(keys) > 0 {
+			result.Verified = true
+			result.Method = VerificationMethodHTTP
+			result.PublicKeys = keys
+		}
+	}
+
+	return result, nil
+}
+
+func (v *NamespaceVerifier) resolver() DNSResolver {
+	if v.Resolver != nil {
+		return v.Resolver
+	}
+	return net.DefaultResolver
+}
+
+func (v *NamespaceVerifier) httpClient() *http.Client {
+	if v.HTTPClient != nil {
+		return v.HTTPClient
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// fetchWellKnownKeys retrieves the MCP public keys served at the well-known
+// auth path of domain.
+func (v *NamespaceVerifier) fetchWellKnownKeys(ctx context.Context, domain string) ([]ed25519.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+domain+wellKnownAuthPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/plain")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := v.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %d", req.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWellKnownSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxWellKnownSize {
+		return nil, fmt.Errorf("GET %s: response too large", req.URL)
+	}
+
+	return ParseMCPPublicKeys([]string{strings.TrimSpace(string(body))}), nil
+}
+
+// ParseMCPPublicKeys extracts the Ed25519 public keys from records in the
+// "v=MCPv1; k=ed25519; p=<base64 key>" format used by DNS TXT records and
+// well-known auth documents. Malformed records are skipped.
+func ParseMCPPublicKeys(records []string) []ed25519.PublicKey {
+	var keys []ed25519.PublicKey
+	for _, record := range records {
+		matches := mcpKeyRE.FindStringSubmatch(record)
+		if len(matches) != 2 {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(matches[1])
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			continue
+		}
+		keys = append(keys, ed25519.PublicKey(key))
+	}
+	return keys
+}
+
+// FormatMCPPublicKey returns the record publishers serve in DNS TXT records
+// or well-known auth documents to prove ownership of key.
+func FormatMCPPublicKey(key ed25519.PublicKey) string {
+	return "v=MCPv1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(key)
+}
+
+// ReverseDomain reverses the labels of a domain or namespace, converting
+// "example.com" to "com.example" and vice versa.
+func ReverseDomain(s string) string {
+	labels := strings.Split(s, ".")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return strings.Join(labels, ".")
+}
@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := f[name]
+	if !ok {
+		return nil, fmt.Errorf("no such host: %s", name)
+	}
+	return records, nil
+}
+
+func TestNamespaceVerifier_Verify_DNS(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+
+	v := &NamespaceVerifier{
+		Resolver: fakeResolver{
+			"example.com": {"google-site-verification=abc", FormatMCPPublicKey(pub)},
+		},
+		SkipHTTP: true,
+	}
+
+	tests := []struct {
+		name       string
+		serverName string
+		wantDomain string
+		wantOK     bool
+	}{
+		{
+			name:       "exact domain",
+			serverName: "com.example/server",
+			wantDomain: "example.com",
+			wantOK:     true,
+		},
+		{
+			name:       "subdomain namespace verified by parent",
+			serverName: "com.example.mcp/server",
+			wantDomain: "example.com",
+			wantOK:     true,
+		},
+		{
+			name:       "unverified domain",
+			serverName: "org.other/server",
+			wantDomain: "other.org",
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(context.Background(), tt.serverName)
+			if err != nil {
+				t.Fatalf("Verify returned error: %v", err)
+			}
+			if got.Verified != tt.wantOK {
+				t.Errorf("Verify Verified = %v, want %v", got.Verified, tt.wantOK)
+			}
+			if got.Domain != tt.wantDomain {
+				t.Errorf("Verify Domain = %q, want %q", got.Domain, tt.wantDomain)
+			}
+			if tt.wantOK {
+				if got.Method != VerificationMethodDNS {
+					t.Errorf("Verify Method = %q, want %q", got.Method, VerificationMethodDNS)
+				}
+				if len(got.PublicKeys) != 1 || !got.PublicKeys[0].Equal(pub) {
+					t.Errorf("Verify PublicKeys = %v, want [%v]", got.PublicKeys, pub)
+				}
+			}
+		})
+	}
+}
+
+func TestNamespaceVerifier_Verify_HTTP(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != wellKnownAuthPath {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, FormatMCPPublicKey(pub))
+	}))
+	defer server.Close()
+
+	client := server.Client()
+	transport := client.Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	client.Transport = transport
+
+	v := &NamespaceVerifier{HTTPClient: client, SkipDNS: true}
+
+	got, err := v.Verify(context.Background(), "com.example/server")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if !got.Verified || got.Method != VerificationMethodHTTP {
+		t.Errorf("Verify = %+v, want verified via http", got)
+	}
+}
+
+func TestNamespaceVerifier_Verify_GitHubNamespace(t *testing.T) {
+	v := &NamespaceVerifier{Resolver: fakeResolver{}}
+
+	got, err := v.Verify(context.Background(), "io.github.user/server")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if got.Verified {
+		t.Errorf("Verify Verified = true, want false for GitHub namespace")
+	}
+}
+
+func TestNamespaceVerifier_Verify_InvalidName(t *testing.T) {
+	v := &NamespaceVerifier{}
+	if _, err := v.Verify(context.Background(), "no-namespace"); err == nil {
+		t.Error("Verify expected error for name without namespace")
+	}
+}
+
+func TestParseMCPPublicKeys(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+
+	records := []string{
+		FormatMCPPublicKey(pub),
+		"v=MCPv1; k=ed25519; p=not-base64!",
+		"v=MCPv1; k=ed25519; p=c2hvcnQ=",
+		"unrelated",
+	}
+
+	keys := ParseMCPPublicKeys(records)
+	if len(keys) != 1 || !keys[0].Equal(pub) {
+		t.Errorf("ParseMCPPublicKeys = %v, want [%v]", keys, pub)
+	}
+}
+
+func TestReverseDomain(t *testing.T) {
+	if got := ReverseDomain("mcp.example.com"); got != "com.example.mcp" {
+		t.Errorf("ReverseDomain = %q, want %q", got, "com.example.mcp")
+	}
+}
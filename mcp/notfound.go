@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// NotFoundPolicy selects how ServersService lookups report a server or
+// version that does not exist.
+type NotFoundPolicy int
+
+const (
+	// NotFoundDefault keeps the behavior documented by each method: Get,
+	// GetByNameExactVersion and ListVersionsByName return the 404
+	// *ErrorResponse, while the search-based helpers and ResolveVersion
+	// return a nil result without error.
+	NotFoundDefault NotFoundPolicy = iota
+
+	// NotFoundAsNil makes every lookup return a nil result without error.
+	NotFoundAsNil
+
+	// NotFoundAsError makes every lookup return a *NotFoundError, which
+	// matches ErrNotFound.
+	NotFoundAsError
+)
+
+// WithNotFoundPolicy returns an Option that sets how ServersService
+// lookups report missing servers, so callers can handle them the same way
+// whichever method they use. It applies to Get, GetByNameExactVersion,
+// ListVersionsByName, ListByName, GetByNameLatest,
+// GetByNameLatestActiveVersion, ResolveVersion and the methods built on
+// them, and to VersionTimeline.
+//
+// Write operations are not affected; they always return a *NotFoundError
+// for a missing version.
+func WithNotFoundPolicy(p NotFoundPolicy) Option {
+	return func(c *Client) error {
+		if p < NotFoundDefault || p > NotFoundAsError {
+			return fmt.Errorf("invalid not found policy: %d", p)
+		}
+		c.notFoundPolicy = p
+		return nil
+	}
+}
+
+// WithNotFoundSuggestions returns an Option that attaches up to n names of
+// similar servers, found with ServersService.SuggestNames, to the
+// *NotFoundError returned by lookups, so CLIs and chat bots can ask "did you
+// mean" without a lookup of their own. Suggestions are looked up only for
+// errors returned as *NotFoundError, such as with
+// WithNotFoundPolicy(NotFoundAsError), and only for names not found at all:
+// a missing version of an existing server gets none. A failed suggestion
+// search leaves the error without suggestions.
+func WithNotFoundSuggestions(n int) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("invalid number of not found suggestions: %d", n)
+		}
+		c.notFoundSuggestions = n
+		return nil
+	}
+}
+
+// notFound applies the client's not-found policy to err, the error of a
+// request looking up the server named name at version. Errors other than
+// 404 responses are returned unchanged.
+func (s *ServersService) notFound(ctx context.Context, err error, name, version string) error {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil || errResp.Response.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	switch s.client.notFoundPolicy {
+	case NotFoundAsNil:
+		return nil
+	case NotFoundAsError:
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return err
+		}
+		return s.suggest(ctx, &NotFoundError{ErrorResponse: errResp, Name: name, Version: version})
+	}
+	return err
+}
+
+// missing returns the error for a lookup of the server named name at
+// version that found nothing without a 404 response, according to the
+// client's not-found policy.
+func (s *ServersService) missing(ctx context.Context, name, version string) error {
+	if s.client.notFoundPolicy == NotFoundAsError {
+		return s.suggest(ctx, &NotFoundError{Name: name, Version: version})
+	}
+	return nil
+}
+
+// suggest attaches the names of servers similar to the missing one to err,
+// if the client is configured with WithNotFoundSuggestions.
+func (s *ServersService) suggest(ctx context.Context, err *NotFoundError) *NotFoundError {
+	n := s.client.notFoundSuggestions
+	if n == 0 || err.Name == "" {
+		return err
+	}
+	// A missing version of an existing server is not a misspelled name.
+	// Get is not used, as its own not-found error would be suggested for.
+	// A 404 response for the latest version already tells the name is
+	// missing.
+	if err.Version != "" && (err.Version != "latest" || err.ErrorResponse == nil) {
+		params := map[string]string{"serverName": err.Name, "version": "latest"}
+		req, reqErr := s.client.NewRouteRequest(RouteGetServerVersion, params, nil, nil)
+		if reqErr != nil {
+			return err
+		}
+		if _, getErr := s.client.Do(ctx, req, nil); getErr == nil {
+			return err
+		}
+	}
+	names, suggestErr := s.SuggestNames(ctx, err.Name, n)
+	if suggestErr == nil {
+		err.Suggestions = names
+	}
+	return err
+}
@@ -0,0 +1,221 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithNotFoundPolicy(t *testing.T) {
+	ctx := context.Background()
+	lookups := map[string]func(*Client) (bool, error){
+		"Get": func(c *Client) (bool, error) {
+			server, _, err := c.Servers.Get(ctx, "com.example/missing", nil)
+			return server == nil, err
+		},
+		"GetByNameExactVersion": func(c *Client) (bool, error) {
+			server, _, err := c.Servers.GetByNameExactVersion(ctx, "com.example/missing", "1.0.0")
+			return server == nil, err
+		},
+		"ListVersionsByName": func(c *Client) (bool, error) {
+			versions, _, err := c.Servers.ListVersionsByName(ctx, "com.example/missing")
+			return versions == nil, err
+		},
+		"ListByName": func(c *Client) (bool, error) {
+			servers, _, err := c.Servers.ListByName(ctx, "com.example/missing")
+			return servers == nil, err
+		},
+		"GetByNameLatest": func(c *Client) (bool, error) {
+			server, _, err := c.Servers.GetByNameLatest(ctx, "com.example/missing")
+			return server == nil, err
+		},
+		"ResolveVersion": func(c *Client) (bool, error) {
+			server, _, err := c.Servers.ResolveVersion(ctx, "com.example/missing", nil)
+			return server == nil, err
+		},
+	}
+
+	for _, policy := range []NotFoundPolicy{NotFoundAsNil, NotFoundAsError} {
+		for name, lookup := range lookups {
+			t.Run(fmt.Sprintf("%s/%d", name, policy), func(t *testing.T) {
+				client, mux, _, teardown := setup()
+				defer teardown()
+				if err := WithNotFoundPolicy(policy)(client); err != nil {
+					t.Fatalf("WithNotFoundPolicy returned error: %v", err)
+				}
+
+				mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+				})
+				mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message":"Server not found"}`)
+				})
+
+				isNil, err := lookup(client)
+				if !isNil {
+					t.Error("result is not nil")
+				}
+				switch policy {
+				case NotFoundAsNil:
+					if err != nil {
+						t.Errorf("error = %v, want nil", err)
+					}
+				case NotFoundAsError:
+					var notFound *NotFoundError
+					if !errors.As(err, &notFound) || notFound.Name != "com.example/missing" {
+						t.Errorf("error = %v, want *NotFoundError for com.example/missing", err)
+					}
+					if !errors.Is(err, ErrNotFound) {
+						t.Errorf("errors.Is(%v, ErrNotFound) = false, want true", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestWithNotFoundPolicy_Default(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, _, err := client.Servers.Get(context.Background(), "com.example/missing", nil)
+	if _, ok := err.(*ErrorResponse); !ok {
+		t.Errorf("Get error = %T, want *ErrorResponse", err)
+	}
+
+	if _, err := NewClient(nil, WithNotFoundPolicy(NotFoundPolicy(7))); err == nil {
+		t.Error("WithNotFoundPolicy(7) returned nil error")
+	}
+}
+
+func TestNotFoundError_Error(t *testing.T) {
+	err := &NotFoundError{Name: "com.example/missing", Version: "latest"}
+	if want := "server com.example/missing version latest not found"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if errors.Unwrap(err) != nil {
+		t.Errorf("Unwrap() = %v, want nil", errors.Unwrap(err))
+	}
+}
+
+func TestWithNotFoundSuggestions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	for _, opt := range []Option{WithNotFoundPolicy(NotFoundAsError), WithNotFoundSuggestions(2)} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		var entries []string
+		for _, name := range []string{"io.github.example/weather", "io.github.example/wither"} {
+			if strings.Contains(name, r.URL.Query().Get("search")) {
+				entries = append(entries, fmt.Sprintf(`{"server":{"name":%q,"version":"1.0.0"}}`, name))
+			}
+		}
+		fmt.Fprintf(w, `{"servers":[%s],"metadata":{}}`, strings.Join(entries, ","))
+	})
+	mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v0.1/servers/io.github.example/weather/versions/latest" {
+			fmt.Fprint(w, `{"server":{"name":"io.github.example/weather","version":"1.0.0"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Server not found"}`)
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Servers.Get(ctx, "io.github.example/wether", nil)
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Get error = %v, want *NotFoundError", err)
+	}
+	if want := []string{"io.github.example/weather", "io.github.example/wither"}; !reflect.DeepEqual(notFound.Suggestions, want) {
+		t.Errorf("Suggestions = %v, want %v", notFound.Suggestions, want)
+	}
+	if !strings.Contains(err.Error(), "(did you mean io.github.example/weather or io.github.example/wither?)") {
+		t.Errorf("Error() = %q, want suggestions", err.Error())
+	}
+	if got, want := Humanize(err), "Server io.github.example/wether not found. Did you mean io.github.example/weather or io.github.example/wither?"; got != want {
+		t.Errorf("Humanize = %q, want %q", got, want)
+	}
+
+	// A missing version of an existing server gets no suggestions.
+	_, _, err = client.Servers.GetByNameExactVersion(ctx, "io.github.example/weather", "9.9.9")
+	if !errors.As(err, &notFound) {
+		t.Fatalf("GetByNameExactVersion error = %v, want *NotFoundError", err)
+	}
+	if notFound.Suggestions != nil {
+		t.Errorf("Suggestions = %v for a missing version, want nil", notFound.Suggestions)
+	}
+
+	if err := WithNotFoundSuggestions(-1)(client); err == nil {
+		t.Error("WithNotFoundSuggestions(-1) returned no error")
+	}
+}
+
+func TestWithNotFoundSuggestions_RequestOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	for _, opt := range []Option{WithNotFoundPolicy(NotFoundAsError), WithNotFoundSuggestions(1)} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	searches := 0
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		searches++
+		if got := r.Header.Get("Authorization"); got != "Bearer per-call" {
+			t.Errorf("suggestion search Authorization = %q, want the per-call token", got)
+		}
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("suggestion search X-Tenant = %q, want the per-call header", got)
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Server not found"}`)
+	})
+
+	ctx := context.Background()
+	opts := []RequestOption{WithRequestToken("per-call"), WithRequestHeader("X-Tenant", "acme")}
+	lookups := map[string]func() error{
+		"Get": func() error {
+			_, _, err := client.Servers.Get(ctx, "com.example/missing", nil, opts...)
+			return err
+		},
+		"GetByNameExactVersion": func() error {
+			_, _, err := client.Servers.GetByNameExactVersion(ctx, "com.example/missing", "1.0.0", opts...)
+			return err
+		},
+		"ListVersionsByName": func() error {
+			_, _, err := client.Servers.ListVersionsByName(ctx, "com.example/missing", opts...)
+			return err
+		},
+		"VersionTimeline": func() error {
+			_, _, err := client.Servers.VersionTimeline(ctx, "com.example/missing", opts...)
+			return err
+		},
+	}
+	for name, lookup := range lookups {
+		searches = 0
+		if err := lookup(); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s error = %v, want ErrNotFound", name, err)
+		}
+		if searches == 0 {
+			t.Errorf("%s looked up no suggestions", name)
+		}
+	}
+}
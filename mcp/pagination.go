@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// listPages calls fn for each entry of every page listed with opts. It is
+// the pagination loop behind ListAll and AdminService.ListAll.
+//
+// Registries may invalidate cursors, for example after a long pause between
+// pages. When a page is rejected because of its cursor, listing resumes
+// after the last entry seen, using the registry's "name:version" cursor
+// format; if that is rejected as well, it restarts from the first page.
+// Entries already passed to fn are skipped, so each entry is seen once. opts
+// is not modified.
+func (s *ServersService) listPages(ctx context.Context, opts *ServerListOptions, fn func(entry *registryv0.ServerResponse)) (*Response, error) {
+	listOpts := *opts
+	seen := make(map[string]bool)
+	var last *registryv0.ServerJSON
+	var lastResp *Response
+
+	// Whether listing has resumed after the last entry, or restarted from
+	// the first page, since it last made progress
+	resumed, restarted := false, false
+
+	for {
+		page, resp, err := s.List(ctx, &listOpts)
+		if err != nil {
+			if listOpts.Cursor == "" || !cursorRejected(err) {
+				return resp, err
+			}
+
+			switch {
+			case !resumed && last != nil:
+				listOpts.Cursor = last.Name + ":" + last.Version
+				resumed = true
+			case !restarted && listOpts.Cursor != opts.Cursor:
+				listOpts.Cursor = opts.Cursor
+				restarted = true
+			default:
+				return resp, err
+			}
+			continue
+		}
+		lastResp = resp
+
+		for i := range page.Servers {
+			entry := &page.Servers[i]
+			key := entry.Server.Name + "@" + entry.Server.Version
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			last = &entry.Server
+			resumed, restarted = false, false
+			fn(entry)
+		}
+
+		if page.Metadata.NextCursor == "" {
+			break
+		}
+		listOpts.Cursor = page.Metadata.NextCursor
+	}
+
+	return lastResp, nil
+}
+
+// cursorRejected reports whether err is the registry rejecting a list
+// request's pagination cursor, for example because it expired.
+func cursorRejected(err error) bool {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+
+	switch errResp.Response.StatusCode {
+	case http.StatusGone:
+		return true
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity:
+	default:
+		return false
+	}
+
+	if strings.Contains(strings.ToLower(errResp.Message), "cursor") {
+		return true
+	}
+	for _, e := range errResp.Errors {
+		if strings.EqualFold(e.Field, "cursor") || strings.Contains(strings.ToLower(e.Message), "cursor") {
+			return true
+		}
+	}
+	return false
+}
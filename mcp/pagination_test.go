@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestServersService_ListAll_ExpiredCursor(t *testing.T) {
+	tests := []struct {
+		name string
+		// pages maps cursors to responses; cursors not listed are rejected
+		pages map[string]string
+		// restart, if set, replaces the first page when it is requested
+		// again, as registries issue new cursors
+		restart      string
+		wantNames    []string
+		wantRequests []string
+	}{
+		{
+			name: "resumes after last entry",
+			pages: map[string]string{
+				"":        `{"servers":[{"server":{"name":"a","version":"1.0.0"}}],"metadata":{"nextCursor":"opaque-1"}}`,
+				"a:1.0.0": `{"servers":[{"server":{"name":"b","version":"1.0.0"}}],"metadata":{}}`,
+			},
+			wantNames:    []string{"a", "b"},
+			wantRequests: []string{"", "opaque-1", "a:1.0.0"},
+		},
+		{
+			name: "restarts and skips seen entries",
+			pages: map[string]string{
+				"":        `{"servers":[{"server":{"name":"a","version":"1.0.0"}}],"metadata":{"nextCursor":"opaque-1"}}`,
+				"fresh-1": `{"servers":[{"server":{"name":"b","version":"1.0.0"}}],"metadata":{}}`,
+			},
+			restart:      `{"servers":[{"server":{"name":"a","version":"1.0.0"}}],"metadata":{"nextCursor":"fresh-1"}}`,
+			wantNames:    []string{"a", "b"},
+			wantRequests: []string{"", "opaque-1", "a:1.0.0", "", "fresh-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			var requests []string
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				cursor := r.URL.Query().Get("cursor")
+				requests = append(requests, cursor)
+
+				page, ok := tt.pages[cursor]
+				if cursor == "" && len(requests) > 1 && tt.restart != "" {
+					page = tt.restart
+				}
+				if !ok {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"message":"invalid or expired cursor"}`)
+					return
+				}
+				fmt.Fprint(w, page)
+			})
+
+			servers, _, err := client.Servers.ListAll(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("ListAll returned error: %v", err)
+			}
+
+			var names []string
+			for _, s := range servers {
+				names = append(names, s.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("ListAll names = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(requests, tt.wantRequests) {
+				t.Errorf("requested cursors = %q, want %q", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestServersService_ListAll_CursorRejectedTwice(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("cursor") != "" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"a","version":"1.0.0"}}],"metadata":{"nextCursor":"opaque-1"}}`)
+	})
+
+	servers, _, err := client.Servers.ListAll(context.Background(), nil)
+	if err == nil {
+		t.Fatal("ListAll returned nil error")
+	}
+	if len(servers) != 1 {
+		t.Errorf("ListAll returned %d servers, want the 1 received before the error", len(servers))
+	}
+	// First page, rejected cursor, rejected resume, restart, rejected cursor
+	if requests != 5 {
+		t.Errorf("server received %d requests, want 5", requests)
+	}
+}
+
+func TestCursorRejected(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		errs    []Error
+		want    bool
+	}{
+		{http.StatusGone, "", nil, true},
+		{http.StatusBadRequest, "Invalid cursor", nil, true},
+		{http.StatusUnprocessableEntity, "", []Error{{Field: "cursor"}}, true},
+		{http.StatusBadRequest, "invalid limit", nil, false},
+		{http.StatusInternalServerError, "cursor store unavailable", nil, false},
+	}
+	for _, tt := range tests {
+		err := &ErrorResponse{Response: &http.Response{StatusCode: tt.status}, Message: tt.message, Errors: tt.errs}
+		if got := cursorRejected(err); got != tt.want {
+			t.Errorf("cursorRejected(%d %q) = %v, want %v", tt.status, tt.message, got, tt.want)
+		}
+	}
+}
@@ -28,6 +28,20 @@ func IntValue(v *int) int {
 	return 0
 }
 
+// Float64 returns a pointer to the provided float64 value.
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// Float64Value returns the value of the float64 pointer passed in or
+// 0 if the pointer is nil.
+func Float64Value(v *float64) float64 {
+	if v != nil {
+		return *v
+	}
+	return 0
+}
+
 // Bool returns a pointer to the provided bool value.
 func Bool(v bool) *bool {
 	return &v
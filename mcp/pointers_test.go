@@ -99,6 +99,47 @@ func TestIntValue(t *testing.T) {
 	}
 }
 
+func TestFloat64(t *testing.T) {
+	v := 0.25
+	p := Float64(v)
+
+	if p == nil {
+		t.Fatal("Float64() returned nil")
+	}
+
+	if *p != v {
+		t.Errorf("Float64() = %v, want %v", *p, v)
+	}
+}
+
+func TestFloat64Value(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *float64
+		want  float64
+	}{
+		{
+			name:  "non-nil pointer",
+			input: Float64(0.25),
+			want:  0.25,
+		},
+		{
+			name:  "nil pointer",
+			input: nil,
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Float64Value(tt.input)
+			if got != tt.want {
+				t.Errorf("Float64Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBool(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Prefetcher fetches the versions of servers a user is likely to open
+// before they are opened, so detail views in catalog UIs render without
+// waiting on the registry. A UI passes the items of the page it shows to
+// Prefetch, and reads the details of the item the user opens with Versions.
+// Navigating to another page replaces the prefetch, and Cancel stops it when
+// the user leaves the catalog.
+//
+// A Prefetcher is safe for concurrent use.
+type Prefetcher struct {
+	client  *Client
+	workers int
+
+	mu      sync.Mutex
+	entries map[string]*prefetchEntry
+	cancel  context.CancelFunc // cancels the current prefetch
+}
+
+// prefetchEntry is the versions fetch of one server name.
+type prefetchEntry struct {
+	done     chan struct{} // closed once the fetch finishes
+	versions []registryv0.ServerJSON
+	err      error
+}
+
+// NewPrefetcher returns a Prefetcher that fetches with client, running at
+// most workers fetches at a time. A workers value below 1 is treated as 1.
+func NewPrefetcher(client *Client, workers int) *Prefetcher {
+	return &Prefetcher{
+		client:  client,
+		workers: max(workers, 1),
+		entries: make(map[string]*prefetchEntry),
+	}
+}
+
+// Prefetch starts fetching the versions of servers, in order, in the
+// background. It returns immediately. Prefetch replaces the previous
+// prefetch: fetches for servers no longer listed are canceled and their
+// results dropped, while results for servers listed again are kept.
+func (p *Prefetcher) Prefetch(servers []registryv0.ServerResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cancel != nil {
+		p.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	entries := make(map[string]*prefetchEntry, len(servers))
+	var names []string
+	for _, server := range servers {
+		name := server.Server.Name
+		if _, ok := entries[name]; ok || name == "" {
+			continue
+		}
+		if e, ok := p.entries[name]; ok && isDone(e) && e.err == nil {
+			entries[name] = e
+			continue
+		}
+		entries[name] = &prefetchEntry{done: make(chan struct{})}
+		names = append(names, name)
+	}
+	p.entries = entries
+
+	sem := make(chan struct{}, p.workers)
+	go func() {
+		for _, name := range names {
+			select {
+			case <-ctx.Done():
+				p.finish(name, entries[name], nil, ctx.Err())
+				continue
+			case sem <- struct{}{}:
+			}
+			go func() {
+				defer func() { <-sem }()
+				versions, _, err := p.client.Servers.ListVersionsByName(ctx, name)
+				p.finish(name, entries[name], versions, err)
+			}()
+		}
+	}()
+}
+
+// Cancel stops the current prefetch, for example when the user navigates
+// away from the catalog. Versions already fetched remain available.
+func (p *Prefetcher) Cancel() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// Versions returns the versions of the server named name. It returns the
+// prefetched versions if they are available, waits for a prefetch of name
+// that is in flight, and otherwise fetches them from the registry, as it
+// also does if the prefetch failed or was canceled.
+func (p *Prefetcher) Versions(ctx context.Context, name string, opts ...RequestOption) ([]registryv0.ServerJSON, error) {
+	p.mu.Lock()
+	e, ok := p.entries[name]
+	p.mu.Unlock()
+
+	if ok {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-e.done:
+		}
+		if e.err == nil {
+			return e.versions, nil
+		}
+	}
+
+	versions, _, err := p.client.Servers.ListVersionsByName(ctx, name, opts...)
+	return versions, err
+}
+
+// finish records the result of the fetch of name in e. A failed or
+// canceled fetch is dropped so that Versions fetches name again.
+func (p *Prefetcher) finish(name string, e *prefetchEntry, versions []registryv0.ServerJSON, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.versions, e.err = versions, err
+	if err != nil && p.entries[name] == e {
+		delete(p.entries, name)
+	}
+	close(e.done)
+}
+
+// isDone reports whether the fetch of e has finished.
+func isDone(e *prefetchEntry) bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
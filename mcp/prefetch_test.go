@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestPrefetcher(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	for _, name := range []string{"a", "b", "c"} {
+		mux.HandleFunc("/v0.1/servers/com.example%2F"+name+"/versions", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests[name]++
+			mu.Unlock()
+			fmt.Fprintf(w, `{"servers":[{"server":{"name":"com.example/%s","version":"1.0.0"}}],"metadata":{}}`, name)
+		})
+	}
+
+	page := []registryv0.ServerResponse{
+		{Server: registryv0.ServerJSON{Name: "com.example/a", Version: "1.0.0"}},
+		{Server: registryv0.ServerJSON{Name: "com.example/b", Version: "1.0.0"}},
+		{Server: registryv0.ServerJSON{Name: "com.example/a", Version: "0.9.0"}},
+	}
+
+	p := NewPrefetcher(client, 2)
+	defer p.Cancel()
+	p.Prefetch(page)
+
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "a", "c"} {
+		versions, err := p.Versions(ctx, "com.example/"+name)
+		if err != nil {
+			t.Fatalf("Versions(%s) returned error: %v", name, err)
+		}
+		if len(versions) != 1 || versions[0].Name != "com.example/"+name {
+			t.Errorf("Versions(%s) = %+v, want com.example/%s", name, versions, name)
+		}
+	}
+
+	// Prefetched servers are fetched once, the other server on demand.
+	mu.Lock()
+	defer mu.Unlock()
+	if want := map[string]int{"a": 1, "b": 1, "c": 1}; fmt.Sprint(requests) != fmt.Sprint(want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestPrefetcher_Cancel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	started := make(chan struct{})
+	var requests atomic.Int32
+	mux.HandleFunc("/v0.1/servers/com.example%2Fa/versions", func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Block the prefetch until it is canceled
+			close(started)
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/a","version":"1.0.0"}}],"metadata":{}}`)
+	})
+
+	p := NewPrefetcher(client, 1)
+	p.Prefetch([]registryv0.ServerResponse{{Server: registryv0.ServerJSON{Name: "com.example/a"}}})
+	<-started
+	p.Cancel()
+
+	// The canceled prefetch is replaced by a fetch on demand.
+	versions, err := p.Versions(context.Background(), "com.example/a")
+	if err != nil {
+		t.Fatalf("Versions returned error: %v", err)
+	}
+	if len(versions) != 1 {
+		t.Errorf("Versions returned %d versions, want 1", len(versions))
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
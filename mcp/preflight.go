@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PreflightStatus is the outcome of a preflight check.
+type PreflightStatus string
+
+// Preflight check outcomes.
+const (
+	PreflightOK      PreflightStatus = "ok"
+	PreflightWarning PreflightStatus = "warning"
+	PreflightFailed  PreflightStatus = "failed"
+	PreflightSkipped PreflightStatus = "skipped"
+)
+
+// Names of the checks run by Preflight, in the order they run.
+const (
+	PreflightConnectivity = "connectivity"
+	PreflightAPIVersion   = "api-version"
+	PreflightAuth         = "auth"
+	PreflightRateLimit    = "rate-limit"
+)
+
+// PreflightCheck is the result of one preflight check.
+type PreflightCheck struct {
+	Name   string
+	Status PreflightStatus
+
+	// Detail describes the outcome in a few words, for logs.
+	Detail string
+
+	// Err is the error that failed the check, if any.
+	Err error
+}
+
+// PreflightReport is the result of Preflight.
+type PreflightReport struct {
+	// Checks lists the checks in the order they ran.
+	Checks []PreflightCheck
+
+	// Identity is the identity of the configured registry token, or nil if
+	// there is none or it could not be decoded.
+	Identity *Identity
+
+	// Rate is the rate limit reported by the registry, if any.
+	Rate Rate
+}
+
+// OK reports whether no check failed. Warnings do not count as failures.
+func (r *PreflightReport) OK() bool {
+	return r.Err() == nil
+}
+
+// Err returns the errors of the failed checks joined together, or nil if no
+// check failed.
+func (r *PreflightReport) Err() error {
+	var errs []error
+	for _, c := range r.Checks {
+		if c.Status == PreflightFailed {
+			errs = append(errs, fmt.Errorf("preflight %s: %w", c.Name, c.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check returns the check with the given name, and whether it ran.
+func (r *PreflightReport) Check(name string) (PreflightCheck, bool) {
+	for _, c := range r.Checks {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return PreflightCheck{}, false
+}
+
+// PreflightOptions specifies the optional parameters to Preflight.
+type PreflightOptions struct {
+	// MinRateRemaining is the number of requests that must remain in the
+	// current rate limit window. Fewer is a warning. Defaults to 1.
+	MinRateRemaining int
+
+	// TokenValidFor is how long the registry token must remain valid.
+	// A token expiring sooner is a warning. Defaults to 5 minutes.
+	TokenValidFor time.Duration
+}
+
+// Preflight checks in one call that client can serve requests, for services
+// that should exit at startup rather than fail on their first request:
+//
+//   - connectivity: the registry answers its health endpoint;
+//   - api-version: the registry serves the API version of the client, and
+//     its server list decodes;
+//   - auth: the configured registry token, if any, is a registry token that
+//     has not expired;
+//   - rate-limit: the registry leaves enough requests in the current window.
+//
+// The checks stop at the first failure and the remaining ones are reported
+// as skipped. Failures are reported in the returned report rather than as an
+// error:
+//
+//	if err := mcp.Preflight(ctx, client, nil).Err(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Like WhoAmI, the auth check decodes the token locally and does not verify
+// it with the registry, which has no introspection endpoint.
+func Preflight(ctx context.Context, client *Client, opts *PreflightOptions) *PreflightReport {
+	if opts == nil {
+		opts = &PreflightOptions{}
+	}
+	minRemaining := opts.MinRateRemaining
+	if minRemaining <= 0 {
+		minRemaining = 1
+	}
+	validFor := opts.TokenValidFor
+	if validFor <= 0 {
+		validFor = 5 * time.Minute
+	}
+
+	report := &PreflightReport{}
+	checks := []struct {
+		name string
+		run  func() PreflightCheck
+	}{
+		{PreflightConnectivity, func() PreflightCheck { return checkConnectivity(ctx, client) }},
+		{PreflightAPIVersion, func() PreflightCheck { return checkAPIVersion(ctx, client, report) }},
+		{PreflightAuth, func() PreflightCheck { return checkAuth(ctx, client, validFor, report) }},
+		{PreflightRateLimit, func() PreflightCheck { return checkRateLimit(report.Rate, minRemaining) }},
+	}
+
+	failed := false
+	for _, c := range checks {
+		if failed {
+			report.Checks = append(report.Checks, PreflightCheck{Name: c.name, Status: PreflightSkipped, Detail: "an earlier check failed"})
+			continue
+		}
+		result := c.run()
+		result.Name = c.name
+		report.Checks = append(report.Checks, result)
+		failed = result.Status == PreflightFailed
+	}
+	return report
+}
+
+// checkConnectivity requests the health endpoint of the registry.
+func checkConnectivity(ctx context.Context, client *Client) PreflightCheck {
+	req, err := client.NewRouteRequest(RouteGetHealth, nil, nil, nil)
+	if err != nil {
+		return PreflightCheck{Status: PreflightFailed, Err: err}
+	}
+	var health struct {
+		Status string `json:"status"`
+	}
+	if _, err := client.Do(ctx, req, &health); err != nil {
+		return PreflightCheck{Status: PreflightFailed, Detail: "registry unreachable", Err: err}
+	}
+	if health.Status != "" && health.Status != "ok" {
+		return PreflightCheck{Status: PreflightWarning, Detail: fmt.Sprintf("registry reports status %q", health.Status)}
+	}
+	return PreflightCheck{Status: PreflightOK, Detail: "registry reachable"}
+}
+
+// checkAPIVersion requests one server to check that the registry serves the
+// client's API version, and records the rate limit of the response.
+func checkAPIVersion(ctx context.Context, client *Client, report *PreflightReport) PreflightCheck {
+	req, err := client.NewRouteRequest(RouteListServers, nil, &ServerListOptions{ListOptions: ListOptions{Limit: 1}}, nil)
+	if err != nil {
+		return PreflightCheck{Status: PreflightFailed, Err: err}
+	}
+	var list registryv0.ServerListResponse
+	resp, err := client.Do(ctx, req, &list)
+	if resp != nil {
+		report.Rate = resp.Rate
+	}
+	if err != nil {
+		var errResp *ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			return PreflightCheck{Status: PreflightFailed, Detail: "API version not served", Err: err}
+		}
+		return PreflightCheck{Status: PreflightFailed, Err: err}
+	}
+	return PreflightCheck{Status: PreflightOK, Detail: "API " + client.apiVersion + " served"}
+}
+
+// checkAuth decodes the configured registry token and checks its expiry.
+func checkAuth(ctx context.Context, client *Client, validFor time.Duration, report *PreflightReport) PreflightCheck {
+	token, _, err := client.token(ctx)
+	if err != nil {
+		return PreflightCheck{Status: PreflightFailed, Detail: "token source failed", Err: err}
+	}
+	if token == "" {
+		return PreflightCheck{Status: PreflightSkipped, Detail: "no registry token configured"}
+	}
+	id, err := ParseIdentity(token)
+	if err != nil {
+		return PreflightCheck{Status: PreflightWarning, Detail: "token cannot be checked locally", Err: err}
+	}
+
+	report.Identity = id
+	if id.ExpiresAt.IsZero() {
+		return PreflightCheck{Status: PreflightOK, Detail: "token has no expiry"}
+	}
+	left := id.ExpiresAt.Sub(client.clock.Now())
+	switch {
+	case left <= 0:
+		return PreflightCheck{Status: PreflightFailed, Detail: "token expired", Err: fmt.Errorf("registry token expired at %v", id.ExpiresAt)}
+	case left < validFor:
+		return PreflightCheck{Status: PreflightWarning, Detail: fmt.Sprintf("token expires in %v", left.Round(time.Second))}
+	}
+	return PreflightCheck{Status: PreflightOK, Detail: "token valid until " + id.ExpiresAt.UTC().Format(time.RFC3339)}
+}
+
+// checkRateLimit checks that at least minRemaining requests remain in rate.
+func checkRateLimit(rate Rate, minRemaining int) PreflightCheck {
+	if rate.Limit == 0 {
+		return PreflightCheck{Status: PreflightOK, Detail: "registry reports no rate limit"}
+	}
+	detail := fmt.Sprintf("%d/%d requests remaining", rate.Remaining, rate.Limit)
+	if rate.Remaining < minRemaining {
+		return PreflightCheck{Status: PreflightWarning, Detail: detail}
+	}
+	return PreflightCheck{Status: PreflightOK, Detail: detail}
+}
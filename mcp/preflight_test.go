@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPreflight(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"limit": "1"})
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "2")
+		fmt.Fprint(w, `{"servers":[],"metadata":{"count":0}}`)
+	})
+
+	exp := time.Now().Add(time.Hour).Unix()
+	ctx := ContextWithToken(context.Background(), testRegistryToken(fmt.Sprintf(`{"auth_method_sub":"octocat","exp":%d}`, exp)))
+	report := Preflight(ctx, client, &PreflightOptions{MinRateRemaining: 5})
+
+	if err := report.Err(); err != nil {
+		t.Fatalf("Preflight failed: %v", err)
+	}
+	want := map[string]PreflightStatus{
+		PreflightConnectivity: PreflightOK,
+		PreflightAPIVersion:   PreflightOK,
+		PreflightAuth:         PreflightOK,
+		PreflightRateLimit:    PreflightWarning,
+	}
+	if len(report.Checks) != len(want) {
+		t.Fatalf("Preflight ran %d checks, want %d", len(report.Checks), len(want))
+	}
+	for name, status := range want {
+		if c, ok := report.Check(name); !ok || c.Status != status {
+			t.Errorf("check %s = %+v, want status %s", name, c, status)
+		}
+	}
+	if report.Identity == nil || report.Identity.Subject != "octocat" {
+		t.Errorf("Identity = %+v, want subject octocat", report.Identity)
+	}
+	if report.Rate.Remaining != 2 {
+		t.Errorf("Rate.Remaining = %d, want 2", report.Rate.Remaining)
+	}
+}
+
+func TestPreflight_StopsAtFailure(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+
+	report := Preflight(context.Background(), client, nil)
+	if report.OK() {
+		t.Fatal("Preflight OK = true, want false for a missing API version")
+	}
+	wantStatus := []PreflightStatus{PreflightOK, PreflightFailed, PreflightSkipped, PreflightSkipped}
+	for i, c := range report.Checks {
+		if c.Status != wantStatus[i] {
+			t.Errorf("check %s status = %s, want %s", c.Name, c.Status, wantStatus[i])
+		}
+	}
+}
+
+func TestPreflight_ExpiredToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/health", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[]}`)
+	})
+
+	ctx := ContextWithToken(context.Background(), testRegistryToken(`{"exp":1}`))
+	report := Preflight(ctx, client, nil)
+
+	c, _ := report.Check(PreflightAuth)
+	if c.Status != PreflightFailed {
+		t.Errorf("auth check = %+v, want failed", c)
+	}
+	if report.Err() == nil {
+		t.Error("Err = nil, want the expired token error")
+	}
+
+	report = Preflight(context.Background(), client, nil)
+	if c, _ := report.Check(PreflightAuth); c.Status != PreflightSkipped {
+		t.Errorf("auth check without token = %+v, want skipped", c)
+	}
+}
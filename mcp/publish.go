@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Publish publishes a new server version to the registry and returns the
+// created entry, including the registry metadata assigned to it.
+//
+// Publishing requires a registry token with publish permission for the
+// server's namespace, configured with WithAuthToken, ContextWithToken or
+// WithRequestToken:
+//
+//	client, _ := mcp.NewClient(nil, mcp.WithAuthToken(registryToken))
+//	created, _, err := client.Servers.Publish(ctx, server)
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/publish-server
+func (s *ServersService) Publish(ctx context.Context, server *registryv0.ServerJSON, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	if server == nil {
+		return nil, nil, fmt.Errorf("server cannot be nil")
+	}
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+
+	req, err := s.client.NewRouteRequest(RoutePublishServer, nil, nil, server)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var created *registryv0.ServerResponse
+	resp, err := s.client.Do(ctx, req, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created, resp, nil
+}
+
+// Update replaces the server.json of an existing server version, for example
+// to fix its description, remotes, or packages, and returns the updated
+// entry. The name and version of server must match name and version, as
+// servers cannot be renamed and versions cannot be changed in place.
+//
+// Updating requires a registry token with edit permission for the server,
+// configured with WithAuthToken, ContextWithToken or WithRequestToken.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/edit-server
+func (s *ServersService) Update(ctx context.Context, name, version string, server *registryv0.ServerJSON, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	if server == nil {
+		return nil, nil, fmt.Errorf("server cannot be nil")
+	}
+	if server.Name != name || server.Version != version {
+		return nil, nil, fmt.Errorf("server %s version %s does not match %s version %s", server.Name, server.Version, name, version)
+	}
+
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+
+	return s.edit(ctx, name, version, server, "")
+}
+
+// editOptions specifies the query parameters of the edit-server endpoint.
+type editOptions struct {
+	Status model.Status `url:"status,omitempty"`
+}
+
+// edit sends server to the edit-server endpoint, changing the status of the
+// version as well if status is not empty.
+func (s *ServersService) edit(ctx context.Context, name, version string, server *registryv0.ServerJSON, status model.Status) (*registryv0.ServerResponse, *Response, error) {
+	params := map[string]string{"serverName": name, "version": version}
+	req, err := s.client.NewRouteRequest(RouteEditServer, params, &editOptions{Status: status}, server)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updated *registryv0.ServerResponse
+	resp, err := s.client.Do(ctx, req, &updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, nil
+}
+
+// Delete deletes a server version. The registry keeps deleted versions as
+// tombstones with status deleted, which are hidden from consumers and cannot
+// be restored.
+//
+// Deleting requires a registry token with edit permission for the server,
+// configured with WithAuthToken, ContextWithToken or WithRequestToken. A
+// *NotFoundError is returned if the version does not exist, and a
+// *ForbiddenError if the token does not grant permission to delete it.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/edit-server
+func (s *ServersService) Delete(ctx context.Context, name, version string, opts ...RequestOption) (*Response, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+
+	_, resp, err := s.setStatus(ctx, name, version, model.StatusDeleted)
+	return resp, serverError(err, name, version)
+}
+
+// SetStatus changes the status of a server version and returns the updated
+// entry. The current server.json of the version is sent unchanged alongside
+// the new status, as required by the registry.
+//
+// Changing the status requires a registry token with edit permission for the
+// server, configured with WithAuthToken, ContextWithToken or
+// WithRequestToken. The official registry server does not allow deleted
+// versions to change status again.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/edit-server
+func (s *ServersService) SetStatus(ctx context.Context, name, version string, status model.Status, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	switch status {
+	case model.StatusActive, model.StatusDeprecated, model.StatusDeleted:
+	default:
+		return nil, nil, fmt.Errorf("invalid status: %q", status)
+	}
+
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+
+	updated, resp, err := s.setStatus(ctx, name, version, status)
+	return updated, resp, serverError(err, name, version)
+}
+
+// Deprecate marks a server version as deprecated. Deprecated versions remain
+// available but are skipped by helpers that look for active versions.
+func (s *ServersService) Deprecate(ctx context.Context, name, version string, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	return s.SetStatus(ctx, name, version, model.StatusDeprecated, opts...)
+}
+
+// Undeprecate marks a deprecated server version as active again.
+func (s *ServersService) Undeprecate(ctx context.Context, name, version string, opts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	return s.SetStatus(ctx, name, version, model.StatusActive, opts...)
+}
+
+// setStatus changes the status of a server version, sending its current
+// server.json unchanged as required by the edit-server endpoint.
+func (s *ServersService) setStatus(ctx context.Context, name, version string, status model.Status) (*registryv0.ServerResponse, *Response, error) {
+	params := map[string]string{"serverName": name, "version": version}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersion, params, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var current *registryv0.ServerResponse
+	resp, err := s.client.Do(ctx, req, &current)
+	if err != nil {
+		return nil, resp, err
+	}
+	if current == nil {
+		return nil, resp, fmt.Errorf("server %s version %s not found", name, version)
+	}
+
+	return s.edit(ctx, name, version, &current.Server, status)
+}
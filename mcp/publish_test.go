@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServersService_Publish(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if got, want := r.Header.Get("Authorization"), "Bearer registry-token"; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+
+		var body registryv0.ServerJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if body.Name != "io.github.example/weather" || body.Version != "1.2.0" {
+			t.Errorf("request body = %+v, want io.github.example/weather 1.2.0", body)
+		}
+
+		fmt.Fprint(w, `{
+			"server": {"name":"io.github.example/weather","version":"1.2.0"},
+			"_meta": {"io.modelcontextprotocol.registry/official": {
+				"status":"active","publishedAt":"2025-01-01T00:00:00Z","updatedAt":"2025-01-01T00:00:00Z","isLatest":true
+			}}
+		}`)
+	})
+
+	ctx := ContextWithToken(context.Background(), "registry-token")
+	created, _, err := client.Servers.Publish(ctx, &registryv0.ServerJSON{
+		Name:    "io.github.example/weather",
+		Version: "1.2.0",
+	})
+	if err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+
+	if created.Server.Name != "io.github.example/weather" {
+		t.Errorf("Publish Server.Name = %q, want io.github.example/weather", created.Server.Name)
+	}
+	if created.Meta.Official == nil || created.Meta.Official.Status != model.StatusActive || !created.Meta.Official.IsLatest {
+		t.Errorf("Publish Meta.Official = %+v, want active latest", created.Meta.Official)
+	}
+}
+
+func TestServersService_Publish_Forbidden(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"title":"Forbidden","status":403,"detail":"You do not have permission to publish this server"}`)
+	})
+
+	_, resp, err := client.Servers.Publish(context.Background(), &registryv0.ServerJSON{Name: "com.example/server"})
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Publish error = %v, want *ErrorResponse", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Publish response = %v, want status 403", resp)
+	}
+}
+
+func TestServersService_Publish_NilServer(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if _, _, err := client.Servers.Publish(context.Background(), nil); err == nil {
+		t.Error("Publish(nil) returned nil error")
+	}
+}
+
+func TestServersService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmail/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testFormValues(t, r, values{})
+
+		var body registryv0.ServerJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if body.Description != "Fixed description" {
+			t.Errorf("request body Description = %q, want %q", body.Description, "Fixed description")
+		}
+
+		fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0","description":"Fixed description"}}`)
+	})
+
+	updated, _, err := client.Servers.Update(context.Background(), "com.example/mail", "1.0.0", &registryv0.ServerJSON{
+		Name:        "com.example/mail",
+		Version:     "1.0.0",
+		Description: "Fixed description",
+	})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if updated.Server.Description != "Fixed description" {
+		t.Errorf("Update Server.Description = %q, want %q", updated.Server.Description, "Fixed description")
+	}
+}
+
+func TestServersService_Update_Mismatch(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	tests := []struct {
+		name   string
+		server *registryv0.ServerJSON
+	}{
+		{name: "nil server", server: nil},
+		{name: "renamed", server: &registryv0.ServerJSON{Name: "com.example/other", Version: "1.0.0"}},
+		{name: "different version", server: &registryv0.ServerJSON{Name: "com.example/mail", Version: "2.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := client.Servers.Update(context.Background(), "com.example/mail", "1.0.0", tt.server); err == nil {
+				t.Error("Update returned nil error")
+			}
+		})
+	}
+}
+
+func TestServersService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmail/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0"}}`)
+		case "PUT":
+			testFormValues(t, r, values{"status": "deleted"})
+			fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0"},
+				"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deleted"}}}`)
+		default:
+			t.Errorf("unexpected request method %s", r.Method)
+		}
+	})
+
+	if _, err := client.Servers.Delete(context.Background(), "com.example/mail", "1.0.0"); err != nil {
+		t.Errorf("Delete returned error: %v", err)
+	}
+}
+
+func TestServersService_Delete_Errors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmissing/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"title":"Not Found","status":404,"detail":"Server not found"}`)
+	})
+	mux.HandleFunc("/v0.1/servers/com.example%2Fmail/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"title":"Forbidden","status":403}`)
+	})
+
+	_, err := client.Servers.Delete(context.Background(), "com.example/missing", "1.0.0")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Delete error = %v, want *NotFoundError", err)
+	}
+	if notFound.Name != "com.example/missing" || notFound.Version != "1.0.0" {
+		t.Errorf("NotFoundError = %+v, want com.example/missing 1.0.0", notFound)
+	}
+
+	_, err = client.Servers.Delete(context.Background(), "com.example/mail", "1.0.0")
+	var forbidden *ForbiddenError
+	if !errors.As(err, &forbidden) {
+		t.Fatalf("Delete error = %v, want *ForbiddenError", err)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusForbidden {
+		t.Errorf("Delete error does not unwrap to the 403 *ErrorResponse")
+	}
+}
+
+func TestServersService_Deprecate(t *testing.T) {
+	tests := []struct {
+		name       string
+		call       func(*ServersService) (*registryv0.ServerResponse, *Response, error)
+		wantStatus model.Status
+	}{
+		{
+			name: "deprecate",
+			call: func(s *ServersService) (*registryv0.ServerResponse, *Response, error) {
+				return s.Deprecate(context.Background(), "com.example/mail", "1.0.0")
+			},
+			wantStatus: model.StatusDeprecated,
+		},
+		{
+			name: "undeprecate",
+			call: func(s *ServersService) (*registryv0.ServerResponse, *Response, error) {
+				return s.Undeprecate(context.Background(), "com.example/mail", "1.0.0")
+			},
+			wantStatus: model.StatusActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/v0.1/servers/com.example%2Fmail/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "GET" {
+					fmt.Fprint(w, `{"server":{"name":"com.example/mail","version":"1.0.0"}}`)
+					return
+				}
+				testMethod(t, r, "PUT")
+				testFormValues(t, r, values{"status": string(tt.wantStatus)})
+				fmt.Fprintf(w, `{"server":{"name":"com.example/mail","version":"1.0.0"},
+					"_meta":{"io.modelcontextprotocol.registry/official":{"status":%q}}}`, tt.wantStatus)
+			})
+
+			updated, _, err := tt.call(client.Servers)
+			if err != nil {
+				t.Fatalf("returned error: %v", err)
+			}
+			if updated.Meta.Official == nil || updated.Meta.Official.Status != tt.wantStatus {
+				t.Errorf("Meta.Official = %+v, want status %s", updated.Meta.Official, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestServersService_SetStatus_Invalid(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if _, _, err := client.Servers.SetStatus(context.Background(), "com.example/mail", "1.0.0", "hidden"); err == nil {
+		t.Error("SetStatus with invalid status returned nil error")
+	}
+}
@@ -0,0 +1,227 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxReadmeSize caps the number of bytes read from a README to keep
+// catalog pages from pulling arbitrarily large documents.
+const maxReadmeSize = 1 << 20
+
+// githubRawBaseURL is the host serving raw GitHub file contents.
+// It is a variable so tests can point it at a local server.
+var githubRawBaseURL = "https://raw.githubusercontent.com/"
+
+// readmeNames lists the README file names tried, in order.
+var readmeNames = []string{"README.md", "readme.md", "Readme.md", "README.markdown", "README"}
+
+// Readme represents the documentation fetched for a server's repository.
+type Readme struct {
+	// URL is the raw URL the README was retrieved from.
+	URL string
+
+	// Content is the sanitized Markdown content of the README.
+	Content string
+}
+
+// FetchReadme retrieves the README of the repository declared by server and
+// returns its sanitized Markdown content. Raw file URLs are derived from the
+// repository URL for GitHub and GitLab hosted repositories, honoring the
+// repository subfolder when present. If a nil httpClient is provided,
+// http.DefaultClient is used.
+//
+// FetchReadme deliberately does not go through Client.Do so that registry
+// credentials are never sent to third-party hosts.
+//
+// Returns nil if no README could be found.
+func FetchReadme(ctx context.Context, httpClient *http.Client, server *registryv0.ServerJSON) (*Readme, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context must be non-nil")
+	}
+	if server == nil {
+		return nil, fmt.Errorf("server must be non-nil")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	candidates, err := readmeURLs(server.Repository.URL, server.Repository.Subfolder)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range candidates {
+		content, found, err := fetchRaw(ctx, httpClient, u)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return &Readme{URL: u, Content: SanitizeMarkdown(content)}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// readmeURLs returns the candidate raw README URLs for a repository.
+func readmeURLs(repoURL, subfolder string) ([]string, error) {
+	return rawFileURLs(repoURL, subfolder, readmeNames)
+}
+
+// rawFileURLs returns the raw content URLs of the named files within a
+// GitHub or GitLab repository, relative to subfolder.
+func rawFileURLs(repoURL, subfolder string, names []string) ([]string, error) {
+	if repoURL == "" {
+		return nil, fmt.Errorf("server has no repository URL")
+	}
+
+	info, err := ParseRepository(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if subfolder == "" {
+		subfolder = info.Subfolder
+	}
+
+	var base string
+	switch info.Forge {
+	case ForgeGitHub:
+		base = githubRawBaseURL + path.Join(info.Owner, info.Repo, "HEAD")
+	case ForgeGitLab:
+		base = fmt.Sprintf("https://%s/%s/-/raw/HEAD", info.Host, path.Join(info.Owner, info.Repo))
+	default:
+		return nil, fmt.Errorf("unsupported repository host: %s", info.Host)
+	}
+
+	var urls []string
+	for _, name := range names {
+		urls = append(urls, strings.TrimSuffix(base, "/")+"/"+path.Join(strings.Trim(subfolder, "/"), name))
+	}
+
+	return urls, nil
+}
+
+// fetchRaw retrieves the body at u. A 404 response is reported as not found
+// rather than an error so callers can try the next candidate.
+func fetchRaw(ctx context.Context, httpClient *http.Client, u string) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", false, fmt.Errorf("GET %s: %d", u, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxReadmeSize))
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(data), true, nil
+}
+
+var (
+	htmlCommentRE = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+	// dangerousElemREs match active elements together with their content.
+	// RE2 has no backreferences, so each element gets its own expression.
+	dangerousElemREs = []*regexp.Regexp{
+		regexp.MustCompile(`(?is)<script\b.*?</script\s*>`),
+		regexp.MustCompile(`(?is)<style\b.*?</style\s*>`),
+		regexp.MustCompile(`(?is)<iframe\b.*?</iframe\s*>`),
+		regexp.MustCompile(`(?is)<object\b.*?</object\s*>`),
+	}
+
+	// autolinkRE matches a Markdown autolink to a web or mail address at
+	// the start of a string.
+	autolinkRE = regexp.MustCompile(`^<(?:(?i:https?|mailto):[^\s<>]*|[\w.+-]+@[\w-]+(?:\.[\w-]+)+)>`)
+
+	// scriptURLRE matches the script or data scheme of a Markdown link
+	// destination or link reference definition, including the obfuscations
+	// renderers and browsers undo: character references, backslash escapes,
+	// and whitespace and control characters.
+	scriptURLRE = regexp.MustCompile(`(\]\(\s*<?|\]:\s*<?)` + obfuscatedSchemes("javascript", "vbscript", "data"))
+)
+
+// obfuscatedSchemes returns a regular expression matching any of the URL
+// schemes, followed by a colon, as written in possibly obfuscated Markdown.
+func obfuscatedSchemes(schemes ...string) string {
+	const noise = `(?:[\x00-\x20\\]|&(?:tab|newline);|&#0*(?:9|10|13|32);?|&#x0*(?:9|a|d|20);?)*`
+	alternatives := make([]string, len(schemes))
+	for i, scheme := range schemes {
+		var b strings.Builder
+		b.WriteString(noise)
+		for _, c := range scheme {
+			lower, upper := unicode.ToLower(c), unicode.ToUpper(c)
+			fmt.Fprintf(&b, `(?:%c|&#0*(?:%d|%d);?|&#x0*(?:%x|%x);?)`, lower, lower, upper, lower, upper)
+			b.WriteString(noise)
+		}
+		alternatives[i] = b.String()
+	}
+	return `(?i)(?:` + strings.Join(alternatives, "|") + `)(?::|&colon;|&#0*58;?|&#x0*3a;?)`
+}
+
+// SanitizeMarkdown makes Markdown safe to render inline in a web page. It
+// removes HTML comments and active elements such as <script> and <iframe>,
+// escapes all other raw HTML so that it renders as text, and neutralizes
+// script and data URLs in links and images. Autolinks to web and mail
+// addresses are kept. Because raw HTML cannot be told apart from code
+// without parsing the Markdown, angle brackets of tags in code blocks and
+// spans are escaped too and render as "&lt;". Images in the Markdown are
+// not checked; like icons resolved with IconOptions.AllowSVG, they must be
+// displayed with <img> so that SVG images cannot run script.
+func SanitizeMarkdown(s string) string {
+	s = htmlCommentRE.ReplaceAllString(s, "")
+	for _, re := range dangerousElemREs {
+		s = re.ReplaceAllString(s, "")
+	}
+	s = scriptURLRE.ReplaceAllString(s, "${1}#")
+	return escapeRawHTML(s)
+}
+
+// escapeRawHTML replaces the "<" starting every HTML tag, comment,
+// declaration or processing instruction in s with "&lt;".
+func escapeRawHTML(s string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(s, '<')
+		if i < 0 || i == len(s)-1 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+
+		if link := autolinkRE.FindString(s); link != "" {
+			b.WriteString(link)
+			s = s[len(link):]
+			continue
+		}
+		if c := s[1]; c == '/' || c == '!' || c == '?' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') {
+			b.WriteString("&lt;")
+		} else {
+			b.WriteByte('<')
+		}
+		s = s[1:]
+	}
+}
@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestFetchReadme(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	oldBase := githubRawBaseURL
+	githubRawBaseURL = server.URL + "/"
+	defer func() { githubRawBaseURL = oldBase }()
+
+	mux.HandleFunc("/example/repo/HEAD/servers/gmail/readme.md", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "# Gmail\n<script>alert(1)</script>\nUsage docs.")
+	})
+
+	srv := &registryv0.ServerJSON{
+		Name: "com.example/gmail",
+		Repository: model.Repository{
+			URL:       "https://github.com/example/repo",
+			Source:    "github",
+			Subfolder: "servers/gmail",
+		},
+	}
+
+	readme, err := FetchReadme(context.Background(), server.Client(), srv)
+	if err != nil {
+		t.Fatalf("FetchReadme returned error: %v", err)
+	}
+	if readme == nil {
+		t.Fatal("FetchReadme returned nil readme")
+	}
+
+	wantURL := server.URL + "/example/repo/HEAD/servers/gmail/readme.md"
+	if readme.URL != wantURL {
+		t.Errorf("FetchReadme URL = %q, want %q", readme.URL, wantURL)
+	}
+	if want := "# Gmail\n\nUsage docs."; readme.Content != want {
+		t.Errorf("FetchReadme Content = %q, want %q", readme.Content, want)
+	}
+}
+
+func TestFetchReadme_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	oldBase := githubRawBaseURL
+	githubRawBaseURL = server.URL + "/"
+	defer func() { githubRawBaseURL = oldBase }()
+
+	srv := &registryv0.ServerJSON{
+		Repository: model.Repository{URL: "https://github.com/example/repo"},
+	}
+
+	readme, err := FetchReadme(context.Background(), server.Client(), srv)
+	if err != nil {
+		t.Fatalf("FetchReadme returned error: %v", err)
+	}
+	if readme != nil {
+		t.Errorf("FetchReadme = %+v, want nil", readme)
+	}
+}
+
+func TestFetchReadme_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  *registryv0.ServerJSON
+		wantErr string
+	}{
+		{
+			name:    "nil server",
+			server:  nil,
+			wantErr: "server must be non-nil",
+		},
+		{
+			name:    "missing repository",
+			server:  &registryv0.ServerJSON{},
+			wantErr: "no repository URL",
+		},
+		{
+			name: "unsupported host",
+			server: &registryv0.ServerJSON{
+				Repository: model.Repository{URL: "https://example.com/owner/repo"},
+			},
+			wantErr: "unsupported repository host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FetchReadme(context.Background(), nil, tt.server)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("FetchReadme error = %v, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadmeURLs_GitLab(t *testing.T) {
+	urls, err := readmeURLs("https://gitlab.com/group/sub/repo.git", "")
+	if err != nil {
+		t.Fatalf("readmeURLs returned error: %v", err)
+	}
+
+	want := "https://gitlab.com/group/sub/repo/-/raw/HEAD/README.md"
+	if urls[0] != want {
+		t.Errorf("readmeURLs[0] = %q, want %q", urls[0], want)
+	}
+}
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain markdown untouched",
+			input: "# Title\n\nSome metadata: value, one=1.",
+			want:  "# Title\n\nSome metadata: value, one=1.",
+		},
+		{
+			name:  "html comment removed",
+			input: "a<!-- hidden -->b",
+			want:  "ab",
+		},
+		{
+			name:  "iframe removed with content",
+			input: "a<iframe src=\"x\">inner</iframe>b",
+			want:  "ab",
+		},
+		{
+			name:  "raw html escaped",
+			input: `<img src="logo.png" onerror="alert(1)">`,
+			want:  `&lt;img src="logo.png" onerror="alert(1)">`,
+		},
+		{
+			name:  "attribute without whitespace escaped",
+			input: "<img/src=x/onerror=alert(1)>",
+			want:  "&lt;img/src=x/onerror=alert(1)>",
+		},
+		{
+			name:  "svg escaped",
+			input: "<svg/onload=alert(1)>",
+			want:  "&lt;svg/onload=alert(1)>",
+		},
+		{
+			name:  "entity-encoded href escaped",
+			input: `<a href="jav&#x61;script:alert(1)">x</a>`,
+			want:  `&lt;a href="jav&#x61;script:alert(1)">x&lt;/a>`,
+		},
+		{
+			name:  "closing tag and declaration escaped",
+			input: "</p><!DOCTYPE html><?xml?>",
+			want:  "&lt;/p>&lt;!DOCTYPE html>&lt;?xml?>",
+		},
+		{
+			name:  "comparison and autolinks kept",
+			input: "a < b, <https://example.com/a?b=c> and <dev@example.com>",
+			want:  "a < b, <https://example.com/a?b=c> and <dev@example.com>",
+		},
+		{
+			name:  "javascript autolink escaped",
+			input: "<javascript:alert(1)>",
+			want:  "&lt;javascript:alert(1)>",
+		},
+		{
+			name:  "javascript link neutralized",
+			input: "[click](javascript:alert(1))",
+			want:  "[click](#alert(1))",
+		},
+		{
+			name:  "entity-encoded link neutralized",
+			input: "[click](jav&#x61;script&#58;alert(1))",
+			want:  "[click](#alert(1))",
+		},
+		{
+			name:  "whitespace in link scheme neutralized",
+			input: "[click](java\tscript:alert(1)) ![i]( \x01JaVa\\Script\\:alert(1))",
+			want:  "[click](#alert(1)) ![i]( #alert(1))",
+		},
+		{
+			name:  "pointy link destination neutralized",
+			input: "[click](<data:text/html,x>)",
+			want:  "[click](<#text/html,x>)",
+		},
+		{
+			name:  "reference definition neutralized",
+			input: "[click][x]\n\n[x]:\n  vbscript:msgbox(1)",
+			want:  "[click][x]\n\n[x]:\n  #msgbox(1)",
+		},
+		{
+			name:  "safe link untouched",
+			input: "[docs](https://example.com/javascript:guide)",
+			want:  "[docs](https://example.com/javascript:guide)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeMarkdown(tt.input); got != tt.want {
+				t.Errorf("SanitizeMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// provenanceMetaKey is the publisher-provided metadata key under which
+// PublishRelease records where a release was built from.
+const provenanceMetaKey = "provenance"
+
+// GitRelease describes the commit checked out in a git repository.
+type GitRelease struct {
+	// Commit is the full hash of HEAD.
+	Commit string
+
+	// Tag is the tag pointing at HEAD, or "" if there is none.
+	Tag string
+
+	// Dirty reports whether the working tree has uncommitted changes.
+	Dirty bool
+}
+
+// ReadGitRelease returns the commit and tag checked out in the git
+// repository at repoPath. It runs the git command, which must be installed.
+func ReadGitRelease(ctx context.Context, repoPath string) (*GitRelease, error) {
+	commit, err := runGit(ctx, repoPath, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	status, err := runGit(ctx, repoPath, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	// describe fails if no tag points at HEAD.
+	tag, _ := runGit(ctx, repoPath, "describe", "--tags", "--exact-match", "HEAD")
+
+	return &GitRelease{Commit: commit, Tag: tag, Dirty: status != ""}, nil
+}
+
+// runGit runs git with args in dir and returns its trimmed output.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// PublishReleaseOptions specifies the optional parameters to the
+// ServersService.PublishRelease method.
+type PublishReleaseOptions struct {
+	// ServerJSON is the path of the manifest, relative to the repository.
+	// Defaults to "server.json".
+	ServerJSON string
+
+	// AllowDirty publishes even if the working tree has uncommitted
+	// changes, which then are not described by the recorded commit.
+	AllowDirty bool
+}
+
+// PublishRelease publishes the server.json of the git repository at
+// repoPath for the release checked out there, so that a release is one call
+// in a GitHub Action. HEAD must be tagged with the version of the manifest,
+// optionally prefixed with "v" and, for monorepos, a path such as
+// "servers/weather/", and the working tree must be clean unless
+// opts.AllowDirty is set.
+//
+// The tag and commit are recorded in the publisher-provided metadata under
+// "provenance", along with the URL of the workflow run when running in
+// GitHub Actions:
+//
+//	"provenance": {"tag": "v1.2.0", "commit": "4f9c...", "buildUrl": "https://github.com/..."}
+//
+// Publishing requires a registry token, as described by Publish.
+func (s *ServersService) PublishRelease(ctx context.Context, repoPath string, opts *PublishReleaseOptions, reqOpts ...RequestOption) (*registryv0.ServerResponse, *Response, error) {
+	if opts == nil {
+		opts = &PublishReleaseOptions{}
+	}
+	manifest := opts.ServerJSON
+	if manifest == "" {
+		manifest = "server.json"
+	}
+
+	release, err := ReadGitRelease(ctx, repoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if release.Tag == "" {
+		return nil, nil, fmt.Errorf("commit %s is not tagged", release.Commit)
+	}
+	if release.Dirty && !opts.AllowDirty {
+		return nil, nil, fmt.Errorf("working tree of %s has uncommitted changes", repoPath)
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoPath, manifest))
+	if err != nil {
+		return nil, nil, err
+	}
+	var server registryv0.ServerJSON
+	if err := json.Unmarshal(data, &server); err != nil {
+		return nil, nil, fmt.Errorf("decoding %s: %w", manifest, err)
+	}
+	if !tagMatchesVersion(release.Tag, server.Version) {
+		return nil, nil, fmt.Errorf("tag %s does not match version %s of %s", release.Tag, server.Version, manifest)
+	}
+
+	provenance := map[string]any{"tag": release.Tag, "commit": release.Commit}
+	if url := actionsRunURL(); url != "" {
+		provenance["buildUrl"] = url
+	}
+	if server.Meta == nil {
+		server.Meta = &registryv0.ServerMeta{}
+	}
+	if server.Meta.PublisherProvided == nil {
+		server.Meta.PublisherProvided = make(map[string]any)
+	}
+	server.Meta.PublisherProvided[provenanceMetaKey] = provenance
+
+	return s.Publish(ctx, &server, reqOpts...)
+}
+
+// tagMatchesVersion reports whether the git tag names version, as in
+// "1.2.0", "v1.2.0" or "servers/weather/v1.2.0".
+func tagMatchesVersion(tag, version string) bool {
+	if i := strings.LastIndex(tag, "/"); i >= 0 {
+		tag = tag[i+1:]
+	}
+	return version != "" && (tag == version || tag == "v"+version)
+}
+
+// actionsRunURL returns the URL of the GitHub Actions workflow run the
+// process is part of, or "" outside GitHub Actions.
+func actionsRunURL() string {
+	server, repo, run := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+	if os.Getenv("GITHUB_ACTIONS") != "true" || server == "" || repo == "" || run == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, run)
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// gitRepo creates a git repository holding a server.json of version and
+// returns its path.
+func gitRepo(t *testing.T, version string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	manifest := fmt.Sprintf(`{"name":"com.example/weather","description":"Weather","version":%q}`, version)
+	if err := os.WriteFile(filepath.Join(dir, "server.json"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "init", "-q")
+	git(t, dir, "add", "server.json")
+	git(t, dir, "commit", "-q", "-m", "Release")
+	return dir
+}
+
+// git runs git with args in dir.
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	args = append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestServersService_PublishRelease(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	dir := gitRepo(t, "1.2.0")
+	git(t, dir, "tag", "v1.2.0")
+	commit := git(t, dir, "rev-parse", "HEAD")
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "example/weather")
+	t.Setenv("GITHUB_RUN_ID", "42")
+
+	var published registryv0.ServerJSON
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if err := json.NewDecoder(r.Body).Decode(&published); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		fmt.Fprint(w, `{"server":{"name":"com.example/weather","version":"1.2.0"}}`)
+	})
+
+	created, _, err := client.Servers.PublishRelease(context.Background(), dir, nil)
+	if err != nil {
+		t.Fatalf("PublishRelease returned error: %v", err)
+	}
+	if created.Server.Version != "1.2.0" {
+		t.Errorf("PublishRelease returned version %s, want 1.2.0", created.Server.Version)
+	}
+
+	want := map[string]any{
+		"tag":      "v1.2.0",
+		"commit":   commit,
+		"buildUrl": "https://github.com/example/weather/actions/runs/42",
+	}
+	if published.Meta == nil || !reflect.DeepEqual(published.Meta.PublisherProvided["provenance"], want) {
+		t.Errorf("published metadata = %+v, want provenance %v", published.Meta, want)
+	}
+}
+
+func TestServersService_PublishRelease_Rejected(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("PublishRelease published a rejected release")
+	})
+
+	untagged := gitRepo(t, "1.2.0")
+
+	mismatched := gitRepo(t, "1.2.0")
+	git(t, mismatched, "tag", "v1.1.0")
+
+	dirty := gitRepo(t, "1.2.0")
+	git(t, dirty, "tag", "servers/weather/v1.2.0")
+	if err := os.WriteFile(filepath.Join(dirty, "notes.txt"), []byte("wip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		dir     string
+		wantErr string
+	}{
+		"untagged":   {dir: untagged, wantErr: "is not tagged"},
+		"mismatched": {dir: mismatched, wantErr: "tag v1.1.0 does not match version 1.2.0"},
+		"dirty":      {dir: dirty, wantErr: "uncommitted changes"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := client.Servers.PublishRelease(context.Background(), tt.dir, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PublishRelease returned error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Forge identifies the hosting service of a source repository.
+type Forge string
+
+// Supported forge values.
+const (
+	ForgeGitHub    Forge = "github"
+	ForgeGitLab    Forge = "gitlab"
+	ForgeBitbucket Forge = "bitbucket"
+	ForgeOther     Forge = "other"
+)
+
+// RepositoryInfo represents a normalized source repository location.
+type RepositoryInfo struct {
+	// Forge is the hosting service of the repository.
+	Forge Forge
+
+	// Host is the host name serving the repository, e.g. "github.com".
+	Host string
+
+	// Owner is the user, organization, or group owning the repository.
+	// GitLab subgroups are included, separated by slashes.
+	Owner string
+
+	// Repo is the repository name without any ".git" suffix.
+	Repo string
+
+	// Subfolder is the path within the repository, if the URL pointed
+	// below the repository root.
+	Subfolder string
+}
+
+// URL returns the canonical HTTPS URL of the repository root.
+func (r *RepositoryInfo) URL() string {
+	return fmt.Sprintf("https://%s/%s/%s", r.Host, r.Owner, r.Repo)
+}
+
+// ParseRepository parses a repository URL and returns its forge, owner,
+// repository name, and subfolder. HTTPS, SSH ("git@host:owner/repo.git" and
+// "ssh://git@host/owner/repo"), and "git+https" forms are normalized to the
+// same result. Browse URLs pointing into a tree (e.g.
+// "https://github.com/owner/repo/tree/main/servers/gmail") yield the path
+// below the branch as Subfolder.
+func ParseRepository(rawURL string) (*RepositoryInfo, error) {
+	s := strings.TrimSpace(rawURL)
+	if s == "" {
+		return nil, fmt.Errorf("repository URL cannot be empty")
+	}
+
+	// Rewrite scp-like SSH syntax (git@host:owner/repo) into a URL
+	if !strings.Contains(s, "://") {
+		if at := strings.Index(s, "@"); at >= 0 {
+			if colon := strings.Index(s[at:], ":"); colon > 0 {
+				s = "ssh://" + s[:at+colon] + "/" + s[at+colon+1:]
+			}
+		} else {
+			s = "https://" + s
+		}
+	}
+	s = strings.TrimPrefix(s, "git+")
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ssh", "git":
+	default:
+		return nil, fmt.Errorf("unsupported repository URL scheme: %s", u.Scheme)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if host == "" {
+		return nil, fmt.Errorf("repository URL %q has no host", rawURL)
+	}
+
+	info := &RepositoryInfo{Host: host, Forge: forgeForHost(host)}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" {
+		return nil, fmt.Errorf("repository URL %q does not name a repository", rawURL)
+	}
+
+	switch info.Forge {
+	case ForgeGitLab:
+		// GitLab supports nested groups; "/-/" separates the project
+		// path from browse routes such as "/-/tree/main/dir"
+		project := parts
+		var rest []string
+		for i, p := range parts {
+			if p == "-" {
+				project, rest = parts[:i], parts[i+1:]
+				break
+			}
+		}
+		if len(project) < 2 {
+			return nil, fmt.Errorf("repository URL %q does not name a repository", rawURL)
+		}
+		info.Owner = strings.Join(project[:len(project)-1], "/")
+		info.Repo = project[len(project)-1]
+		if len(rest) > 2 && (rest[0] == "tree" || rest[0] == "blob") {
+			info.Subfolder = strings.Join(rest[2:], "/")
+		}
+	default:
+		info.Owner = parts[0]
+		info.Repo = parts[1]
+		rest := parts[2:]
+		if len(rest) > 2 && (rest[0] == "tree" || rest[0] == "blob" || rest[0] == "src") {
+			info.Subfolder = strings.Join(rest[2:], "/")
+		}
+	}
+
+	info.Repo = strings.TrimSuffix(info.Repo, ".git")
+	if info.Repo == "" {
+		return nil, fmt.Errorf("repository URL %q does not name a repository", rawURL)
+	}
+
+	return info, nil
+}
+
+// forgeForHost returns the forge serving host. Self-hosted GitLab instances
+// are recognized by a "gitlab" label in the host name.
+func forgeForHost(host string) Forge {
+	switch {
+	case host == "github.com":
+		return ForgeGitHub
+	case host == "bitbucket.org":
+		return ForgeBitbucket
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab.") || strings.Contains(host, ".gitlab."):
+		return ForgeGitLab
+	default:
+		return ForgeOther
+	}
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawURL  string
+		want    *RepositoryInfo
+		wantErr bool
+	}{
+		{
+			name:   "github https",
+			rawURL: "https://github.com/example/repo",
+			want:   &RepositoryInfo{Forge: ForgeGitHub, Host: "github.com", Owner: "example", Repo: "repo"},
+		},
+		{
+			name:   "github https with .git and www",
+			rawURL: "https://www.github.com/example/repo.git",
+			want:   &RepositoryInfo{Forge: ForgeGitHub, Host: "github.com", Owner: "example", Repo: "repo"},
+		},
+		{
+			name:   "github scp-like ssh",
+			rawURL: "git@github.com:example/repo.git",
+			want:   &RepositoryInfo{Forge: ForgeGitHub, Host: "github.com", Owner: "example", Repo: "repo"},
+		},
+		{
+			name:   "github ssh url",
+			rawURL: "ssh://git@github.com/example/repo",
+			want:   &RepositoryInfo{Forge: ForgeGitHub, Host: "github.com", Owner: "example", Repo: "repo"},
+		},
+		{
+			name:   "git+https",
+			rawURL: "git+https://github.com/example/repo.git",
+			want:   &RepositoryInfo{Forge: ForgeGitHub, Host: "github.com", Owner: "example", Repo: "repo"},
+		},
+		{
+			name:   "github tree subfolder",
+			rawURL: "https://github.com/example/repo/tree/main/servers/gmail",
+			want:   &RepositoryInfo{Forge: ForgeGitHub, Host: "github.com", Owner: "example", Repo: "repo", Subfolder: "servers/gmail"},
+		},
+		{
+			name:   "gitlab subgroup with tree",
+			rawURL: "https://gitlab.com/group/sub/repo/-/tree/main/pkg",
+			want:   &RepositoryInfo{Forge: ForgeGitLab, Host: "gitlab.com", Owner: "group/sub", Repo: "repo", Subfolder: "pkg"},
+		},
+		{
+			name:   "self-hosted gitlab",
+			rawURL: "https://gitlab.example.com/team/repo",
+			want:   &RepositoryInfo{Forge: ForgeGitLab, Host: "gitlab.example.com", Owner: "team", Repo: "repo"},
+		},
+		{
+			name:   "bitbucket src subfolder",
+			rawURL: "https://bitbucket.org/team/repo/src/main/docs",
+			want:   &RepositoryInfo{Forge: ForgeBitbucket, Host: "bitbucket.org", Owner: "team", Repo: "repo", Subfolder: "docs"},
+		},
+		{
+			name:   "other forge without scheme",
+			rawURL: "codeberg.org/owner/repo",
+			want:   &RepositoryInfo{Forge: ForgeOther, Host: "codeberg.org", Owner: "owner", Repo: "repo"},
+		},
+		{
+			name:    "empty",
+			rawURL:  "",
+			wantErr: true,
+		},
+		{
+			name:    "no repository path",
+			rawURL:  "https://github.com/example",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			rawURL:  "ftp://github.com/example/repo",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRepository(tt.rawURL)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRepository(%q) expected error, got %+v", tt.rawURL, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRepository(%q) unexpected error: %v", tt.rawURL, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRepository(%q) = %+v, want %+v", tt.rawURL, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepositoryInfo_URL(t *testing.T) {
+	info, err := ParseRepository("git@gitlab.com:group/sub/repo.git")
+	if err != nil {
+		t.Fatalf("ParseRepository returned error: %v", err)
+	}
+
+	if got, want := info.URL(), "https://gitlab.com/group/sub/repo"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption customizes a single API call. Request options take
+// precedence over the equivalent context and client settings, and apply to
+// every request the call sends, including all pages fetched by helpers such
+// as ListAll.
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the settings of RequestOptions.
+type requestOptions struct {
+	token    string
+	hasToken bool
+
+	header  http.Header
+	noCache bool
+
+	timeout time.Duration
+}
+
+// requestOptionsContextKey is the context key for the request options of a
+// call.
+type requestOptionsContextKey struct{}
+
+// WithRequestToken returns a RequestOption that authenticates the call with
+// token, overriding any token attached with ContextWithToken and any
+// credential configured on the client. This lets one process publish to
+// several namespaces with different tokens:
+//
+//	client.Servers.Publish(ctx, github, mcp.WithRequestToken(githubToken))
+//	client.Servers.Publish(ctx, company, mcp.WithRequestToken(dnsToken))
+//
+// An empty token sends the call without credentials.
+func WithRequestToken(token string) RequestOption {
+	return func(o *requestOptions) {
+		o.token, o.hasToken = token, true
+	}
+}
+
+// WithRequestHeader returns a RequestOption that sets the header key to
+// value on the call's requests, replacing any value set by the client, for
+// example to send a tracing header with one call.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithNoCache returns a RequestOption that makes the call fetch list pages
+// and versions from the registry even if they are in the cache configured
+// with WithListCache or WithVersionCache. Fetched responses still refresh
+// the cache.
+func WithNoCache() RequestOption {
+	return func(o *requestOptions) {
+		o.noCache = true
+	}
+}
+
+// WithCallTimeout returns a RequestOption that bounds the whole call by d,
+// including every page fetched by helpers such as ListAll and any retries.
+// When d elapses the call returns context.DeadlineExceeded. A shorter
+// deadline already set on the context still applies. A non-positive d has
+// no effect.
+func WithCallTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// withRequestOptions returns ctx with the settings of opts applied on top of
+// any request options ctx already carries, so they reach every request a
+// call sends. The caller must call the returned cancel function once the
+// call is done to release the resources of a call timeout.
+func withRequestOptions(ctx context.Context, opts []RequestOption) (context.Context, context.CancelFunc) {
+	if len(opts) == 0 {
+		return ctx, func() {}
+	}
+
+	o := requestOptionsFromContext(ctx)
+	o.header = o.header.Clone()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cancel := func() {}
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		// The deadline now lives on ctx; nested calls must not restart it.
+		o.timeout = 0
+	}
+	if o.hasToken {
+		ctx = ContextWithToken(ctx, o.token)
+	}
+	return context.WithValue(ctx, requestOptionsContextKey{}, o), cancel
+}
+
+// requestOptionsFromContext returns the request options carried by ctx.
+func requestOptionsFromContext(ctx context.Context) requestOptions {
+	o, _ := ctx.Value(requestOptionsContextKey{}).(requestOptions)
+	return o
+}
+
+// setRequestHeaders sets the headers of the request options carried by ctx
+// on req. req must be a copy owned by the caller.
+func setRequestHeaders(ctx context.Context, req *http.Request) {
+	header := requestOptionsFromContext(ctx).header
+	if len(header) == 0 {
+		return
+	}
+
+	req.Header = req.Header.Clone()
+	for key, values := range header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestWithRequestToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		opts       []RequestOption
+		wantHeader string
+	}{
+		{
+			name:       "client token",
+			ctx:        context.Background(),
+			wantHeader: "Bearer client-token",
+		},
+		{
+			name:       "overrides client token",
+			ctx:        context.Background(),
+			opts:       []RequestOption{WithRequestToken("call-token")},
+			wantHeader: "Bearer call-token",
+		},
+		{
+			name:       "overrides context token",
+			ctx:        ContextWithToken(context.Background(), "tenant-token"),
+			opts:       []RequestOption{WithRequestToken("call-token")},
+			wantHeader: "Bearer call-token",
+		},
+		{
+			name:       "last option wins",
+			ctx:        context.Background(),
+			opts:       []RequestOption{WithRequestToken("first"), WithRequestToken("second")},
+			wantHeader: "Bearer second",
+		},
+		{
+			name:       "empty token sends no credentials",
+			ctx:        context.Background(),
+			opts:       []RequestOption{WithRequestToken("")},
+			wantHeader: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			if err := WithAuthToken("client-token")(client); err != nil {
+				t.Fatalf("WithAuthToken returned error: %v", err)
+			}
+
+			mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != tt.wantHeader {
+					t.Errorf("Authorization = %q, want %q", got, tt.wantHeader)
+				}
+				fmt.Fprint(w, `{"server":{"name":"com.example/server","version":"1.0.0"}}`)
+			})
+
+			server := &registryv0.ServerJSON{Name: "com.example/server", Version: "1.0.0"}
+			if _, _, err := client.Servers.Publish(tt.ctx, server, tt.opts...); err != nil {
+				t.Fatalf("Publish returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWithRequestToken_SetStatus(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	// Both the read of the current version and the edit use the call token
+	mux.HandleFunc("/v0.1/servers/com.example%2Fserver/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer call-token"; got != want {
+			t.Errorf("%s Authorization = %q, want %q", r.Method, got, want)
+		}
+		fmt.Fprint(w, `{"server":{"name":"com.example/server","version":"1.0.0"}}`)
+	})
+
+	if _, _, err := client.Servers.Deprecate(context.Background(), "com.example/server", "1.0.0", WithRequestToken("call-token")); err != nil {
+		t.Fatalf("Deprecate returned error: %v", err)
+	}
+}
+
+func TestRequestOptions_ReadMethods(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithDefaultHeaders(http.Header{"X-Trace-Id": {"client"}})(client); err != nil {
+		t.Fatalf("WithDefaultHeaders returned error: %v", err)
+	}
+
+	var traces []string
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		traces = append(traces, r.Header.Get("X-Trace-Id"))
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/a","version":"1.0.0"}}],"metadata":{"nextCursor":"com.example/a:1.0.0"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/b","version":"1.0.0"}}],"metadata":{}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/com.example%2Fa/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		traces = append(traces, r.Header.Get("X-Trace-Id"))
+		fmt.Fprint(w, `{"server":{"name":"com.example/a","version":"1.0.0"}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.ListAll(ctx, nil, WithRequestHeader("X-Trace-Id", "crawl")); err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if _, _, err := client.Servers.Get(ctx, "com.example/a", nil, WithRequestHeader("X-Trace-Id", "get")); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if _, _, err := client.Servers.Get(ctx, "com.example/a", nil); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	if want := "[crawl crawl get client]"; fmt.Sprint(traces) != want {
+		t.Errorf("X-Trace-Id of requests = %v, want %s", traces, want)
+	}
+}
+
+func TestWithNoCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithListCache(time.Hour)(client); err != nil {
+		t.Fatalf("WithListCache returned error: %v", err)
+	}
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	ctx := context.Background()
+	for _, opts := range [][]RequestOption{nil, {WithNoCache()}, nil} {
+		if _, _, err := client.Servers.List(ctx, nil, opts...); err != nil {
+			t.Fatalf("List returned error: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("server received %d requests, want 2", requests)
+	}
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	_, _, err := client.Servers.ListAll(context.Background(), nil, WithCallTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ListAll returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,191 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ResolveOptions specifies the optional parameters to the
+// ServersService.ResolveVersion method.
+type ResolveOptions struct {
+	// Channel, if set, only considers versions whose channel it includes, as
+	// reported by Channel.Includes.
+	Channel Channel
+
+	// IncludePrereleases also considers versions with a prerelease tag, such
+	// as 2.0.0-beta.1. By default they are skipped, as in npm and cargo. It
+	// has no effect if Channel is set, since the channel decides which
+	// prereleases qualify.
+	IncludePrereleases bool
+
+	// IncludeDeprecated and IncludeDeleted also consider versions with these
+	// statuses. By default only active versions are considered.
+	IncludeDeprecated bool
+	IncludeDeleted    bool
+
+	// Constraint, if set, only considers versions satisfying the semantic
+	// version constraint, such as "^1.2" or ">= 2.0, < 3".
+	Constraint string
+}
+
+// allows reports whether opts consider versions with status.
+func (opts *ResolveOptions) allows(status model.Status) bool {
+	switch status {
+	case model.StatusDeprecated:
+		return opts.IncludeDeprecated
+	case model.StatusDeleted:
+		return opts.IncludeDeleted
+	}
+	return true
+}
+
+// VersionStatus is the status of a server version.
+type VersionStatus struct {
+	Version string
+	Status  model.Status
+
+	// Deprecation is the deprecation notice of deprecated versions.
+	Deprecation *Deprecation
+}
+
+// NoActiveVersionError occurs when ResolveVersion finds versions of a server
+// that would match, but all of them were excluded because of their status,
+// so installers can explain why nothing resolved.
+type NoActiveVersionError struct {
+	Name string // Name of the server
+
+	// Excluded lists the matching versions and their statuses.
+	Excluded []VersionStatus
+}
+
+func (e *NoActiveVersionError) Error() string {
+	excluded := make([]string, len(e.Excluded))
+	for i, v := range e.Excluded {
+		excluded[i] = fmt.Sprintf("%s is %s", v.Version, v.Status)
+	}
+	msg := fmt.Sprintf("server %s has no active version: %s", e.Name, strings.Join(excluded, ", "))
+	if r := e.ReplacedBy(); r != nil {
+		msg += fmt.Sprintf("; replaced by %s", r)
+	}
+	return msg
+}
+
+// ReplacedBy returns the successor named by the deprecation notice of the
+// highest excluded version that names one, or nil, so update tooling can
+// suggest what to install instead.
+func (e *NoActiveVersionError) ReplacedBy() *Replacement {
+	var replacement *Replacement
+	var highest *semver.Version
+	for _, v := range e.Excluded {
+		if v.Deprecation == nil || v.Deprecation.ReplacedBy == nil {
+			continue
+		}
+		version, err := parseVersion(v.Version)
+		if err != nil {
+			continue
+		}
+		if highest == nil || versionGreater(version, highest) {
+			replacement, highest = v.Deprecation.ReplacedBy, version
+		}
+	}
+	return replacement
+}
+
+// ResolveVersion returns the highest version of the server named name that
+// opts allow, by semantic version comparison. Prereleases, deprecated and
+// deleted versions are skipped unless opts include them, and versions that
+// are not semantic versions are ignored.
+//
+// If versions matched but were all skipped because of their status, a
+// *NoActiveVersionError lists them. If no version matched at all, nil is
+// returned without error, unless the client is configured otherwise with
+// WithNotFoundPolicy. Renamed servers are followed as described by
+// WithAliases.
+func (s *ServersService) ResolveVersion(ctx context.Context, name string, opts *ResolveOptions, reqOpts ...RequestOption) (*registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
+	if opts == nil {
+		opts = &ResolveOptions{}
+	}
+
+	server, excluded, resp, err := s.resolve(ctx, name, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+	if server == nil && len(excluded) > 0 {
+		return nil, resp, &NoActiveVersionError{Name: name, Excluded: excluded}
+	}
+	if server == nil {
+		return nil, resp, s.missing(ctx, name, "")
+	}
+
+	return server, resp, nil
+}
+
+// resolve returns the highest version of the server named name that opts
+// allow, and the matching versions skipped because of their status.
+func (s *ServersService) resolve(ctx context.Context, name string, opts *ResolveOptions) (*registryv0.ServerJSON, []VersionStatus, *Response, error) {
+	var constraint *semver.Constraints
+	if opts.Constraint != "" {
+		c, err := semver.NewConstraint(opts.Constraint)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid version constraint %q: %w", opts.Constraint, err)
+		}
+		constraint = c
+	}
+
+	name = s.client.aliases.Resolve(name)
+	params := map[string]string{"serverName": name}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersions, params, nil, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var versions *registryv0.ServerListResponse
+	resp, err := s.client.Do(ctx, req, &versions)
+	if err != nil {
+		return nil, nil, resp, s.notFound(ctx, err, name, "")
+	}
+	if versions == nil {
+		return nil, nil, resp, nil
+	}
+
+	var latest *registryv0.ServerJSON
+	var latestVersion *semver.Version
+	var excluded []VersionStatus
+	for i := range versions.Servers {
+		entry := &versions.Servers[i]
+		if opts.Channel != "" && !opts.Channel.Includes(VersionChannel(entry.Server.Version)) {
+			continue
+		}
+		version, err := parseVersion(entry.Server.Version)
+		if err != nil {
+			continue
+		}
+		if opts.Channel == "" && !opts.IncludePrereleases && version.Prerelease() != "" {
+			continue
+		}
+		if constraint != nil && !constraint.Check(version) {
+			continue
+		}
+		if status := entryStatus(entry); !opts.allows(status) {
+			excluded = append(excluded, VersionStatus{
+				Version:     entry.Server.Version,
+				Status:      status,
+				Deprecation: ParseDeprecation(entry),
+			})
+			continue
+		}
+
+		if latestVersion == nil || versionGreater(version, latestVersion) {
+			latest, latestVersion = &entry.Server, version
+		}
+	}
+
+	return latest, excluded, resp, nil
+}
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServersService_ResolveVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      string
+		opts        *ResolveOptions
+		wantVersion string
+		wantErr     *NoActiveVersionError
+	}{
+		{
+			name:        "active only by default",
+			server:      "com.example/weather",
+			wantVersion: "1.0.0",
+		},
+		{
+			name:        "include deprecated",
+			server:      "com.example/weather",
+			opts:        &ResolveOptions{IncludeDeprecated: true},
+			wantVersion: "1.1.0",
+		},
+		{
+			name:        "include deprecated prereleases",
+			server:      "com.example/weather",
+			opts:        &ResolveOptions{IncludeDeprecated: true, IncludePrereleases: true},
+			wantVersion: "3.0.0-beta.1",
+		},
+		{
+			name:        "channel selects prereleases",
+			server:      "com.example/weather",
+			opts:        &ResolveOptions{Channel: ChannelBeta, IncludeDeprecated: true},
+			wantVersion: "3.0.0-beta.1",
+		},
+		{
+			name:        "include deprecated in stable channel",
+			server:      "com.example/weather",
+			opts:        &ResolveOptions{Channel: ChannelStable, IncludeDeprecated: true},
+			wantVersion: "1.1.0",
+		},
+		{
+			name:        "include deleted",
+			server:      "com.example/weather",
+			opts:        &ResolveOptions{Channel: ChannelStable, IncludeDeprecated: true, IncludeDeleted: true},
+			wantVersion: "2.0.0",
+		},
+		{
+			name:        "constraint",
+			server:      "com.example/weather",
+			opts:        &ResolveOptions{Channel: ChannelStable, IncludeDeprecated: true, IncludeDeleted: true, Constraint: "<2"},
+			wantVersion: "1.1.0",
+		},
+		{
+			name:   "only inactive matches",
+			server: "com.example/retired",
+			wantErr: &NoActiveVersionError{
+				Name: "com.example/retired",
+				Excluded: []VersionStatus{
+					{
+						Version: "1.0.0",
+						Status:  model.StatusDeprecated,
+						Deprecation: &Deprecation{
+							Message:    "Superseded",
+							ReplacedBy: &Replacement{Name: "com.example/successor"},
+						},
+					},
+					{Version: "2.0.0", Status: model.StatusDeleted},
+				},
+			},
+		},
+		{
+			name:   "no matches",
+			server: "com.example/unversioned",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"servers":[
+					{"server":{"name":"com.example/weather","version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+					{"server":{"name":"com.example/weather","version":"1.1.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}},
+					{"server":{"name":"com.example/weather","version":"2.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deleted"}}},
+					{"server":{"name":"com.example/weather","version":"3.0.0-beta.1"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}}
+				],"metadata":{"count":4}}`)
+			})
+			mux.HandleFunc("/v0.1/servers/com.example%2Fretired/versions", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"servers":[
+					{"server":{"name":"com.example/retired","version":"1.0.0","_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"deprecation":{"message":"Superseded","replacedBy":"com.example/successor"}}}},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated"}}},
+					{"server":{"name":"com.example/retired","version":"2.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deleted"}}}
+				],"metadata":{"count":2}}`)
+			})
+			mux.HandleFunc("/v0.1/servers/com.example%2Funversioned/versions", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"servers":[
+					{"server":{"name":"com.example/unversioned","version":"nightly"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"deleted"}}}
+				],"metadata":{"count":1}}`)
+			})
+
+			server, _, err := client.Servers.ResolveVersion(context.Background(), tt.server, tt.opts)
+
+			if tt.wantErr != nil {
+				var noActive *NoActiveVersionError
+				if !errors.As(err, &noActive) {
+					t.Fatalf("ResolveVersion error = %v, want *NoActiveVersionError", err)
+				}
+				if !reflect.DeepEqual(noActive, tt.wantErr) {
+					t.Errorf("ResolveVersion error = %+v, want %+v", noActive, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVersion returned error: %v", err)
+			}
+
+			var got string
+			if server != nil {
+				got = server.Version
+			}
+			if got != tt.wantVersion {
+				t.Errorf("ResolveVersion = %q, want %q", got, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestNoActiveVersionError(t *testing.T) {
+	err := &NoActiveVersionError{
+		Name:     "com.example/retired",
+		Excluded: []VersionStatus{{Version: "1.0.0", Status: model.StatusDeprecated}},
+	}
+	if got, want := err.Error(), "server com.example/retired has no active version: 1.0.0 is deprecated"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	err.Excluded = []VersionStatus{
+		{Version: "1.0.0", Status: model.StatusDeprecated, Deprecation: &Deprecation{ReplacedBy: &Replacement{Name: "com.example/old-successor"}}},
+		{Version: "1.1.0", Status: model.StatusDeprecated, Deprecation: &Deprecation{ReplacedBy: &Replacement{Name: "com.example/successor", Version: "2.0.0"}}},
+		{Version: "1.2.0", Status: model.StatusDeprecated, Deprecation: &Deprecation{}},
+	}
+	if got, want := err.Error(), "server com.example/retired has no active version: 1.0.0 is deprecated, 1.1.0 is deprecated, 1.2.0 is deprecated; replaced by com.example/successor@2.0.0"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestServersService_GetByNameLatestActiveVersion_SkipsPrereleases(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"com.example/weather","version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+			{"server":{"name":"com.example/weather","version":"2.0.0-rc.1"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}}
+		],"metadata":{"count":2}}`)
+	})
+
+	server, _, err := client.Servers.GetByNameLatestActiveVersion(context.Background(), "com.example/weather")
+	if err != nil {
+		t.Fatalf("GetByNameLatestActiveVersion returned error: %v", err)
+	}
+	if server == nil || server.Version != "1.0.0" {
+		t.Errorf("GetByNameLatestActiveVersion = %+v, want version 1.0.0", server)
+	}
+}
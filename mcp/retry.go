@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// maxRetryBackoff caps the exponential backoff between retries. Delays
+// requested by the registry with Retry-After are not capped.
+const maxRetryBackoff = 30 * time.Second
+
+// retryPolicy is the retry behavior configured with WithRetry.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// WithRetry returns an Option that retries idempotent requests (GET and
+// HEAD) that fail with a 5xx status, a 429 rate limit response, or a
+// transient transport error: a timeout, a reset or refused connection, or a
+// connection closed mid-response. Other transport errors, such as failed TLS
+// verification, are returned at once. Each request is sent at most
+// maxAttempts times.
+//
+// Retries wait for the delay in the response's Retry-After header if there
+// is one, and otherwise back off exponentially from backoff, doubling it
+// after each attempt up to 30 seconds, with random jitter so that clients
+// failing together do not retry together. Retries count against the rate
+// budget configured with WithRateBudget, and Client.Do returns the result of
+// the last attempt.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		if maxAttempts < 1 {
+			return fmt.Errorf("retry attempts must be at least 1, got %d", maxAttempts)
+		}
+		if backoff <= 0 {
+			return fmt.Errorf("retry backoff must be positive, got %v", backoff)
+		}
+		c.retry = &retryPolicy{maxAttempts: maxAttempts, backoff: backoff}
+		return nil
+	}
+}
+
+// send performs req, which already carries ctx, retrying it according to
+// the client's retry policy, and decodes the response into v as described by
+// Do.
+func (c *Client) send(ctx context.Context, req *http.Request, v any) (*Response, error) {
+	if c.retry == nil || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return c.sendOnce(ctx, req, v)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(ctx, req, v)
+		if attempt >= c.retry.maxAttempts || !retryable(ctx, resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp, ctx.Err()
+		case <-c.clock.After(c.retry.delay(attempt, resp, c.clock.Now())):
+		}
+
+		retry := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			retry.Body = body
+		}
+		req = retry
+	}
+}
+
+// retryable reports whether a request that ended with resp and err may
+// succeed if sent again.
+func retryable(ctx context.Context, resp *Response, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		return true
+	}
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && errResp.Response.StatusCode >= http.StatusInternalServerError
+	}
+
+	// Errors with a response happened while decoding the body
+	return resp == nil && transientNetworkError(err)
+}
+
+// transientNetworkError reports whether err is a transport error that may
+// not recur, such as a timeout or a reset connection. Errors that will
+// recur, such as invalid URLs or failed TLS verification, are not.
+func transientNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// delay returns how long to wait before sending a request again after the
+// given attempt, which ended with resp.
+func (p *retryPolicy) delay(attempt int, resp *Response, now time.Time) time.Duration {
+	if resp != nil && resp.Response != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return d
+		}
+	}
+
+	d := p.backoff
+	for i := 1; i < attempt && d < maxRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > maxRetryBackoff {
+		d = maxRetryBackoff
+	}
+
+	// Wait between half and all of the backoff
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryAfter parses a Retry-After header value, either a number of seconds
+// or an HTTP date, into a delay from now.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
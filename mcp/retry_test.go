@@ -0,0 +1,224 @@
+package mcp
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		wantRequests int
+		wantStatus   int
+	}{
+		{
+			name:         "retries server errors",
+			method:       "GET",
+			statuses:     []int{503, 500, 200},
+			wantRequests: 3,
+			wantStatus:   200,
+		},
+		{
+			name:         "gives up after max attempts",
+			method:       "GET",
+			statuses:     []int{502, 502, 502, 200},
+			wantRequests: 3,
+			wantStatus:   502,
+		},
+		{
+			name:         "client errors are final",
+			method:       "GET",
+			statuses:     []int{404, 200},
+			wantRequests: 1,
+			wantStatus:   404,
+		},
+		{
+			name:         "writes are not retried",
+			method:       "POST",
+			statuses:     []int{503, 200},
+			wantRequests: 1,
+			wantStatus:   503,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+			if err := WithRetry(3, time.Millisecond)(client); err != nil {
+				t.Fatalf("WithRetry returned error: %v", err)
+			}
+
+			requests := 0
+			mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[requests])
+				requests++
+				fmt.Fprint(w, `{}`)
+			})
+
+			req, err := client.NewRequest(tt.method, "v0.1/servers", nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+			resp, _ := client.Do(context.Background(), req, nil)
+
+			if requests != tt.wantRequests {
+				t.Errorf("server received %d requests, want %d", requests, tt.wantRequests)
+			}
+			if resp == nil || resp.StatusCode != tt.wantStatus {
+				t.Errorf("Do response = %+v, want status %d", resp, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestWithRetry_RetryAfter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	clock := mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, opt := range []Option{WithClock(clock), WithRetry(2, time.Millisecond)} {
+		if err := opt(client); err != nil {
+			t.Fatalf("option returned error: %v", err)
+		}
+	}
+
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "5")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := client.Servers.List(context.Background(), nil)
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(4 * time.Second)
+	select {
+	case <-done:
+		t.Fatal("List returned before Retry-After elapsed")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("server received %d requests, want 2", requests)
+	}
+}
+
+func TestWithRetry_TransportError(t *testing.T) {
+	client, err := NewClient(&http.Client{Transport: &mcptest.FaultTransport{
+		Sequence: []mcptest.Fault{mcptest.FaultDrop, mcptest.FaultDrop},
+	}}, WithRetry(2, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	_, _, err = client.Servers.List(context.Background(), nil)
+	if !errors.Is(err, mcptest.ErrDropped) {
+		t.Errorf("List error = %v, want ErrDropped after exhausting retries", err)
+	}
+	if got := client.client.Transport.(*mcptest.FaultTransport).Requests(); got != 2 {
+		t.Errorf("transport saw %d requests, want 2", got)
+	}
+}
+
+func TestWithRetry_PermanentTransportError(t *testing.T) {
+	requests := 0
+	client, err := NewClient(&http.Client{Transport: RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		requests++
+		return nil, x509.UnknownAuthorityError{}
+	})}, WithRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	_, _, err = client.Servers.List(context.Background(), nil)
+	if !errors.As(err, new(x509.UnknownAuthorityError)) {
+		t.Errorf("List error = %v, want x509.UnknownAuthorityError", err)
+	}
+	if requests != 1 {
+		t.Errorf("transport saw %d requests, want 1", requests)
+	}
+}
+
+func TestRetryable_TransportErrors(t *testing.T) {
+	urlError := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://registry.example.com/v0.1/servers", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"timeout", urlError(&net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}), true},
+		{"connection reset", urlError(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}), true},
+		{"connection refused", urlError(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true},
+		{"unexpected EOF", urlError(io.ErrUnexpectedEOF), true},
+		{"dropped", mcptest.ErrDropped, true},
+		{"unknown authority", urlError(x509.UnknownAuthorityError{}), false},
+		{"hostname mismatch", urlError(x509.HostnameError{Host: "registry.example.com", Certificate: &x509.Certificate{}}), false},
+		{"invalid URL", urlError(errors.New("unsupported protocol scheme")), false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(context.Background(), nil, tt.err); got != tt.want {
+				t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRetry_Invalid(t *testing.T) {
+	for _, opt := range []Option{WithRetry(0, time.Second), WithRetry(3, 0)} {
+		if _, err := NewClient(nil, opt); err == nil {
+			t.Error("NewClient returned nil error")
+		}
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := &retryPolicy{maxAttempts: 10, backoff: time.Second}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for attempt, want := range map[int]time.Duration{1: time.Second, 3: 4 * time.Second, 9: maxRetryBackoff} {
+		if got := p.delay(attempt, nil, now); got < want/2 || got > want {
+			t.Errorf("delay(%d) = %v, want between %v and %v", attempt, got, want/2, want)
+		}
+	}
+
+	resp := &Response{Response: &http.Response{Header: http.Header{
+		"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)},
+	}}}
+	if got := p.delay(1, resp, now); got != time.Minute {
+		t.Errorf("delay with Retry-After date = %v, want 1m", got)
+	}
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// defaultAPIVersion is the API version path prefix of the routes, unless
+// set with WithAPIVersion.
+const defaultAPIVersion = APIVersionV01
+
+// Route names of the registry endpoints used by the SDK. The names follow the
+// operation IDs of the official registry API.
+const (
+	RouteListServers       = "list-servers"
+	RouteGetServerVersion  = "get-server-version"
+	RouteGetServerVersions = "get-server-versions"
+	RoutePublishServer     = "publish-server"
+	RouteEditServer        = "edit-server"
+
+	RouteGetHealth               = "get-health"
+	RouteExchangeGitHubToken     = "exchange-github-token"
+	RouteExchangeGitHubOIDCToken = "exchange-github-oidc-token"
+	RouteExchangeDNSToken        = "exchange-dns-token"
+	RouteExchangeHTTPToken       = "exchange-http-token"
+	RouteGetAnonymousToken       = "get-anonymous-token"
+)
+
+// Route describes a registry API endpoint.
+type Route struct {
+	// Method is the HTTP method of the endpoint.
+	Method string
+
+	// Path is the endpoint path relative to the API version prefix, with
+	// path parameters in braces, e.g. "servers/{serverName}/versions".
+	Path string
+}
+
+// defaultRoutes is the route table every Client starts with.
+var defaultRoutes = map[string]Route{
+	RouteListServers:       {Method: http.MethodGet, Path: "servers"},
+	RouteGetServerVersion:  {Method: http.MethodGet, Path: "servers/{serverName}/versions/{version}"},
+	RouteGetServerVersions: {Method: http.MethodGet, Path: "servers/{serverName}/versions"},
+	RoutePublishServer:     {Method: http.MethodPost, Path: "publish"},
+	RouteEditServer:        {Method: http.MethodPut, Path: "servers/{serverName}/versions/{version}"},
+
+	RouteGetHealth:               {Method: http.MethodGet, Path: "health"},
+	RouteExchangeGitHubToken:     {Method: http.MethodPost, Path: "auth/github-at"},
+	RouteExchangeGitHubOIDCToken: {Method: http.MethodPost, Path: "auth/github-oidc"},
+	RouteExchangeDNSToken:        {Method: http.MethodPost, Path: "auth/dns"},
+	RouteExchangeHTTPToken:       {Method: http.MethodPost, Path: "auth/http"},
+	RouteGetAnonymousToken:       {Method: http.MethodPost, Path: "auth/none"},
+}
+
+// routeParamRE matches a path parameter placeholder such as "{serverName}".
+var routeParamRE = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// WithRoutes returns an Option that adds routes to the client's route table,
+// replacing any existing routes with the same names. This allows targeting
+// experimental endpoints, or registries that serve an endpoint at a
+// different path, without changing the services that use them.
+func WithRoutes(routes map[string]Route) Option {
+	return func(c *Client) error {
+		for name, r := range routes {
+			if name == "" {
+				return fmt.Errorf("route name cannot be empty")
+			}
+			if r.Method == "" || r.Path == "" {
+				return fmt.Errorf("route %q must have a method and path", name)
+			}
+			c.routes[name] = r
+		}
+		return nil
+	}
+}
+
+// Routes returns a copy of the client's route table.
+func (c *Client) Routes() map[string]Route {
+	routes := make(map[string]Route, len(c.routes))
+	for name, r := range c.routes {
+		routes[name] = r
+	}
+	return routes
+}
+
+// RoutePath returns the URL of the named route relative to the BaseURL,
+// including the API version prefix. Each placeholder in the route path is
+// replaced by the path-escaped value of the matching entry in params, and
+// opts is added as URL query parameters.
+func (c *Client) RoutePath(name string, params map[string]string, opts any) (string, error) {
+	r, ok := c.routes[name]
+	if !ok {
+		return "", fmt.Errorf("unknown route: %s", name)
+	}
+
+	var missing []string
+	p := routeParamRE.ReplaceAllStringFunc(r.Path, func(m string) string {
+		key := m[1 : len(m)-1]
+		v, ok := params[key]
+		if !ok {
+			missing = append(missing, key)
+			return m
+		}
+		return url.PathEscape(v)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("route %s: missing path parameters: %s", name, strings.Join(missing, ", "))
+	}
+
+	u := c.apiVersion + "/" + strings.TrimPrefix(p, "/")
+	if opts != nil {
+		return addOptions(u, opts)
+	}
+
+	return u, nil
+}
+
+// NewRouteRequest creates an API request for the named route. The path is
+// built as described by RoutePath, and body is handled as by NewRequest.
+func (c *Client) NewRouteRequest(name string, params map[string]string, opts any, body any) (*http.Request, error) {
+	u, err := c.RoutePath(name, params, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(c.routes[name].Method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return req.WithContext(contextWithRoute(req.Context(), name)), nil
+}
+
+// routeContextKey is the context key for the route name of a request.
+type routeContextKey struct{}
+
+// contextWithRoute returns a copy of ctx carrying the route name.
+func contextWithRoute(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, routeContextKey{}, name)
+}
+
+// RouteFromContext returns the name of the route a request was created
+// for by NewRouteRequest, and whether it was. Client.Do keeps the route in
+// the context of the request it sends, so middleware can label requests by
+// endpoint, for example in metrics, without parsing URLs.
+func RouteFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(routeContextKey{}).(string)
+	return name, ok
+}
@@ -0,0 +1,173 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_RoutePath(t *testing.T) {
+	c, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		route      string
+		params     map[string]string
+		opts       any
+		want       string
+		wantErrMsg string
+	}{
+		{
+			name:  "list servers with options",
+			route: RouteListServers,
+			opts:  &ServerListOptions{Search: "github"},
+			want:  "v0.1/servers?search=github",
+		},
+		{
+			name:   "server name is escaped",
+			route:  RouteGetServerVersions,
+			params: map[string]string{"serverName": "ai.waystation/gmail"},
+			want:   "v0.1/servers/ai.waystation%2Fgmail/versions",
+		},
+		{
+			name:       "missing parameter",
+			route:      RouteGetServerVersion,
+			params:     map[string]string{"serverName": "ai.waystation/gmail"},
+			wantErrMsg: "missing path parameters: version",
+		},
+		{
+			name:       "unknown route",
+			route:      "does-not-exist",
+			wantErrMsg: "unknown route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.RoutePath(tt.route, tt.params, tt.opts)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Errorf("RoutePath() error = %v, want to contain %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RoutePath() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RoutePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		routes  map[string]Route
+		wantErr bool
+	}{
+		{
+			name:   "valid route",
+			routes: map[string]Route{"list-collections": {Method: http.MethodGet, Path: "collections"}},
+		},
+		{
+			name:    "empty name",
+			routes:  map[string]Route{"": {Method: http.MethodGet, Path: "collections"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing path",
+			routes:  map[string]Route{"list-collections": {Method: http.MethodGet}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(nil, WithRoutes(tt.routes))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClient(WithRoutes) error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithRoutes_Override(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	err := WithRoutes(map[string]Route{
+		RouteListServers: {Method: http.MethodGet, Path: "experimental/servers"},
+	})(client)
+	if err != nil {
+		t.Fatalf("WithRoutes returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/experimental/servers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"servers":[],"metadata":{"count":0}}`)
+	})
+
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Errorf("Servers.List returned error: %v", err)
+	}
+}
+
+func TestClient_Routes_ReturnsCopy(t *testing.T) {
+	c, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	routes := c.Routes()
+	delete(routes, RouteListServers)
+
+	if _, ok := c.Routes()[RouteListServers]; !ok {
+		t.Error("Routes() modification affected the client route table")
+	}
+}
+
+func TestRouteFromContext(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var routes []string
+	client.middleware = []Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			route, ok := RouteFromContext(req.Context())
+			if !ok {
+				route = "none"
+			}
+			routes = append(routes, route)
+			return next.RoundTrip(req)
+		})
+	}}
+	if err := client.applyMiddleware(); err != nil {
+		t.Fatalf("applyMiddleware returned error: %v", err)
+	}
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[],"metadata":{}}`)
+	})
+
+	ctx := context.Background()
+	if _, _, err := client.Servers.List(ctx, nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	req, err := client.NewRequest("GET", "v0.1/servers", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if want := "[list-servers none]"; fmt.Sprint(routes) != want {
+		t.Errorf("routes = %v, want %s", routes, want)
+	}
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"sort"
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Runtime identifies the runtime needed to run a server implementation.
+type Runtime string
+
+// Supported runtime values.
+const (
+	RuntimeNode    Runtime = "node"
+	RuntimePython  Runtime = "python"
+	RuntimeDotNet  Runtime = "dotnet"
+	RuntimeDocker  Runtime = "docker"
+	RuntimeBinary  Runtime = "binary"
+	RuntimeRemote  Runtime = "remote"
+	RuntimeUnknown Runtime = "unknown"
+)
+
+// PackageRuntime returns the runtime needed to run pkg. The runtime hint is
+// preferred when present, since it names the launcher the publisher expects
+// (e.g. "uvx"); otherwise the package registry type decides.
+func PackageRuntime(pkg model.Package) Runtime {
+	switch strings.ToLower(pkg.RunTimeHint) {
+	case model.RuntimeHintNPX, "node", "npm", "pnpm", "bunx":
+		return RuntimeNode
+	case model.RuntimeHintUVX, "python", "pip", "pipx":
+		return RuntimePython
+	case model.RuntimeHintDocker, "podman":
+		return RuntimeDocker
+	case model.RuntimeHintDNX, "dotnet":
+		return RuntimeDotNet
+	}
+
+	switch strings.ToLower(pkg.RegistryType) {
+	case model.RegistryTypeNPM:
+		return RuntimeNode
+	case model.RegistryTypePyPI:
+		return RuntimePython
+	case model.RegistryTypeNuGet:
+		return RuntimeDotNet
+	case model.RegistryTypeOCI:
+		return RuntimeDocker
+	case model.RegistryTypeMCPB:
+		return RuntimeBinary
+	}
+
+	return RuntimeUnknown
+}
+
+// Runtimes returns the sorted, de-duplicated runtimes with which server can
+// be run, one per distinct package runtime. Servers without packages that
+// declare remotes report RuntimeRemote.
+func Runtimes(server *registryv0.ServerJSON) []Runtime {
+	if server == nil {
+		return nil
+	}
+
+	seen := make(map[Runtime]bool)
+	var runtimes []Runtime
+	for _, pkg := range server.Packages {
+		rt := PackageRuntime(pkg)
+		if !seen[rt] {
+			seen[rt] = true
+			runtimes = append(runtimes, rt)
+		}
+	}
+	if len(runtimes) == 0 && len(server.Remotes) > 0 {
+		runtimes = append(runtimes, RuntimeRemote)
+	}
+
+	sort.Slice(runtimes, func(i, j int) bool { return runtimes[i] < runtimes[j] })
+	return runtimes
+}
+
+// HasRuntime reports whether server can be run with rt.
+func HasRuntime(server *registryv0.ServerJSON, rt Runtime) bool {
+	for _, got := range Runtimes(server) {
+		if got == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByRuntime returns the servers that can be run with rt, preserving
+// their order. For example, FilterByRuntime(servers, RuntimePython) returns
+// the servers that ship a Python package runnable with uvx.
+func FilterByRuntime(servers []registryv0.ServerJSON, rt Runtime) []registryv0.ServerJSON {
+	var matches []registryv0.ServerJSON
+	for i := range servers {
+		if HasRuntime(&servers[i], rt) {
+			matches = append(matches, servers[i])
+		}
+	}
+	return matches
+}
+
+// FilterByOnlyRuntime returns the servers whose every package requires rt,
+// such as Docker-only servers, preserving their order.
+func FilterByOnlyRuntime(servers []registryv0.ServerJSON, rt Runtime) []registryv0.ServerJSON {
+	var matches []registryv0.ServerJSON
+	for i := range servers {
+		if runtimes := Runtimes(&servers[i]); len(runtimes) == 1 && runtimes[0] == rt {
+			matches = append(matches, servers[i])
+		}
+	}
+	return matches
+}
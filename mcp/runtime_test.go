@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"reflect"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPackageRuntime(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  model.Package
+		want Runtime
+	}{
+		{name: "npm", pkg: model.Package{RegistryType: "npm"}, want: RuntimeNode},
+		{name: "pypi", pkg: model.Package{RegistryType: "pypi"}, want: RuntimePython},
+		{name: "nuget", pkg: model.Package{RegistryType: "nuget"}, want: RuntimeDotNet},
+		{name: "oci", pkg: model.Package{RegistryType: "oci"}, want: RuntimeDocker},
+		{name: "mcpb", pkg: model.Package{RegistryType: "mcpb"}, want: RuntimeBinary},
+		{name: "hint wins", pkg: model.Package{RegistryType: "oci", RunTimeHint: "uvx"}, want: RuntimePython},
+		{name: "unknown", pkg: model.Package{RegistryType: "cargo"}, want: RuntimeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PackageRuntime(tt.pkg); got != tt.want {
+				t.Errorf("PackageRuntime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimes(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *registryv0.ServerJSON
+		want   []Runtime
+	}{
+		{
+			name:   "nil server",
+			server: nil,
+			want:   nil,
+		},
+		{
+			name: "multiple packages",
+			server: &registryv0.ServerJSON{
+				Packages: []model.Package{
+					{RegistryType: "pypi"},
+					{RegistryType: "oci"},
+					{RegistryType: "pypi"},
+				},
+			},
+			want: []Runtime{RuntimeDocker, RuntimePython},
+		},
+		{
+			name: "remote only",
+			server: &registryv0.ServerJSON{
+				Remotes: []model.Transport{{Type: "streamable-http", URL: "https://mcp.example.com"}},
+			},
+			want: []Runtime{RuntimeRemote},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Runtimes(tt.server); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Runtimes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterByRuntime(t *testing.T) {
+	servers := []registryv0.ServerJSON{
+		{Name: "python-and-docker", Packages: []model.Package{{RegistryType: "pypi"}, {RegistryType: "oci"}}},
+		{Name: "docker-only", Packages: []model.Package{{RegistryType: "oci"}}},
+		{Name: "node", Packages: []model.Package{{RegistryType: "npm"}}},
+	}
+
+	if got := FilterByRuntime(servers, RuntimeDocker); len(got) != 2 || got[0].Name != "python-and-docker" || got[1].Name != "docker-only" {
+		t.Errorf("FilterByRuntime(docker) = %+v, want python-and-docker and docker-only", got)
+	}
+
+	if got := FilterByOnlyRuntime(servers, RuntimeDocker); len(got) != 1 || got[0].Name != "docker-only" {
+		t.Errorf("FilterByOnlyRuntime(docker) = %+v, want docker-only", got)
+	}
+
+	if got := FilterByRuntime(servers, RuntimeDotNet); got != nil {
+		t.Errorf("FilterByRuntime(dotnet) = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,343 @@
+package mcp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// SBOMFormat is a software bill of materials format supported by WriteSBOM.
+type SBOMFormat string
+
+// Supported SBOM formats.
+const (
+	SBOMCycloneDX SBOMFormat = "cyclonedx" // CycloneDX 1.5 JSON
+	SBOMSPDX      SBOMFormat = "spdx"      // SPDX 2.3 JSON
+)
+
+// SBOMOptions specifies the optional parameters to WriteSBOM.
+type SBOMOptions struct {
+	// Format of the document. Defaults to SBOMCycloneDX.
+	Format SBOMFormat
+
+	// Created is the creation time recorded in the document. Defaults to
+	// the current time; set it to make output reproducible.
+	Created time.Time
+}
+
+// WriteSBOM writes a software bill of materials describing servers and the
+// packages they declare to w, so MCP server adoption can be fed into
+// existing supply-chain tooling. Each server becomes an application that
+// depends on its packages, which are identified by package URLs (purls)
+// derived from their registry type and carry their SHA-256 digests, if
+// published.
+//
+// Remotes are not included, since they are not software that is installed.
+func WriteSBOM(w io.Writer, servers []registryv0.ServerJSON, opts *SBOMOptions) error {
+	if opts == nil {
+		opts = &SBOMOptions{}
+	}
+	created := opts.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	var doc any
+	switch opts.Format {
+	case "", SBOMCycloneDX:
+		doc = cycloneDXDocument(servers, created)
+	case SBOMSPDX:
+		doc = spdxDocument(servers, created)
+	default:
+		return fmt.Errorf("unsupported SBOM format %q", opts.Format)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// CycloneDX document structure, limited to the fields WriteSBOM emits.
+type (
+	cdxDocument struct {
+		BOMFormat    string          `json:"bomFormat"`
+		SpecVersion  string          `json:"specVersion"`
+		Version      int             `json:"version"`
+		Metadata     cdxMetadata     `json:"metadata"`
+		Components   []cdxComponent  `json:"components"`
+		Dependencies []cdxDependency `json:"dependencies"`
+	}
+	cdxMetadata struct {
+		Timestamp string   `json:"timestamp"`
+		Tools     cdxTools `json:"tools"`
+	}
+	cdxTools struct {
+		Components []cdxComponent `json:"components"`
+	}
+	cdxComponent struct {
+		Type               string           `json:"type"`
+		BOMRef             string           `json:"bom-ref,omitempty"`
+		Name               string           `json:"name"`
+		Version            string           `json:"version,omitempty"`
+		Description        string           `json:"description,omitempty"`
+		PURL               string           `json:"purl,omitempty"`
+		Hashes             []cdxHash        `json:"hashes,omitempty"`
+		ExternalReferences []cdxExternalRef `json:"externalReferences,omitempty"`
+	}
+	cdxHash struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	}
+	cdxExternalRef struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+	cdxDependency struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn"`
+	}
+)
+
+// cycloneDXDocument returns the CycloneDX document describing servers.
+func cycloneDXDocument(servers []registryv0.ServerJSON, created time.Time) *cdxDocument {
+	toolName, toolVersion, _ := strings.Cut(userAgent, "/")
+	doc := &cdxDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{
+				{Type: "application", Name: toolName, Version: toolVersion},
+			}},
+		},
+		Components:   []cdxComponent{},
+		Dependencies: []cdxDependency{},
+	}
+
+	seen := make(map[string]bool)
+	for i := range servers {
+		server := &servers[i]
+		component := cdxComponent{
+			Type:        "application",
+			BOMRef:      server.Name + "@" + server.Version,
+			Name:        server.Name,
+			Version:     server.Version,
+			Description: server.Description,
+		}
+		if server.Repository.URL != "" {
+			component.ExternalReferences = []cdxExternalRef{{Type: "vcs", URL: server.Repository.URL}}
+		}
+		doc.Components = append(doc.Components, component)
+
+		dependency := cdxDependency{Ref: component.BOMRef, DependsOn: []string{}}
+		for _, pkg := range server.Packages {
+			purl := packageURL(pkg)
+			dependency.DependsOn = append(dependency.DependsOn, purl)
+			if seen[purl] {
+				continue
+			}
+			seen[purl] = true
+
+			pkgType := "library"
+			if strings.EqualFold(pkg.RegistryType, model.RegistryTypeOCI) {
+				pkgType = "container"
+			}
+			pkgComponent := cdxComponent{
+				Type:    pkgType,
+				BOMRef:  purl,
+				Name:    pkg.Identifier,
+				Version: pkg.Version,
+				PURL:    purl,
+			}
+			if pkg.FileSHA256 != "" {
+				pkgComponent.Hashes = []cdxHash{{Alg: "SHA-256", Content: pkg.FileSHA256}}
+			}
+			doc.Components = append(doc.Components, pkgComponent)
+		}
+		doc.Dependencies = append(doc.Dependencies, dependency)
+	}
+
+	return doc
+}
+
+// SPDX document structure, limited to the fields WriteSBOM emits.
+type (
+	spdxDoc struct {
+		SPDXVersion       string             `json:"spdxVersion"`
+		DataLicense       string             `json:"dataLicense"`
+		SPDXID            string             `json:"SPDXID"`
+		Name              string             `json:"name"`
+		DocumentNamespace string             `json:"documentNamespace"`
+		CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+		Packages          []spdxPackage      `json:"packages"`
+		Relationships     []spdxRelationship `json:"relationships"`
+	}
+	spdxCreationInfo struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	}
+	spdxPackage struct {
+		SPDXID           string         `json:"SPDXID"`
+		Name             string         `json:"name"`
+		VersionInfo      string         `json:"versionInfo,omitempty"`
+		DownloadLocation string         `json:"downloadLocation"`
+		FilesAnalyzed    bool           `json:"filesAnalyzed"`
+		LicenseConcluded string         `json:"licenseConcluded"`
+		LicenseDeclared  string         `json:"licenseDeclared"`
+		Description      string         `json:"description,omitempty"`
+		Checksums        []spdxChecksum `json:"checksums,omitempty"`
+		ExternalRefs     []spdxRef      `json:"externalRefs,omitempty"`
+	}
+	spdxChecksum struct {
+		Algorithm     string `json:"algorithm"`
+		ChecksumValue string `json:"checksumValue"`
+	}
+	spdxRef struct {
+		ReferenceCategory string `json:"referenceCategory"`
+		ReferenceType     string `json:"referenceType"`
+		ReferenceLocator  string `json:"referenceLocator"`
+	}
+	spdxRelationship struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+	}
+)
+
+// spdxNoAssertion is the SPDX value for information that was not determined.
+const spdxNoAssertion = "NOASSERTION"
+
+// spdxDocument returns the SPDX document describing servers.
+func spdxDocument(servers []registryv0.ServerJSON, created time.Time) *spdxDoc {
+	toolName, toolVersion, _ := strings.Cut(userAgent, "/")
+	doc := &spdxDoc{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        "mcp-servers",
+		CreationInfo: spdxCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName + "-" + toolVersion},
+		},
+		Packages:      []spdxPackage{},
+		Relationships: []spdxRelationship{},
+	}
+	if len(servers) == 1 {
+		doc.Name = servers[0].Name + "@" + servers[0].Version
+	}
+
+	// The namespace must be unique per document; derive it from the
+	// described servers and creation time so output is reproducible
+	h := sha256.New()
+	fmt.Fprint(h, doc.CreationInfo.Created)
+	for _, server := range servers {
+		fmt.Fprint(h, "\x00", server.Name, "@", server.Version)
+	}
+	doc.DocumentNamespace = "https://spdx.org/spdxdocs/mcp-servers-" + hex.EncodeToString(h.Sum(nil))[:32]
+
+	ids := make(map[string]string)
+	for i := range servers {
+		server := &servers[i]
+		serverID := fmt.Sprintf("SPDXRef-Server-%d", i+1)
+		download := spdxNoAssertion
+		if server.Repository.URL != "" {
+			download = server.Repository.URL
+		}
+		doc.Packages = append(doc.Packages, spdxPackage{
+			SPDXID:           serverID,
+			Name:             server.Name,
+			VersionInfo:      server.Version,
+			DownloadLocation: download,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			Description:      server.Description,
+		})
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      doc.SPDXID,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: serverID,
+		})
+
+		for _, pkg := range server.Packages {
+			purl := packageURL(pkg)
+			pkgID, ok := ids[purl]
+			if !ok {
+				pkgID = fmt.Sprintf("SPDXRef-Package-%d", len(ids)+1)
+				ids[purl] = pkgID
+
+				p := spdxPackage{
+					SPDXID:           pkgID,
+					Name:             pkg.Identifier,
+					VersionInfo:      pkg.Version,
+					DownloadLocation: spdxNoAssertion,
+					LicenseConcluded: spdxNoAssertion,
+					LicenseDeclared:  spdxNoAssertion,
+					ExternalRefs: []spdxRef{{
+						ReferenceCategory: "PACKAGE-MANAGER",
+						ReferenceType:     "purl",
+						ReferenceLocator:  purl,
+					}},
+				}
+				if strings.EqualFold(pkg.RegistryType, model.RegistryTypeMCPB) {
+					p.DownloadLocation = pkg.Identifier
+				}
+				if pkg.FileSHA256 != "" {
+					p.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: pkg.FileSHA256}}
+				}
+				doc.Packages = append(doc.Packages, p)
+			}
+
+			doc.Relationships = append(doc.Relationships, spdxRelationship{
+				SPDXElementID:      serverID,
+				RelationshipType:   "DEPENDS_ON",
+				RelatedSPDXElement: pkgID,
+			})
+		}
+	}
+
+	return doc
+}
+
+// packageURL returns the package URL (purl) identifying pkg.
+func packageURL(pkg model.Package) string {
+	version := url.PathEscape(pkg.Version)
+	switch strings.ToLower(pkg.RegistryType) {
+	case model.RegistryTypeNPM:
+		name := strings.Replace(pkg.Identifier, "@", "%40", 1)
+		return "pkg:npm/" + name + "@" + version
+	case model.RegistryTypePyPI:
+		name := strings.ReplaceAll(strings.ToLower(pkg.Identifier), "_", "-")
+		return "pkg:pypi/" + name + "@" + version
+	case model.RegistryTypeNuGet:
+		return "pkg:nuget/" + pkg.Identifier + "@" + version
+	case model.RegistryTypeOCI:
+		// OCI purls are versioned by digest, which the registry does not
+		// record, so the version is given as the tag
+		repo, _, _ := strings.Cut(pkg.Identifier, "@")
+		if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+			repo = repo[:i]
+		}
+		qualifiers := url.Values{"repository_url": {repo}, "tag": {pkg.Version}}
+		return "pkg:oci/" + strings.ToLower(path.Base(repo)) + "?" + qualifiers.Encode()
+	case model.RegistryTypeMCPB:
+		name := strings.TrimSuffix(path.Base(pkg.Identifier), ".mcpb")
+		qualifiers := url.Values{"download_url": {pkg.Identifier}}
+		if pkg.FileSHA256 != "" {
+			qualifiers.Set("checksum", "sha256:"+pkg.FileSHA256)
+		}
+		return "pkg:generic/" + url.PathEscape(name) + "@" + version + "?" + qualifiers.Encode()
+	}
+
+	return "pkg:generic/" + url.PathEscape(pkg.Identifier) + "@" + version
+}
@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPackageURL(t *testing.T) {
+	tests := []struct {
+		pkg  model.Package
+		want string
+	}{
+		{model.Package{RegistryType: "npm", Identifier: "@example/weather-mcp", Version: "1.2.0"}, "pkg:npm/%40example/weather-mcp@1.2.0"},
+		{model.Package{RegistryType: "pypi", Identifier: "Weather_MCP", Version: "0.3.1"}, "pkg:pypi/weather-mcp@0.3.1"},
+		{model.Package{RegistryType: "nuget", Identifier: "Example.Weather", Version: "2.0.0"}, "pkg:nuget/Example.Weather@2.0.0"},
+		{model.Package{RegistryType: "oci", Identifier: "ghcr.io/example/weather:1.2.0", Version: "1.2.0"}, "pkg:oci/weather?repository_url=ghcr.io%2Fexample%2Fweather&tag=1.2.0"},
+		{model.Package{RegistryType: "mcpb", Identifier: "https://example.com/weather.mcpb", Version: "1.0.0", FileSHA256: "abc"}, "pkg:generic/weather@1.0.0?checksum=sha256%3Aabc&download_url=https%3A%2F%2Fexample.com%2Fweather.mcpb"},
+		{model.Package{RegistryType: "cargo", Identifier: "weather", Version: "1.0.0"}, "pkg:generic/weather@1.0.0"},
+	}
+	for _, tt := range tests {
+		if got := packageURL(tt.pkg); got != tt.want {
+			t.Errorf("packageURL(%s %s) = %q, want %q", tt.pkg.RegistryType, tt.pkg.Identifier, got, tt.want)
+		}
+	}
+}
+
+var sbomServers = []registryv0.ServerJSON{
+	{
+		Name:       "com.example/weather",
+		Version:    "1.2.0",
+		Repository: model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+		Packages: []model.Package{
+			{RegistryType: "npm", Identifier: "@example/weather-mcp", Version: "1.2.0"},
+			{RegistryType: "mcpb", Identifier: "https://example.com/weather.mcpb", Version: "1.2.0", FileSHA256: "abc"},
+		},
+	},
+	{
+		Name:    "com.example/forecast",
+		Version: "0.1.0",
+		Packages: []model.Package{
+			{RegistryType: "npm", Identifier: "@example/weather-mcp", Version: "1.2.0"},
+		},
+	},
+}
+
+func TestWriteSBOM_CycloneDX(t *testing.T) {
+	var buf bytes.Buffer
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := WriteSBOM(&buf, sbomServers, &SBOMOptions{Created: created}); err != nil {
+		t.Fatalf("WriteSBOM returned error: %v", err)
+	}
+
+	var doc cdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding SBOM: %v", err)
+	}
+	if doc.BOMFormat != "CycloneDX" || doc.Metadata.Timestamp != "2025-01-01T00:00:00Z" {
+		t.Errorf("header = %s %s, want CycloneDX 2025-01-01T00:00:00Z", doc.BOMFormat, doc.Metadata.Timestamp)
+	}
+
+	var refs []string
+	for _, c := range doc.Components {
+		refs = append(refs, c.BOMRef)
+	}
+	wantRefs := []string{
+		"com.example/weather@1.2.0",
+		"pkg:npm/%40example/weather-mcp@1.2.0",
+		"pkg:generic/weather@1.2.0?checksum=sha256%3Aabc&download_url=https%3A%2F%2Fexample.com%2Fweather.mcpb",
+		"com.example/forecast@0.1.0",
+	}
+	if !reflect.DeepEqual(refs, wantRefs) {
+		t.Errorf("components = %v, want %v", refs, wantRefs)
+	}
+	if got := doc.Components[2].Hashes; len(got) != 1 || got[0].Content != "abc" {
+		t.Errorf("mcpb hashes = %+v, want SHA-256 abc", got)
+	}
+
+	wantDeps := []cdxDependency{
+		{Ref: "com.example/weather@1.2.0", DependsOn: wantRefs[1:3]},
+		{Ref: "com.example/forecast@0.1.0", DependsOn: wantRefs[1:2]},
+	}
+	if !reflect.DeepEqual(doc.Dependencies, wantDeps) {
+		t.Errorf("dependencies = %+v, want %+v", doc.Dependencies, wantDeps)
+	}
+}
+
+func TestWriteSBOM_SPDX(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &SBOMOptions{Format: SBOMSPDX, Created: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if err := WriteSBOM(&buf, sbomServers, opts); err != nil {
+		t.Fatalf("WriteSBOM returned error: %v", err)
+	}
+
+	var doc spdxDoc
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("decoding SBOM: %v", err)
+	}
+	if doc.SPDXVersion != "SPDX-2.3" || doc.DocumentNamespace == "" {
+		t.Errorf("header = %s %q, want SPDX-2.3 with namespace", doc.SPDXVersion, doc.DocumentNamespace)
+	}
+	if len(doc.Packages) != 4 {
+		t.Fatalf("got %d packages, want 4", len(doc.Packages))
+	}
+	if got := doc.Packages[2].DownloadLocation; got != "https://example.com/weather.mcpb" {
+		t.Errorf("mcpb downloadLocation = %q", got)
+	}
+
+	wantRels := []spdxRelationship{
+		{"SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Server-1"},
+		{"SPDXRef-Server-1", "DEPENDS_ON", "SPDXRef-Package-1"},
+		{"SPDXRef-Server-1", "DEPENDS_ON", "SPDXRef-Package-2"},
+		{"SPDXRef-DOCUMENT", "DESCRIBES", "SPDXRef-Server-2"},
+		{"SPDXRef-Server-2", "DEPENDS_ON", "SPDXRef-Package-1"},
+	}
+	if !reflect.DeepEqual(doc.Relationships, wantRels) {
+		t.Errorf("relationships = %+v, want %+v", doc.Relationships, wantRels)
+	}
+
+	// Output is reproducible for a fixed creation time
+	var again bytes.Buffer
+	if err := WriteSBOM(&again, sbomServers, opts); err != nil {
+		t.Fatalf("WriteSBOM returned error: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+		t.Error("WriteSBOM output differs between runs")
+	}
+}
+
+func TestWriteSBOM_UnsupportedFormat(t *testing.T) {
+	if err := WriteSBOM(&bytes.Buffer{}, nil, &SBOMOptions{Format: "swid"}); err == nil {
+		t.Error("WriteSBOM returned nil error for unsupported format")
+	}
+}
@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ServerSchemaURL is the server.json schema that Scaffold declares.
+const ServerSchemaURL = "https://static.modelcontextprotocol.io/schemas/2025-09-29/server.schema.json"
+
+// ScaffoldOptions describes the starter server.json generated by Scaffold,
+// typically filled in from command-line flags or interactive prompts.
+type ScaffoldOptions struct {
+	// Name is the server name in reverse-DNS form, such as
+	// "io.github.alice/weather".
+	Name string
+
+	// Description is a short description of at most 100 characters.
+	Description string
+
+	// Version of the server. Defaults to "1.0.0".
+	Version string
+
+	// RepositoryURL is the URL of the source repository, if any. Its
+	// source is derived from the host, such as "github".
+	RepositoryURL string
+
+	// PackageType is the registry of the package: "npm", "pypi" or "oci".
+	// If empty, the server has no package stanza.
+	PackageType string
+
+	// PackageIdentifier is the package name or image reference.
+	PackageIdentifier string
+
+	// PackageVersion is the version of the package. Defaults to Version.
+	PackageVersion string
+
+	// EnvironmentVariables declares the environment variables the package
+	// reads.
+	EnvironmentVariables []ScaffoldEnvVar
+}
+
+// ScaffoldEnvVar declares an environment variable of a scaffolded package.
+type ScaffoldEnvVar struct {
+	Name        string
+	Description string
+	Required    bool
+	Secret      bool
+}
+
+// scaffoldPackages maps the package types supported by Scaffold to their
+// registry base URL and runtime hint.
+var scaffoldPackages = map[string]struct{ baseURL, runtimeHint string }{
+	model.RegistryTypeNPM:  {model.RegistryURLNPM, model.RuntimeHintNPX},
+	model.RegistryTypePyPI: {model.RegistryURLPyPI, model.RuntimeHintUVX},
+	model.RegistryTypeOCI:  {model.RegistryURLDocker, model.RuntimeHintDocker},
+}
+
+// Patterns of the server.json schema.
+var (
+	serverNamePattern = regexp.MustCompile(`^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$`)
+	envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Scaffold returns a starter server.json for publishers, built from opts and
+// checked against the constraints of the server.json schema: the name
+// format, the description length, a concrete version, and a supported
+// package type with an identifier. All problems found are returned
+// together, so prompts can ask again for every invalid answer at once.
+//
+// The stdio transport is declared for the package, which suits most local
+// servers; edit the result for other transports or to add remotes.
+func Scaffold(opts *ScaffoldOptions) (*registryv0.ServerJSON, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("scaffold options cannot be nil")
+	}
+
+	version := opts.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	server := &registryv0.ServerJSON{
+		Schema:      ServerSchemaURL,
+		Name:        opts.Name,
+		Description: opts.Description,
+		Version:     version,
+	}
+
+	var errs []error
+	switch {
+	case !serverNamePattern.MatchString(opts.Name):
+		errs = append(errs, fmt.Errorf("name %q must have the form namespace/name, such as io.github.user/server", opts.Name))
+	case len(opts.Name) > 200:
+		errs = append(errs, fmt.Errorf("name must be at most 200 characters"))
+	}
+	if opts.Description == "" || len([]rune(opts.Description)) > 100 {
+		errs = append(errs, fmt.Errorf("description must have 1 to 100 characters"))
+	}
+	if err := checkConcreteVersion(version); err != nil {
+		errs = append(errs, err)
+	}
+
+	if opts.RepositoryURL != "" {
+		info, err := ParseRepository(opts.RepositoryURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("repository: %w", err))
+		} else {
+			server.Repository = model.Repository{URL: info.URL(), Source: string(info.Forge), Subfolder: info.Subfolder}
+		}
+	}
+
+	if opts.PackageType != "" {
+		pkg, pkgErrs := scaffoldPackage(opts, version)
+		errs = append(errs, pkgErrs...)
+		server.Packages = []model.Package{pkg}
+	} else if len(opts.EnvironmentVariables) > 0 {
+		errs = append(errs, fmt.Errorf("environment variables require a package type"))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return server, nil
+}
+
+// scaffoldPackage returns the package stanza described by opts and the
+// problems found with it.
+func scaffoldPackage(opts *ScaffoldOptions, version string) (model.Package, []error) {
+	var errs []error
+	info, ok := scaffoldPackages[opts.PackageType]
+	if !ok {
+		errs = append(errs, fmt.Errorf("package type %q must be one of npm, pypi or oci", opts.PackageType))
+	}
+	if opts.PackageIdentifier == "" {
+		errs = append(errs, fmt.Errorf("package identifier cannot be empty"))
+	}
+
+	pkg := model.Package{
+		RegistryType:    opts.PackageType,
+		RegistryBaseURL: info.baseURL,
+		Identifier:      opts.PackageIdentifier,
+		Version:         opts.PackageVersion,
+		RunTimeHint:     info.runtimeHint,
+		Transport:       model.Transport{Type: model.TransportTypeStdio},
+	}
+	if pkg.Version == "" {
+		pkg.Version = version
+	}
+	if err := checkConcreteVersion(pkg.Version); err != nil {
+		errs = append(errs, fmt.Errorf("package %w", err))
+	}
+
+	for _, env := range opts.EnvironmentVariables {
+		if !envVarNamePattern.MatchString(env.Name) {
+			errs = append(errs, fmt.Errorf("environment variable name %q is invalid", env.Name))
+			continue
+		}
+		var input model.KeyValueInput
+		input.Name = env.Name
+		input.Description = env.Description
+		input.IsRequired = env.Required
+		input.IsSecret = env.Secret
+		pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, input)
+	}
+
+	return pkg, errs
+}
+
+// checkConcreteVersion returns an error if version is empty, "latest" or a
+// version range, which the registry rejects.
+func checkConcreteVersion(version string) error {
+	if version == "" || version == "latest" || strings.ContainsAny(version, "^~*<>= |") {
+		return fmt.Errorf("version %q must be a specific version, such as 1.0.0", version)
+	}
+	return nil
+}
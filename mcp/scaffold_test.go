@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestScaffold(t *testing.T) {
+	server, err := Scaffold(&ScaffoldOptions{
+		Name:              "io.github.alice/weather",
+		Description:       "Weather forecasts",
+		RepositoryURL:     "git@github.com:alice/weather.git",
+		PackageType:       "npm",
+		PackageIdentifier: "@alice/weather-mcp",
+		EnvironmentVariables: []ScaffoldEnvVar{
+			{Name: "WEATHER_API_KEY", Description: "API key", Required: true, Secret: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Scaffold returned error: %v", err)
+	}
+
+	var env model.KeyValueInput
+	env.Name = "WEATHER_API_KEY"
+	env.Description = "API key"
+	env.IsRequired = true
+	env.IsSecret = true
+	want := &registryv0.ServerJSON{
+		Schema:      ServerSchemaURL,
+		Name:        "io.github.alice/weather",
+		Description: "Weather forecasts",
+		Version:     "1.0.0",
+		Repository:  model.Repository{URL: "https://github.com/alice/weather", Source: "github"},
+		Packages: []model.Package{{
+			RegistryType:         "npm",
+			RegistryBaseURL:      "https://registry.npmjs.org",
+			Identifier:           "@alice/weather-mcp",
+			Version:              "1.0.0",
+			RunTimeHint:          "npx",
+			Transport:            model.Transport{Type: "stdio"},
+			EnvironmentVariables: []model.KeyValueInput{env},
+		}},
+	}
+	if !reflect.DeepEqual(server, want) {
+		t.Errorf("Scaffold returned %+v, want %+v", server, want)
+	}
+}
+
+func TestScaffold_Invalid(t *testing.T) {
+	_, err := Scaffold(&ScaffoldOptions{
+		Name:                 "weather",
+		Description:          strings.Repeat("x", 101),
+		Version:              "^1.0.0",
+		PackageType:          "cargo",
+		EnvironmentVariables: []ScaffoldEnvVar{{Name: "1BAD"}},
+	})
+	if err == nil {
+		t.Fatal("Scaffold returned no error")
+	}
+
+	for _, want := range []string{
+		`name "weather" must have the form namespace/name`,
+		"description must have 1 to 100 characters",
+		`version "^1.0.0" must be a specific version`,
+		`package type "cargo" must be one of npm, pypi or oci`,
+		"package identifier cannot be empty",
+		`environment variable name "1BAD" is invalid`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Scaffold error does not mention %q:\n%v", want, err)
+		}
+	}
+}
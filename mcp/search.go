@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// WithSearchFallback returns an Option that makes ServersService.List search
+// client-side when the registry rejects the search parameter, as some
+// self-hosted deployments do. The fallback lists servers without the search
+// parameter and keeps those whose names contain the search term, ignoring
+// case, and sets Response.SearchFallback.
+//
+// Each fallback page holds the matches of one upstream page, so it may hold
+// fewer servers than requested, or none, while NextCursor is still set. Once
+// the registry has rejected a search, the client searches client-side for
+// the rest of its lifetime without retrying the parameter. Only rejections
+// naming the search parameter, in the error message or a field error,
+// trigger the fallback; other rejected parameters, such as limit, are
+// returned as errors.
+func WithSearchFallback() Option {
+	return func(c *Client) error {
+		c.searchFallback = true
+		return nil
+	}
+}
+
+// listWithSearchFallback lists servers matching opts.Search, filtering
+// client-side if the registry rejects the search parameter.
+func (s *ServersService) listWithSearchFallback(ctx context.Context, opts *ServerListOptions) (*registryv0.ServerListResponse, *Response, error) {
+	if !s.client.searchUnsupported.Load() {
+		servers, resp, err := s.list(ctx, opts)
+		if !searchRejected(err) {
+			return servers, resp, err
+		}
+		s.client.searchUnsupported.Store(true)
+	}
+
+	unfiltered := *opts
+	unfiltered.Search = ""
+	servers, resp, err := s.list(ctx, &unfiltered)
+	if err != nil {
+		return nil, resp, err
+	}
+	resp.SearchFallback = true
+
+	if servers != nil {
+		term := strings.ToLower(opts.Search)
+		matches := make([]registryv0.ServerResponse, 0, len(servers.Servers))
+		for _, server := range servers.Servers {
+			if strings.Contains(strings.ToLower(server.Server.Name), term) {
+				matches = append(matches, server)
+			}
+		}
+		servers.Servers = matches
+		servers.Metadata.Count = len(matches)
+	}
+
+	return servers, resp, nil
+}
+
+// searchRejected reports whether err is the registry refusing a list request
+// because of its search parameter. Only errors naming the parameter, in
+// their message or field errors, count, so that a rejected limit or cursor
+// does not disable searching for the rest of the client's lifetime.
+func searchRejected(err error) bool {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+
+	switch errResp.Response.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusNotImplemented:
+	default:
+		return false
+	}
+
+	if strings.Contains(strings.ToLower(errResp.Message), "search") {
+		return true
+	}
+	for _, e := range errResp.Errors {
+		if strings.EqualFold(e.Field, "search") || strings.Contains(strings.ToLower(e.Message), "search") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// handleSearchRejected serves a registry that rejects the search parameter
+// and lists servers in two pages. It returns the queries it received.
+func handleSearchRejected(mux *http.ServeMux) *[]string {
+	var queries []string
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("search") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"search is disabled"}`)
+			return
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"servers":[{"server":{"name":"io.github.example/GitHub-tools"}},{"server":{"name":"com.example/slack"}}],"metadata":{"nextCursor":"page2","count":2}}`)
+			return
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/github-mirror"}}],"metadata":{"count":1}}`)
+	})
+	return &queries
+}
+
+func TestServersService_List_SearchFallback(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithSearchFallback()(client); err != nil {
+		t.Fatalf("WithSearchFallback returned error: %v", err)
+	}
+	queries := handleSearchRejected(mux)
+
+	servers, resp, err := client.Servers.List(context.Background(), &ServerListOptions{Search: "github"})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if !resp.SearchFallback {
+		t.Error("Response.SearchFallback = false, want true")
+	}
+	if len(servers.Servers) != 1 || servers.Servers[0].Server.Name != "io.github.example/GitHub-tools" {
+		t.Errorf("List returned %+v, want only the case-insensitive match", servers.Servers)
+	}
+	if servers.Metadata.Count != 1 || resp.NextCursor != "page2" {
+		t.Errorf("Metadata = %+v, NextCursor = %q; want count 1 and upstream cursor", servers.Metadata, resp.NextCursor)
+	}
+
+	// Later searches go straight to the fallback
+	all, _, err := client.Servers.ListAll(context.Background(), &ServerListOptions{Search: "github"})
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("ListAll returned %d servers, want 2", len(all))
+	}
+
+	want := []string{"search=github", "", "", "cursor=page2"}
+	if fmt.Sprint(*queries) != fmt.Sprint(want) {
+		t.Errorf("queries = %q, want %q", *queries, want)
+	}
+}
+
+func TestServersService_List_SearchFallbackDisabled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleSearchRejected(mux)
+
+	_, _, err := client.Servers.List(context.Background(), &ServerListOptions{Search: "github"})
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response.StatusCode != http.StatusBadRequest {
+		t.Errorf("List error = %v, want 400 *ErrorResponse", err)
+	}
+}
+
+func TestServersService_List_SearchSupported(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithSearchFallback()(client); err != nil {
+		t.Fatalf("WithSearchFallback returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("search"); got != "github" {
+			t.Errorf("search = %q, want %q", got, "github")
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"io.github.example/tools"}}],"metadata":{"count":1}}`)
+	})
+
+	servers, resp, err := client.Servers.List(context.Background(), &ServerListOptions{Search: "github"})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if resp.SearchFallback || len(servers.Servers) != 1 {
+		t.Errorf("List = %d servers, SearchFallback = %v; want registry results", len(servers.Servers), resp.SearchFallback)
+	}
+}
+
+func TestServersService_List_SearchFallbackOtherParameter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithSearchFallback()(client); err != nil {
+		t.Fatalf("WithSearchFallback returned error: %v", err)
+	}
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") == "1000" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message":"validation failed","errors":[{"field":"limit","message":"must be at most 100"}]}`)
+			return
+		}
+		if got := r.URL.Query().Get("search"); got != "github" {
+			t.Errorf("search = %q, want %q", got, "github")
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"io.github.example/tools"}}],"metadata":{"count":1}}`)
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Servers.List(ctx, &ServerListOptions{Search: "github", ListOptions: ListOptions{Limit: 1000}})
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("List with an invalid limit returned %v, want the 422 error", err)
+	}
+
+	// The rejected limit does not disable searching.
+	_, resp, err := client.Servers.List(ctx, &ServerListOptions{Search: "github"})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if resp.SearchFallback {
+		t.Error("Response.SearchFallback = true after a rejected limit, want false")
+	}
+}
+
+func TestSearchRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"message", &ErrorResponse{Response: &http.Response{StatusCode: http.StatusBadRequest}, Message: "Search is not supported"}, true},
+		{"field", &ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}, Errors: []Error{{Field: "search"}}}, true},
+		{"other field", &ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}, Errors: []Error{{Field: "limit"}}}, false},
+		{"no details", &ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotImplemented}}, false},
+		{"other status", &ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "search"}, false},
+		{"no response", &ErrorResponse{Message: "search"}, false},
+		{"other error", errors.New("search"), false},
+	}
+	for _, tt := range tests {
+		if got := searchRejected(tt.err); got != tt.want {
+			t.Errorf("%s: searchRejected = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// SeedOutcome describes what ImportSeed did with a seed entry.
+type SeedOutcome string
+
+// Seed entry outcomes.
+const (
+	SeedPublished SeedOutcome = "published"
+	SeedSkipped   SeedOutcome = "skipped"
+	SeedFailed    SeedOutcome = "failed"
+)
+
+// SeedProgress reports the outcome of one seed entry.
+type SeedProgress struct {
+	// Index is the position of the entry in the seed file, and Total the
+	// number of entries in it.
+	Index int
+	Total int
+
+	// Name and Version identify the entry.
+	Name    string
+	Version string
+
+	Outcome SeedOutcome
+
+	// Err is the error that made the entry fail, if any.
+	Err error
+}
+
+// ImportSeedOptions specifies the optional parameters to the
+// AdminService.ImportSeed method.
+type ImportSeedOptions struct {
+	// Offset skips the first Offset entries of the seed file, to resume an
+	// import from the Index reported for the last completed entry plus one.
+	// It must not be negative.
+	Offset int
+
+	// SkipExisting checks whether each server version already exists before
+	// publishing it, and skips those that do. This makes it safe to re-run
+	// an interrupted import.
+	SkipExisting bool
+
+	// Progress, if set, is called after each entry.
+	Progress func(SeedProgress)
+}
+
+// SeedImport summarizes the result of ImportSeed.
+type SeedImport struct {
+	Published int
+	Skipped   int
+
+	// Failed lists the entries that could not be imported.
+	Failed []SeedProgress
+}
+
+// ImportSeed publishes the servers of a seed file, in the format used by the
+// official registry's seed data (a JSON array of server.json documents), to
+// the registry, for example when standing up an internal registry.
+//
+// Entries that fail to publish are recorded in the result and the import
+// continues. The import stops early only if ctx is done, in which case the
+// partial result is returned along with ctx.Err().
+//
+// Publishing requires a registry token with publish permission for every
+// namespace in the seed file, configured with WithAuthToken or
+// ContextWithToken.
+func (s *AdminService) ImportSeed(ctx context.Context, r io.Reader, opts *ImportSeedOptions, reqOpts ...RequestOption) (*SeedImport, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
+	if opts == nil {
+		opts = &ImportSeedOptions{}
+	}
+	if opts.Offset < 0 {
+		return nil, nil, fmt.Errorf("seed offset cannot be negative, got %d", opts.Offset)
+	}
+
+	var servers []registryv0.ServerJSON
+	if err := json.NewDecoder(r).Decode(&servers); err != nil {
+		return nil, nil, fmt.Errorf("decoding seed file: %w", err)
+	}
+
+	result := &SeedImport{}
+	var lastResp *Response
+
+	for i := opts.Offset; i < len(servers); i++ {
+		if err := ctx.Err(); err != nil {
+			return result, lastResp, err
+		}
+
+		server := &servers[i]
+		progress := SeedProgress{
+			Index:   i,
+			Total:   len(servers),
+			Name:    server.Name,
+			Version: server.Version,
+			Outcome: SeedPublished,
+		}
+
+		exists := false
+		if opts.SkipExisting {
+			var resp *Response
+			var err error
+			exists, resp, err = s.versionExists(ctx, server.Name, server.Version)
+			if resp != nil {
+				lastResp = resp
+			}
+			if err != nil {
+				progress.Outcome, progress.Err = SeedFailed, err
+			}
+		}
+
+		switch {
+		case progress.Err != nil:
+		case exists:
+			progress.Outcome = SeedSkipped
+		default:
+			_, resp, err := s.client.Servers.Publish(ctx, server)
+			if resp != nil {
+				lastResp = resp
+			}
+			if err != nil {
+				progress.Outcome, progress.Err = SeedFailed, err
+			}
+		}
+
+		if progress.Err != nil && ctx.Err() != nil {
+			return result, lastResp, ctx.Err()
+		}
+
+		switch progress.Outcome {
+		case SeedPublished:
+			result.Published++
+		case SeedSkipped:
+			result.Skipped++
+		case SeedFailed:
+			result.Failed = append(result.Failed, progress)
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+	}
+
+	return result, lastResp, nil
+}
+
+// versionExists reports whether the registry has the given server version.
+func (s *AdminService) versionExists(ctx context.Context, name, version string) (bool, *Response, error) {
+	params := map[string]string{"serverName": name, "version": version}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersion, params, nil, nil)
+	if err != nil {
+		return false, nil, err
+	}
+
+	resp, err := s.client.Do(ctx, req, nil)
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+		return false, resp, nil
+	}
+	if err != nil {
+		return false, resp, err
+	}
+
+	return true, resp, nil
+}
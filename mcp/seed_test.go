@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const testSeed = `[
+	{"name":"com.example/one","version":"1.0.0"},
+	{"name":"com.example/two","version":"1.0.0"},
+	{"name":"com.example/bad","version":"1.0.0"}
+]`
+
+func TestAdminService_ImportSeed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithAdmin()(client); err != nil {
+		t.Fatalf("WithAdmin returned error: %v", err)
+	}
+
+	var published []string
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var server registryv0.ServerJSON
+		if err := json.NewDecoder(r.Body).Decode(&server); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if server.Name == "com.example/bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"title":"Bad Request","status":400}`)
+			return
+		}
+		published = append(published, server.Name)
+		fmt.Fprintf(w, `{"server":{"name":%q,"version":%q}}`, server.Name, server.Version)
+	})
+	mux.HandleFunc("/v0.1/servers/com.example%2Fone/versions/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"server":{"name":"com.example/one","version":"1.0.0"}}`)
+	})
+	mux.HandleFunc("/v0.1/servers/", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	var progress []SeedProgress
+	result, _, err := client.Admin.ImportSeed(context.Background(), strings.NewReader(testSeed), &ImportSeedOptions{
+		SkipExisting: true,
+		Progress:     func(p SeedProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("ImportSeed returned error: %v", err)
+	}
+
+	if result.Published != 1 || result.Skipped != 1 || len(result.Failed) != 1 {
+		t.Errorf("ImportSeed = %+v, want 1 published, 1 skipped, 1 failed", result)
+	}
+	if len(result.Failed) == 1 && (result.Failed[0].Name != "com.example/bad" || result.Failed[0].Err == nil) {
+		t.Errorf("ImportSeed Failed = %+v, want com.example/bad with error", result.Failed)
+	}
+	if len(published) != 1 || published[0] != "com.example/two" {
+		t.Errorf("published %v, want [com.example/two]", published)
+	}
+
+	wantOutcomes := []SeedOutcome{SeedSkipped, SeedPublished, SeedFailed}
+	if len(progress) != len(wantOutcomes) {
+		t.Fatalf("Progress called %d times, want %d", len(progress), len(wantOutcomes))
+	}
+	for i, p := range progress {
+		if p.Index != i || p.Total != 3 || p.Outcome != wantOutcomes[i] {
+			t.Errorf("progress[%d] = %+v, want index %d of 3, outcome %s", i, p, i, wantOutcomes[i])
+		}
+	}
+}
+
+func TestAdminService_ImportSeed_Offset(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithAdmin()(client); err != nil {
+		t.Fatalf("WithAdmin returned error: %v", err)
+	}
+
+	var published []string
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		var server registryv0.ServerJSON
+		json.NewDecoder(r.Body).Decode(&server)
+		published = append(published, server.Name)
+		fmt.Fprint(w, `{}`)
+	})
+
+	result, _, err := client.Admin.ImportSeed(context.Background(), strings.NewReader(testSeed), &ImportSeedOptions{Offset: 2})
+	if err != nil {
+		t.Fatalf("ImportSeed returned error: %v", err)
+	}
+	if result.Published != 1 || len(published) != 1 || published[0] != "com.example/bad" {
+		t.Errorf("ImportSeed published %v, want only the last entry", published)
+	}
+}
+
+func TestAdminService_ImportSeed_NegativeOffset(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithAdmin()(client); err != nil {
+		t.Fatalf("WithAdmin returned error: %v", err)
+	}
+	mux.HandleFunc("/v0.1/publish", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("ImportSeed published with a negative offset")
+	})
+
+	result, _, err := client.Admin.ImportSeed(context.Background(), strings.NewReader(testSeed), &ImportSeedOptions{Offset: -1})
+	if err == nil {
+		t.Error("ImportSeed with a negative offset returned nil error")
+	}
+	if result != nil {
+		t.Errorf("ImportSeed returned %+v, want nil", result)
+	}
+}
+
+func TestAdminService_ImportSeed_InvalidFile(t *testing.T) {
+	client, err := NewClient(nil, WithAdmin())
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	if _, _, err := client.Admin.ImportSeed(context.Background(), strings.NewReader(`{"name":"x"}`), nil); err == nil {
+		t.Error("ImportSeed with invalid seed file returned nil error")
+	}
+}
@@ -0,0 +1,211 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// defaultRepositoryTTL is how long a ServerRepository keeps a resolution
+// before refreshing it, unless configured otherwise.
+const defaultRepositoryTTL = 5 * time.Minute
+
+// ResolutionChange describes a resolution refreshed by a ServerRepository
+// that now names a different version.
+type ResolutionChange struct {
+	Name       string
+	Constraint string
+
+	// Old and New are the servers the resolution named before and after
+	// the refresh.
+	Old *registryv0.ServerJSON
+	New *registryv0.ServerJSON
+}
+
+// ServerRepositoryOptions specifies the optional parameters to
+// NewServerRepository.
+type ServerRepositoryOptions struct {
+	// TTL is how long a resolution is used before it is refreshed.
+	// Defaults to 5 minutes.
+	TTL time.Duration
+
+	// Resolve sets the other parameters of the resolutions, such as
+	// IncludePrereleases. Its Constraint is replaced by the constraint
+	// passed to GetResolved.
+	Resolve *ResolveOptions
+
+	// OnChange, if set, is called from the refreshing goroutine when a
+	// refreshed resolution names a different version.
+	OnChange func(ResolutionChange)
+
+	// OnError, if set, is called from the refreshing goroutine when a
+	// refresh fails. The previous resolution is kept and the refresh is
+	// retried after the TTL.
+	OnError func(name, constraint string, err error)
+}
+
+// ServerRepository is a read-through cache of version resolutions for
+// long-running hosts, such as MCP gateways, that resolve the servers they
+// run by version constraint. The first GetResolved call for a name and
+// constraint resolves it with ServersService.ResolveVersion; the resolution
+// is then served from memory and refreshed in the background every TTL, and
+// OnChange reports when a refresh picks a different version, such as a newly
+// published release.
+//
+// A ServerRepository is safe for concurrent use. Close it to stop the
+// background refreshes.
+type ServerRepository struct {
+	client   *Client
+	ttl      time.Duration
+	resolve  ResolveOptions
+	onChange func(ResolutionChange)
+	onError  func(name, constraint string, err error)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	entries map[resolutionKey]*resolutionEntry
+}
+
+// resolutionKey identifies a resolution.
+type resolutionKey struct {
+	name       string
+	constraint string
+}
+
+// resolutionEntry is a cached resolution.
+type resolutionEntry struct {
+	ready  chan struct{} // closed once the first resolution finishes
+	server *registryv0.ServerJSON
+	err    error
+}
+
+// NewServerRepository returns a ServerRepository that resolves servers with
+// client. opts may be nil.
+func NewServerRepository(client *Client, opts *ServerRepositoryOptions) *ServerRepository {
+	if opts == nil {
+		opts = &ServerRepositoryOptions{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &ServerRepository{
+		client:   client,
+		ttl:      opts.TTL,
+		onChange: opts.OnChange,
+		onError:  opts.OnError,
+		ctx:      ctx,
+		cancel:   cancel,
+		entries:  make(map[resolutionKey]*resolutionEntry),
+	}
+	if r.ttl <= 0 {
+		r.ttl = defaultRepositoryTTL
+	}
+	if opts.Resolve != nil {
+		r.resolve = *opts.Resolve
+	}
+	return r
+}
+
+// GetResolved returns the highest version of the server named name that
+// satisfies the semantic version constraint, such as "^1.2", or the highest
+// version if constraint is empty. Versions are considered as described by
+// ServersService.ResolveVersion. If no version qualifies, a *NotFoundError
+// is returned.
+//
+// Only the first call for a name and constraint contacts the registry;
+// concurrent first calls share its result, and failed resolutions are not
+// cached. The returned server is shared with other callers and must not be
+// modified.
+func (r *ServerRepository) GetResolved(ctx context.Context, name, constraint string) (*registryv0.ServerJSON, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, fmt.Errorf("server repository is closed")
+	}
+	key := resolutionKey{name: name, constraint: constraint}
+
+	r.mu.Lock()
+	e, ok := r.entries[key]
+	if !ok {
+		e = &resolutionEntry{ready: make(chan struct{})}
+		r.entries[key] = e
+		r.mu.Unlock()
+
+		server, err := r.resolveKey(ctx, key)
+
+		r.mu.Lock()
+		e.server, e.err = server, err
+		if err != nil {
+			delete(r.entries, key)
+		} else {
+			go r.refresh(key, e)
+		}
+		close(e.ready)
+		r.mu.Unlock()
+		return server, err
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-e.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return e.server, e.err
+}
+
+// Close stops the background refreshes. Resolutions already returned remain
+// valid; later GetResolved calls return an error.
+func (r *ServerRepository) Close() {
+	r.cancel()
+}
+
+// resolveKey resolves key with the repository's client.
+func (r *ServerRepository) resolveKey(ctx context.Context, key resolutionKey) (*registryv0.ServerJSON, error) {
+	opts := r.resolve
+	opts.Constraint = key.constraint
+	server, _, err := r.client.Servers.ResolveVersion(ctx, key.name, &opts)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, &NotFoundError{Name: key.name, Version: key.constraint}
+	}
+	return server, nil
+}
+
+// refresh resolves key again every TTL until the repository is closed,
+// updating e and reporting changes.
+func (r *ServerRepository) refresh(key resolutionKey, e *resolutionEntry) {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-r.client.clock.After(r.ttl):
+		}
+
+		server, err := r.resolveKey(r.ctx, key)
+		if r.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if r.onError != nil {
+				r.onError(key.name, key.constraint, err)
+			}
+			continue
+		}
+
+		r.mu.Lock()
+		old := e.server
+		e.server = server
+		r.mu.Unlock()
+
+		if old.Version != server.Version && r.onChange != nil {
+			r.onChange(ResolutionChange{Name: key.name, Constraint: key.constraint, Old: old, New: server})
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lujin3/go-mcp-registry/mcp/mcptest"
+)
+
+func TestServerRepository(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	clock := mcptest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := WithClock(clock)(client); err != nil {
+		t.Fatalf("WithClock returned error: %v", err)
+	}
+
+	var mu sync.Mutex
+	versions := []string{"1.0.0", "1.1.0", "2.0.0"}
+	var requests atomic.Int32
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		mu.Lock()
+		defer mu.Unlock()
+		entries := make([]string, len(versions))
+		for i, v := range versions {
+			entries[i] = fmt.Sprintf(`{"server":{"name":"com.example/weather","version":%q}}`, v)
+		}
+		fmt.Fprintf(w, `{"servers":[%s],"metadata":{}}`, strings.Join(entries, ","))
+	})
+
+	changes := make(chan ResolutionChange, 1)
+	repo := NewServerRepository(client, &ServerRepositoryOptions{
+		TTL:      time.Minute,
+		OnChange: func(c ResolutionChange) { changes <- c },
+	})
+	defer repo.Close()
+
+	ctx := context.Background()
+	for range 2 {
+		server, err := repo.GetResolved(ctx, "com.example/weather", "^1.0")
+		if err != nil {
+			t.Fatalf("GetResolved returned error: %v", err)
+		}
+		if server.Version != "1.1.0" {
+			t.Errorf("GetResolved(^1.0) = %s, want 1.1.0", server.Version)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+
+	if server, err := repo.GetResolved(ctx, "com.example/weather", ""); err != nil || server.Version != "2.0.0" {
+		t.Errorf("GetResolved without constraint = %v, %v; want 2.0.0", server, err)
+	}
+
+	mu.Lock()
+	versions = append(versions, "1.2.0")
+	mu.Unlock()
+
+	clock.BlockUntil(2)
+	clock.Advance(time.Minute)
+
+	select {
+	case c := <-changes:
+		if c.Constraint != "^1.0" || c.Old.Version != "1.1.0" || c.New.Version != "1.2.0" {
+			t.Errorf("change = %s %s -> %s, want ^1.0 1.1.0 -> 1.2.0", c.Constraint, c.Old.Version, c.New.Version)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported after refresh")
+	}
+	if server, _ := repo.GetResolved(ctx, "com.example/weather", "^1.0"); server.Version != "1.2.0" {
+		t.Errorf("GetResolved after refresh = %s, want 1.2.0", server.Version)
+	}
+}
+
+func TestServerRepository_Errors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/weather","version":"1.0.0"}}],"metadata":{}}`)
+	})
+
+	repo := NewServerRepository(client, nil)
+	ctx := context.Background()
+
+	var notFound *NotFoundError
+	if _, err := repo.GetResolved(ctx, "com.example/weather", "^2"); !errors.As(err, &notFound) {
+		t.Errorf("GetResolved(^2) error = %v, want *NotFoundError", err)
+	}
+	if _, err := repo.GetResolved(ctx, "com.example/weather", "not a constraint"); err == nil {
+		t.Error("GetResolved with invalid constraint returned nil error")
+	}
+
+	repo.Close()
+	if _, err := repo.GetResolved(ctx, "com.example/weather", ""); err == nil {
+		t.Error("GetResolved after Close returned nil error")
+	}
+}
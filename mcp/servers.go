@@ -2,9 +2,6 @@ package mcp
 
 import (
 	"context"
-	"fmt"
-	"net/http"
-	"net/url"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -15,20 +12,25 @@ import (
 // List retrieves a paginated list of servers from the MCP Registry.
 //
 // MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/servers/get_servers_v0_servers_get
-func (s *ServersService) List(ctx context.Context, opts *ServerListOptions) (*registryv0.ServerListResponse, *Response, error) {
-	u := "v0.1/servers"
-	u, err := addOptions(u, opts)
-	if err != nil {
-		return nil, nil, err
+func (s *ServersService) List(ctx context.Context, opts *ServerListOptions, reqOpts ...RequestOption) (*registryv0.ServerListResponse, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
+	if s.client.searchFallback && opts != nil && opts.Search != "" {
+		return s.listWithSearchFallback(ctx, opts)
 	}
 
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	return s.list(ctx, opts)
+}
+
+// list sends a single list request with opts.
+func (s *ServersService) list(ctx context.Context, opts *ServerListOptions) (*registryv0.ServerListResponse, *Response, error) {
+	req, err := s.client.NewRouteRequest(RouteListServers, nil, opts, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var servers *registryv0.ServerListResponse
-	resp, err := s.client.Do(ctx, req, &servers)
+	resp, err := s.client.doCached(ctx, s.client.listCache, req, &servers)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -50,32 +52,31 @@ func (s *ServersService) List(ctx context.Context, opts *ServerListOptions) (*re
 // GET /v0.1/servers/{serverName}/versions/latest or GET /v0.1/servers/{serverName}/versions/{version}
 //
 // MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-server
-func (s *ServersService) Get(ctx context.Context, serverName string, opts *ServerGetOptions) (*registryv0.ServerJSON, *Response, error) {
-	// URL-encode the server name to handle forward slashes
-	encodedName := url.PathEscape(serverName)
-
+func (s *ServersService) Get(ctx context.Context, serverName string, opts *ServerGetOptions, reqOpts ...RequestOption) (*registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
 	// Determine the version to fetch
 	version := "latest"
 	if opts != nil && opts.Version != "" {
-		version = url.PathEscape(opts.Version)
+		version = opts.Version
 	}
 
-	u := fmt.Sprintf("v0.1/servers/%s/versions/%s", encodedName, version)
-
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	// The server name and version are URL-encoded by the route to handle forward slashes
+	params := map[string]string{"serverName": serverName, "version": version}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersion, params, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var serverResp *registryv0.ServerResponse
-	resp, err := s.client.Do(ctx, req, &serverResp)
+	resp, err := s.client.doCached(ctx, s.client.versionCache, req, &serverResp)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, s.notFound(ctx, err, serverName, version)
 	}
 
 	// Unwrap ServerResponse to get the ServerJSON
 	if serverResp == nil {
-		return nil, resp, nil
+		return nil, resp, s.missing(ctx, serverName, version)
 	}
 
 	return &serverResp.Server, resp, nil
@@ -87,20 +88,20 @@ func (s *ServersService) Get(ctx context.Context, serverName string, opts *Serve
 // Server names contain forward slashes (e.g., "ai.waystation/gmail") and will be URL-encoded automatically.
 //
 // MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/get-server-versions
-func (s *ServersService) ListVersionsByName(ctx context.Context, serverName string) ([]registryv0.ServerJSON, *Response, error) {
-	// URL-encode the server name to handle forward slashes
-	encodedName := url.PathEscape(serverName)
-	u := fmt.Sprintf("v0.1/servers/%s/versions", encodedName)
-
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+func (s *ServersService) ListVersionsByName(ctx context.Context, serverName string, opts ...RequestOption) ([]registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+	// The server name is URL-encoded by the route to handle forward slashes
+	params := map[string]string{"serverName": serverName}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersions, params, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var serverResp *registryv0.ServerListResponse
-	resp, err := s.client.Do(ctx, req, &serverResp)
+	resp, err := s.client.doCached(ctx, s.client.versionCache, req, &serverResp)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, s.notFound(ctx, err, serverName, "")
 	}
 
 	// Extract servers from the response, unwrapping ServerResponse to ServerJSON
@@ -111,42 +112,31 @@ func (s *ServersService) ListVersionsByName(ctx context.Context, serverName stri
 			servers[i] = serverResponse.Server
 		}
 	}
+	if len(servers) == 0 {
+		return nil, resp, s.missing(ctx, serverName, "")
+	}
 
 	return servers, resp, nil
 }
 
 // ListAll fetches all pages of results for servers.
 // This is a convenience method that handles pagination automatically.
-func (s *ServersService) ListAll(ctx context.Context, opts *ServerListOptions) ([]registryv0.ServerJSON, *Response, error) {
+// If the registry rejects an expired cursor, listing resumes after the last
+// server received, or restarts and skips servers already received, so long
+// crawls survive pauses between pages.
+func (s *ServersService) ListAll(ctx context.Context, opts *ServerListOptions, reqOpts ...RequestOption) ([]registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
 	if opts == nil {
 		opts = &ServerListOptions{}
 	}
 
 	var allServers []registryv0.ServerJSON
-	var lastResp *Response
-
-	for {
-		resp, httpResp, err := s.List(ctx, opts)
-		if err != nil {
-			return allServers, httpResp, err
-		}
-
-		lastResp = httpResp
-
-		// Unwrap ServerResponse to ServerJSON for each server
-		if resp.Servers != nil {
-			for _, serverResponse := range resp.Servers {
-				allServers = append(allServers, serverResponse.Server)
-			}
-		}
-
-		// Check if there are more pages
-		if resp.Metadata.NextCursor == "" {
-			break
-		}
-
-		// Update cursor for next request
-		opts.Cursor = resp.Metadata.NextCursor
+	lastResp, err := s.listPages(ctx, opts, func(entry *registryv0.ServerResponse) {
+		allServers = append(allServers, entry.Server)
+	})
+	if err != nil {
+		return allServers, lastResp, err
 	}
 
 	return allServers, lastResp, nil
@@ -155,8 +145,12 @@ func (s *ServersService) ListAll(ctx context.Context, opts *ServerListOptions) (
 // ListByName retrieves all servers with the specified name.
 // Since each server can have multiple versions in the registry,
 // this method returns a slice containing all matching servers.
-// Returns an empty slice if no matches are found.
-func (s *ServersService) ListByName(ctx context.Context, name string) ([]registryv0.ServerJSON, *Response, error) {
+// Names are matched exactly unless configured with WithNameMatcher.
+// Returns an empty slice if no matches are found, or a *NotFoundError if the
+// client uses NotFoundAsError; see WithNotFoundPolicy.
+func (s *ServersService) ListByName(ctx context.Context, name string, reqOpts ...RequestOption) ([]registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
 	opts := &ServerListOptions{
 		Search: name,
 		ListOptions: ListOptions{
@@ -175,9 +169,9 @@ func (s *ServersService) ListByName(ctx context.Context, name string) ([]registr
 
 		lastResp = httpResp
 
-		// Collect all exact matches, unwrapping ServerResponse to ServerJSON
+		// Collect all matches, unwrapping ServerResponse to ServerJSON
 		for _, serverResponse := range resp.Servers {
-			if serverResponse.Server.Name == name {
+			if s.client.matchName(name, serverResponse.Server.Name) {
 				matchingServers = append(matchingServers, serverResponse.Server)
 			}
 		}
@@ -190,14 +184,22 @@ func (s *ServersService) ListByName(ctx context.Context, name string) ([]registr
 		opts.Cursor = resp.Metadata.NextCursor
 	}
 
+	if len(matchingServers) == 0 {
+		return nil, lastResp, s.missing(ctx, name, "")
+	}
+
 	return matchingServers, lastResp, nil
 }
 
 // GetByNameLatest retrieves the latest version of a server with the specified name.
 // This method uses the version=latest query parameter to filter results to only
-// the latest version, then returns the match.
-// Returns nil if no latest version is found.
-func (s *ServersService) GetByNameLatest(ctx context.Context, name string) (*registryv0.ServerJSON, *Response, error) {
+// the latest version, then returns the first match. Names are matched exactly
+// unless configured with WithNameMatcher.
+// Returns nil if no latest version is found, or a *NotFoundError if the
+// client uses NotFoundAsError; see WithNotFoundPolicy.
+func (s *ServersService) GetByNameLatest(ctx context.Context, name string, reqOpts ...RequestOption) (*registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
 	opts := &ServerListOptions{
 		Search:  name,
 		Version: "latest",
@@ -216,9 +218,9 @@ func (s *ServersService) GetByNameLatest(ctx context.Context, name string) (*reg
 
 		lastResp = httpResp
 
-		// Look for exact match, unwrapping ServerResponse to ServerJSON
+		// Look for a match, unwrapping ServerResponse to ServerJSON
 		for _, serverResponse := range resp.Servers {
-			if serverResponse.Server.Name == name {
+			if s.client.matchName(name, serverResponse.Server.Name) {
 				return &serverResponse.Server, lastResp, nil
 			}
 		}
@@ -231,7 +233,7 @@ func (s *ServersService) GetByNameLatest(ctx context.Context, name string) (*reg
 		opts.Cursor = resp.Metadata.NextCursor
 	}
 
-	return nil, lastResp, nil
+	return nil, lastResp, s.missing(ctx, name, "latest")
 }
 
 // GetByNameExactVersion retrieves a specific version of a server with the specified name.
@@ -240,27 +242,28 @@ func (s *ServersService) GetByNameLatest(ctx context.Context, name string) (*reg
 //
 // Server names contain forward slashes (e.g., "ai.waystation/gmail") and will be URL-encoded automatically.
 //
-// Returns nil if no matching version is found.
-func (s *ServersService) GetByNameExactVersion(ctx context.Context, name, version string) (*registryv0.ServerJSON, *Response, error) {
-	// URL-encode the server name and version to handle forward slashes and special characters
-	encodedName := url.PathEscape(name)
-	encodedVersion := url.PathEscape(version)
-	u := fmt.Sprintf("v0.1/servers/%s/versions/%s", encodedName, encodedVersion)
-
-	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+// If no matching version is found, the registry's 404 *ErrorResponse is
+// returned, or nil or a *NotFoundError if the client uses NotFoundAsNil or
+// NotFoundAsError; see WithNotFoundPolicy.
+func (s *ServersService) GetByNameExactVersion(ctx context.Context, name, version string, opts ...RequestOption) (*registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+	// The server name and version are URL-encoded by the route to handle forward slashes and special characters
+	params := map[string]string{"serverName": name, "version": version}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersion, params, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var serverResp *registryv0.ServerResponse
-	resp, err := s.client.Do(ctx, req, &serverResp)
+	resp, err := s.client.doCached(ctx, s.client.versionCache, req, &serverResp)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, s.notFound(ctx, err, name, version)
 	}
 
 	// Unwrap ServerResponse to get the ServerJSON
 	if serverResp == nil {
-		return nil, resp, nil
+		return nil, resp, s.missing(ctx, name, version)
 	}
 
 	return &serverResp.Server, resp, nil
@@ -269,8 +272,13 @@ func (s *ServersService) GetByNameExactVersion(ctx context.Context, name, versio
 // GetByNameLatestActiveVersion retrieves the latest active version of a server with the specified name.
 // This method performs client-side filtering to find servers with Status == "active",
 // then uses semantic version comparison to determine the latest version.
-// Returns nil if no active versions are found.
-func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name string) (*registryv0.ServerJSON, *Response, error) {
+// Prereleases are skipped; use ResolveVersion with IncludePrereleases to consider them.
+// Names are matched exactly unless configured with WithNameMatcher.
+// Returns nil if no active versions are found, or a *NotFoundError if the
+// client uses NotFoundAsError; see WithNotFoundPolicy.
+func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name string, reqOpts ...RequestOption) (*registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
 	opts := &ServerListOptions{
 		Search: name,
 		ListOptions: ListOptions{
@@ -290,7 +298,7 @@ func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name
 
 		lastResp = httpResp
 
-		// Look for active servers with exact name match
+		// Look for active servers with a matching name
 		// Note: Status has moved from ServerJSON to ServerResponse.Meta.Official.Status
 		for _, serverResponse := range resp.Servers {
 			// Check if server has official metadata with status
@@ -298,16 +306,19 @@ func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name
 				continue
 			}
 
-			if serverResponse.Server.Name == name && serverResponse.Meta.Official.Status == model.StatusActive {
+			if s.client.matchName(name, serverResponse.Server.Name) && serverResponse.Meta.Official.Status == model.StatusActive {
 				// Try to parse the version as semantic version
-				version, err := semver.NewVersion(serverResponse.Server.Version)
+				version, err := parseVersion(serverResponse.Server.Version)
 				if err != nil {
 					// Skip servers with invalid semantic versions
 					continue
 				}
+				if version.Prerelease() != "" {
+					continue
+				}
 
 				// Keep track of the latest version
-				if latestVersion == nil || version.GreaterThan(latestVersion) {
+				if latestVersion == nil || versionGreater(version, latestVersion) {
 					latestVersion = version
 					serverCopy := serverResponse.Server // Create a copy to avoid pointer issues
 					latestServer = &serverCopy
@@ -323,6 +334,10 @@ func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name
 		opts.Cursor = resp.Metadata.NextCursor
 	}
 
+	if latestServer == nil {
+		return nil, lastResp, s.missing(ctx, name, "latest active")
+	}
+
 	return latestServer, lastResp, nil
 }
 
@@ -330,7 +345,9 @@ func (s *ServersService) GetByNameLatestActiveVersion(ctx context.Context, name
 // This method automatically handles pagination to return all matching servers.
 // The timestamp should be in RFC3339 format.
 // Returns an empty slice if no servers have been updated since the timestamp.
-func (s *ServersService) ListByUpdatedSince(ctx context.Context, since time.Time) ([]registryv0.ServerJSON, *Response, error) {
+func (s *ServersService) ListByUpdatedSince(ctx context.Context, since time.Time, reqOpts ...RequestOption) ([]registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
 	opts := &ServerListOptions{
 		UpdatedSince: &since,
 		ListOptions: ListOptions{
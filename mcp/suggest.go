@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SuggestNames returns up to n names of registry servers close to name, the
+// closest first, for "did you mean" hints after a lookup of a misspelled
+// name. The registry only searches by substring, so candidates are gathered
+// with a few searches for parts of name, such as its last path segment and
+// namespace, and ranked by edit distance. Names too different from name are
+// not suggested.
+func (s *ServersService) SuggestNames(ctx context.Context, name string, n int, opts ...RequestOption) ([]string, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+	if n <= 0 || name == "" {
+		return nil, nil
+	}
+
+	candidates := make(map[string]bool)
+	for _, query := range suggestQueries(name) {
+		page, _, err := s.List(ctx, &ServerListOptions{Search: query, Version: "latest", ListOptions: ListOptions{Limit: 100}})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range page.Servers {
+			candidates[entry.Server.Name] = true
+		}
+	}
+	delete(candidates, name)
+
+	type suggestion struct {
+		name     string
+		distance int
+	}
+	// Allow about one edit per three characters of the server's own name,
+	// so long namespaces do not make unrelated servers in them qualify.
+	lower := strings.ToLower(name)
+	maxDistance := max(2, len(name[strings.LastIndex(name, "/")+1:])/3)
+	var suggestions []suggestion
+	for candidate := range candidates {
+		if d := editDistance(lower, strings.ToLower(candidate)); d <= maxDistance {
+			suggestions = append(suggestions, suggestion{candidate, d})
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+
+	names := make([]string, 0, min(n, len(suggestions)))
+	for _, s := range suggestions[:min(n, len(suggestions))] {
+		names = append(names, s.name)
+	}
+	return names, nil
+}
+
+// suggestQueries returns the searches that gather suggestion candidates for
+// name: its last path segment, both halves of that segment, one of which a
+// single typo leaves intact, and its namespace.
+func suggestQueries(name string) []string {
+	namespace, segment, found := strings.Cut(name, "/")
+	if !found {
+		segment, namespace = namespace, ""
+	}
+
+	var queries []string
+	add := func(q string) {
+		if len(q) >= 3 && !slices.Contains(queries, q) {
+			queries = append(queries, q)
+		}
+	}
+	add(segment)
+	add(segment[:len(segment)/2])
+	add(segment[len(segment)/2:])
+	add(namespace)
+	return queries
+}
+
+// editDistance returns the Levenshtein distance between a and b, counted in
+// bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestServersService_SuggestNames(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	names := []string{
+		"io.github.example/weather",
+		"io.github.example/weather-pro",
+		"io.github.example/tools",
+		"io.github.example/wither",
+		"io.github.other/weather",
+		"com.example/wetter",
+	}
+	var queries []string
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("search")
+		queries = append(queries, query)
+		var entries []string
+		for _, name := range names {
+			if strings.Contains(name, query) {
+				entries = append(entries, fmt.Sprintf(`{"server":{"name":%q,"version":"1.0.0"}}`, name))
+			}
+		}
+		fmt.Fprintf(w, `{"servers":[%s],"metadata":{}}`, strings.Join(entries, ","))
+	})
+
+	got, err := client.Servers.SuggestNames(context.Background(), "io.github.example/wether", 3)
+	if err != nil {
+		t.Fatalf("SuggestNames returned error: %v", err)
+	}
+	want := []string{"io.github.example/weather", "io.github.example/wither"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SuggestNames = %v, want %v", got, want)
+	}
+	if want := []string{"wether", "wet", "her", "io.github.example"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("searched %v, want %v", queries, want)
+	}
+
+	if got, _ := client.Servers.SuggestNames(context.Background(), "io.github.example/wether", 1); len(got) != 1 {
+		t.Errorf("SuggestNames with n 1 = %v, want one name", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"weather", "weather", 0},
+		{"wether", "weather", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
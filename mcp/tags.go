@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// TagRule assigns Tag to servers whose short name, description, or package
+// identifiers contain any of Keywords as a whole word. Keywords are matched
+// case-insensitively.
+type TagRule struct {
+	Tag      string
+	Keywords []string
+}
+
+// DefaultTagRules is the rule set used by InferTags and by a Tagger without
+// rules of its own.
+var DefaultTagRules = []TagRule{
+	{Tag: "database", Keywords: []string{"database", "db", "sql", "postgres", "postgresql", "mysql", "sqlite", "mongodb", "mongo", "redis", "supabase", "clickhouse", "bigquery", "snowflake", "neo4j"}},
+	{Tag: "browser", Keywords: []string{"browser", "playwright", "puppeteer", "selenium", "chrome", "chromium", "scraping", "scraper", "crawler"}},
+	{Tag: "email", Keywords: []string{"email", "e-mail", "mail", "gmail", "outlook", "smtp", "imap", "mailgun", "sendgrid"}},
+	{Tag: "kubernetes", Keywords: []string{"kubernetes", "k8s", "kubectl", "helm", "openshift"}},
+	{Tag: "cloud", Keywords: []string{"aws", "azure", "gcp", "cloudflare", "s3", "lambda"}},
+	{Tag: "git", Keywords: []string{"git", "github", "gitlab", "bitbucket"}},
+	{Tag: "search", Keywords: []string{"search", "brave", "tavily", "exa", "perplexity"}},
+	{Tag: "filesystem", Keywords: []string{"filesystem", "file", "files"}},
+	{Tag: "chat", Keywords: []string{"slack", "discord", "teams", "telegram", "whatsapp"}},
+	{Tag: "calendar", Keywords: []string{"calendar", "scheduling"}},
+	{Tag: "payments", Keywords: []string{"stripe", "payment", "payments", "paypal", "billing"}},
+	{Tag: "monitoring", Keywords: []string{"monitoring", "observability", "grafana", "prometheus", "datadog", "sentry", "logs"}},
+}
+
+// Tagger infers tags for registry entries, which carry no formal
+// categories. Rules are applied to the server's name, description, and
+// package identifiers; Overrides replace the inferred tags of specific
+// servers entirely.
+//
+// The zero value uses DefaultTagRules.
+type Tagger struct {
+	// Rules are the inference rules. If nil, DefaultTagRules is used.
+	Rules []TagRule
+
+	// Overrides maps server names to the exact tags to report for them,
+	// bypassing inference.
+	Overrides map[string][]string
+}
+
+// InferTags returns the tags inferred for server with DefaultTagRules.
+func InferTags(server *registryv0.ServerJSON) []string {
+	return (&Tagger{}).Tags(server)
+}
+
+// Tags returns the sorted, de-duplicated tags of server.
+func (t *Tagger) Tags(server *registryv0.ServerJSON) []string {
+	if server == nil {
+		return nil
+	}
+
+	if tags, ok := t.Overrides[server.Name]; ok {
+		return normalizeTags(tags)
+	}
+
+	words := make(map[string]bool)
+	addWords := func(s string) {
+		for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+		}) {
+			words[w] = true
+			// Hyphenated names also match on their parts, e.g. "postgres-mcp"
+			for _, part := range strings.Split(w, "-") {
+				words[part] = true
+			}
+		}
+	}
+
+	// Only the part after the namespace is considered, since namespaces such
+	// as "io.github.user" describe the publisher rather than the server
+	_, shortName, found := strings.Cut(server.Name, "/")
+	if !found {
+		shortName = server.Name
+	}
+	addWords(shortName)
+	addWords(server.Description)
+	for _, pkg := range server.Packages {
+		addWords(pkg.Identifier)
+	}
+
+	rules := t.Rules
+	if rules == nil {
+		rules = DefaultTagRules
+	}
+
+	var tags []string
+	for _, rule := range rules {
+		for _, kw := range rule.Keywords {
+			if words[strings.ToLower(kw)] {
+				tags = append(tags, rule.Tag)
+				break
+			}
+		}
+	}
+
+	return normalizeTags(tags)
+}
+
+// HasTag reports whether server has tag.
+func (t *Tagger) HasTag(server *registryv0.ServerJSON, tag string) bool {
+	for _, got := range t.Tags(server) {
+		if got == strings.ToLower(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the servers that have tag, preserving their order.
+func (t *Tagger) Filter(servers []registryv0.ServerJSON, tag string) []registryv0.ServerJSON {
+	var matches []registryv0.ServerJSON
+	for i := range servers {
+		if t.HasTag(&servers[i], tag) {
+			matches = append(matches, servers[i])
+		}
+	}
+	return matches
+}
+
+// GroupByTag returns the servers grouped by each of their tags. A server
+// with several tags appears in several groups; untagged servers are omitted.
+func (t *Tagger) GroupByTag(servers []registryv0.ServerJSON) map[string][]registryv0.ServerJSON {
+	groups := make(map[string][]registryv0.ServerJSON)
+	for i := range servers {
+		for _, tag := range t.Tags(&servers[i]) {
+			groups[tag] = append(groups[tag], servers[i])
+		}
+	}
+	return groups
+}
+
+// normalizeTags lowercases, de-duplicates, and sorts tags.
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	var out []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	sort.Strings(out)
+
+	return out
+}
@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"reflect"
+	"testing"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestInferTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *registryv0.ServerJSON
+		want   []string
+	}{
+		{
+			name:   "nil server",
+			server: nil,
+			want:   nil,
+		},
+		{
+			name: "name and description",
+			server: &registryv0.ServerJSON{
+				Name:        "ai.waystation/gmail",
+				Description: "Read and send email from Gmail",
+			},
+			want: []string{"email"},
+		},
+		{
+			name: "hyphenated package identifier",
+			server: &registryv0.ServerJSON{
+				Name:        "io.github.example/server",
+				Description: "Query your data",
+				Packages:    []model.Package{{Identifier: "postgres-mcp"}},
+			},
+			want: []string{"database"},
+		},
+		{
+			name: "multiple tags",
+			server: &registryv0.ServerJSON{
+				Name:        "com.example/k8s-browser",
+				Description: "Inspect Kubernetes clusters with Playwright",
+			},
+			want: []string{"browser", "kubernetes"},
+		},
+		{
+			name: "substring does not match",
+			server: &registryv0.ServerJSON{
+				Name:        "com.example/mailbox-art",
+				Description: "Draws databases",
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InferTags(tt.server); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InferTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagger_CustomRulesAndOverrides(t *testing.T) {
+	tagger := &Tagger{
+		Rules: []TagRule{{Tag: "Weather", Keywords: []string{"forecast"}}},
+		Overrides: map[string][]string{
+			"com.example/special": {"featured", "Email", "featured"},
+		},
+	}
+
+	servers := []registryv0.ServerJSON{
+		{Name: "com.example/weather", Description: "Daily forecast"},
+		{Name: "com.example/special", Description: "Daily forecast"},
+		{Name: "com.example/gmail", Description: "Email"},
+	}
+
+	if got, want := tagger.Tags(&servers[0]), []string{"weather"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tags() = %v, want %v", got, want)
+	}
+	if got, want := tagger.Tags(&servers[1]), []string{"email", "featured"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tags() with override = %v, want %v", got, want)
+	}
+	if got := tagger.Tags(&servers[2]); got != nil {
+		t.Errorf("Tags() with custom rules = %v, want nil", got)
+	}
+
+	filtered := tagger.Filter(servers, "WEATHER")
+	if len(filtered) != 1 || filtered[0].Name != "com.example/weather" {
+		t.Errorf("Filter() = %+v, want only weather server", filtered)
+	}
+
+	groups := tagger.GroupByTag(servers)
+	if len(groups["featured"]) != 1 || len(groups["weather"]) != 1 || len(groups["email"]) != 1 {
+		t.Errorf("GroupByTag() = %+v, want one server per tag", groups)
+	}
+}
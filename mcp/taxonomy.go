@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Error codes of ErrorTaxonomy, returned by ClassifyError.
+const (
+	ErrorCodeBadRequest      = "bad_request"
+	ErrorCodeUnauthorized    = "unauthorized"
+	ErrorCodeForbidden       = "forbidden"
+	ErrorCodeNotFound        = "not_found"
+	ErrorCodeConflict        = "conflict"
+	ErrorCodeInvalid         = "invalid"
+	ErrorCodeRateLimited     = "rate_limited"
+	ErrorCodeServer          = "server_error"
+	ErrorCodeOtherStatus     = "other_status"
+	ErrorCodeNoActiveVersion = "no_active_version"
+	ErrorCodeNoToken         = "no_token"
+	ErrorCodeContract        = "contract"
+	ErrorCodeCanceled        = "canceled"
+	ErrorCodeNetwork         = "network"
+	ErrorCodeOther           = "other"
+)
+
+// ErrorKind describes a kind of error the client returns, as listed by
+// ErrorTaxonomy.
+type ErrorKind struct {
+	// Code identifies the kind, such as "not_found". Codes are stable and
+	// suitable as metric labels and alerting keys.
+	Code string `json:"code"`
+
+	// Status is the HTTP status of the registry response, such as "404" or
+	// "5xx", or "" for errors without one.
+	Status string `json:"status,omitempty"`
+
+	// Sentinel is the sentinel error matching the kind with errors.Is, if
+	// any, such as "mcp.ErrNotFound".
+	Sentinel string `json:"sentinel,omitempty"`
+
+	// Types lists the error types of the kind, matched with errors.As.
+	Types []string `json:"types,omitempty"`
+
+	// Retryable reports whether WithRetry sends every request failing with
+	// the kind again.
+	Retryable bool `json:"retryable"`
+
+	// Description explains when the error occurs.
+	Description string `json:"description"`
+}
+
+// errorTaxonomy lists every kind of error, in ClassifyError precedence.
+var errorTaxonomy = []ErrorKind{
+	{Code: ErrorCodeCanceled, Sentinel: "context.Canceled, context.DeadlineExceeded", Description: "The context was canceled or its deadline, such as one set with WithCallTimeout, expired."},
+	{Code: ErrorCodeNoToken, Sentinel: "mcp.ErrNoToken", Description: "An operation needing a registry token found none configured."},
+	{Code: ErrorCodeNoActiveVersion, Types: []string{"*mcp.NoActiveVersionError"}, Description: "ResolveVersion matched only deprecated or deleted versions."},
+	{Code: ErrorCodeContract, Types: []string{"*mcp.ContractError"}, Description: "CheckRoundTrip found data lost when decoding a registry response."},
+	{Code: ErrorCodeRateLimited, Status: "429", Sentinel: "mcp.ErrRateLimited", Types: []string{"*mcp.RateLimitError"}, Retryable: true, Description: "The registry rate limit was exceeded; RateLimitError.Rate tells when it resets."},
+	{Code: ErrorCodeBadRequest, Status: "400", Sentinel: "mcp.ErrBadRequest", Types: []string{"*mcp.ErrorResponse"}, Description: "The registry rejected the request parameters."},
+	{Code: ErrorCodeUnauthorized, Status: "401", Sentinel: "mcp.ErrUnauthorized", Types: []string{"*mcp.ErrorResponse"}, Description: "The registry token is missing, invalid or expired."},
+	{Code: ErrorCodeForbidden, Status: "403", Sentinel: "mcp.ErrForbidden", Types: []string{"*mcp.ErrorResponse", "*mcp.ForbiddenError"}, Description: "The registry token does not grant the operation on the server."},
+	{Code: ErrorCodeNotFound, Status: "404", Sentinel: "mcp.ErrNotFound", Types: []string{"*mcp.ErrorResponse", "*mcp.NotFoundError"}, Description: "The server or version does not exist."},
+	{Code: ErrorCodeConflict, Status: "409", Sentinel: "mcp.ErrConflict", Types: []string{"*mcp.ErrorResponse"}, Description: "The version is already published or conflicts with the registry state."},
+	{Code: ErrorCodeInvalid, Status: "422", Sentinel: "mcp.ErrInvalid", Types: []string{"*mcp.ErrorResponse"}, Description: "The registry rejected the server.json; ErrorResponse.Errors lists the invalid fields."},
+	{Code: ErrorCodeServer, Status: "5xx", Sentinel: "mcp.ErrServer", Types: []string{"*mcp.ErrorResponse"}, Retryable: true, Description: "The registry failed to handle the request."},
+	{Code: ErrorCodeOtherStatus, Status: "other", Types: []string{"*mcp.ErrorResponse"}, Description: "The registry answered with another non-2xx status."},
+	{Code: ErrorCodeNetwork, Types: []string{"*url.Error"}, Description: "The request failed without a response, such as on a connection error; WithRetry retries only timeouts, reset or refused connections and connections closed mid-response."},
+	{Code: ErrorCodeOther, Description: "Any other error, such as invalid arguments or an undecodable response body."},
+}
+
+// ErrorTaxonomy returns every kind of error the client returns, keyed by
+// code, so services can build exhaustive error handling and alerting rules.
+// ClassifyError returns the code of an error.
+func ErrorTaxonomy() map[string]ErrorKind {
+	taxonomy := make(map[string]ErrorKind, len(errorTaxonomy))
+	for _, kind := range errorTaxonomy {
+		kind.Types = append([]string(nil), kind.Types...)
+		taxonomy[kind.Code] = kind
+	}
+	return taxonomy
+}
+
+// ErrorTaxonomyJSON returns ErrorTaxonomy as a JSON array sorted by code,
+// for tooling outside Go.
+func ErrorTaxonomyJSON() ([]byte, error) {
+	kinds := make([]ErrorKind, len(errorTaxonomy))
+	copy(kinds, errorTaxonomy)
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i].Code < kinds[j].Code })
+	return json.MarshalIndent(kinds, "", "  ")
+}
+
+// ErrorTaxonomyMarkdown returns ErrorTaxonomy as a Markdown table, for
+// generated documentation.
+func ErrorTaxonomyMarkdown() string {
+	rows := make([][]string, len(errorTaxonomy))
+	for i, kind := range errorTaxonomy {
+		retryable := "no"
+		if kind.Retryable {
+			retryable = "yes"
+		}
+		types := make([]string, len(kind.Types))
+		for j, t := range kind.Types {
+			types[j] = "`" + t + "`"
+		}
+		sentinel := ""
+		if kind.Sentinel != "" {
+			sentinel = "`" + strings.ReplaceAll(kind.Sentinel, ", ", "`, `") + "`"
+		}
+		rows[i] = []string{"`" + kind.Code + "`", kind.Status, sentinel, strings.Join(types, ", "), retryable, kind.Description}
+	}
+	return MarkdownTable([]string{"Code", "Status", "Sentinel", "Types", "Retryable", "Description"}, rows)
+}
+
+// ClassifyError returns the ErrorTaxonomy code of err, or "" if err is nil.
+func ClassifyError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeCanceled
+	case errors.Is(err, ErrNoToken):
+		return ErrorCodeNoToken
+	}
+
+	var noActive *NoActiveVersionError
+	var contract *ContractError
+	switch {
+	case errors.As(err, &noActive):
+		return ErrorCodeNoActiveVersion
+	case errors.As(err, &contract):
+		return ErrorCodeContract
+	}
+
+	sentinels := []struct {
+		err  error
+		code string
+	}{
+		{ErrRateLimited, ErrorCodeRateLimited},
+		{ErrBadRequest, ErrorCodeBadRequest},
+		{ErrUnauthorized, ErrorCodeUnauthorized},
+		{ErrForbidden, ErrorCodeForbidden},
+		{ErrNotFound, ErrorCodeNotFound},
+		{ErrConflict, ErrorCodeConflict},
+		{ErrInvalid, ErrorCodeInvalid},
+		{ErrServer, ErrorCodeServer},
+	}
+	for _, s := range sentinels {
+		if errors.Is(err, s.err) {
+			return s.code
+		}
+	}
+
+	var errResp *ErrorResponse
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &errResp):
+		return ErrorCodeOtherStatus
+	case errors.As(err, &urlErr):
+		return ErrorCodeNetwork
+	}
+	return ErrorCodeOther
+}
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	response := func(code int) *ErrorResponse {
+		return &ErrorResponse{Response: &http.Response{StatusCode: code}}
+	}
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{context.Canceled, ErrorCodeCanceled},
+		{&url.Error{Op: "Get", URL: "https://example.com", Err: context.DeadlineExceeded}, ErrorCodeCanceled},
+		{ErrNoToken, ErrorCodeNoToken},
+		{&NoActiveVersionError{Name: "com.example/a"}, ErrorCodeNoActiveVersion},
+		{&ContractError{}, ErrorCodeContract},
+		{&RateLimitError{Response: &http.Response{StatusCode: http.StatusTooManyRequests}}, ErrorCodeRateLimited},
+		{response(http.StatusBadRequest), ErrorCodeBadRequest},
+		{response(http.StatusUnauthorized), ErrorCodeUnauthorized},
+		{&ForbiddenError{ErrorResponse: response(http.StatusForbidden)}, ErrorCodeForbidden},
+		{&NotFoundError{Name: "com.example/a"}, ErrorCodeNotFound},
+		{fmt.Errorf("wrapped: %w", response(http.StatusNotFound)), ErrorCodeNotFound},
+		{response(http.StatusConflict), ErrorCodeConflict},
+		{response(http.StatusUnprocessableEntity), ErrorCodeInvalid},
+		{response(http.StatusServiceUnavailable), ErrorCodeServer},
+		{response(http.StatusTeapot), ErrorCodeOtherStatus},
+		{&url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("connection refused")}, ErrorCodeNetwork},
+		{errors.New("boom"), ErrorCodeOther},
+	}
+
+	taxonomy := ErrorTaxonomy()
+	for _, tt := range tests {
+		got := ClassifyError(tt.err)
+		if got != tt.want {
+			t.Errorf("ClassifyError(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+		if got != "" {
+			if _, ok := taxonomy[got]; !ok {
+				t.Errorf("code %q is not in ErrorTaxonomy", got)
+			}
+		}
+	}
+}
+
+func TestErrorTaxonomy(t *testing.T) {
+	taxonomy := ErrorTaxonomy()
+	if len(taxonomy) != len(errorTaxonomy) {
+		t.Errorf("ErrorTaxonomy has %d codes, want %d unique codes", len(taxonomy), len(errorTaxonomy))
+	}
+	if kind := taxonomy[ErrorCodeServer]; !kind.Retryable || kind.Status != "5xx" {
+		t.Errorf("server_error = %+v, want retryable 5xx", kind)
+	}
+
+	data, err := ErrorTaxonomyJSON()
+	if err != nil {
+		t.Fatalf("ErrorTaxonomyJSON returned error: %v", err)
+	}
+	var kinds []ErrorKind
+	if err := json.Unmarshal(data, &kinds); err != nil {
+		t.Fatalf("ErrorTaxonomyJSON is not valid JSON: %v", err)
+	}
+	if len(kinds) != len(taxonomy) || kinds[0].Code != ErrorCodeBadRequest {
+		t.Errorf("ErrorTaxonomyJSON = %d kinds starting with %q, want %d starting with bad_request", len(kinds), kinds[0].Code, len(taxonomy))
+	}
+
+	markdown := ErrorTaxonomyMarkdown()
+	if !strings.Contains(markdown, "| `not_found` | 404 | `mcp.ErrNotFound` | `*mcp.ErrorResponse`, `*mcp.NotFoundError` | no |") {
+		t.Errorf("ErrorTaxonomyMarkdown has no not_found row:\n%s", markdown)
+	}
+}
@@ -0,0 +1,254 @@
+package mcp
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// TimelineEntry is a release on a VersionTimeline.
+type TimelineEntry struct {
+	Version     string
+	PublishedAt time.Time
+	Status      model.Status
+
+	// Repository and Packages record where the release says it comes
+	// from; Packages holds "registryType:identifier" of each package.
+	Repository model.Repository
+	Packages   []string
+}
+
+// TimelineGap is the time between two consecutive releases.
+type TimelineGap struct {
+	From, To string // Versions before and after the gap
+	Duration time.Duration
+}
+
+// VersionTimeline is the release history of a server, as returned by
+// ServersService.VersionTimeline.
+type VersionTimeline struct {
+	Name string
+
+	// Entries lists the releases in order of publication.
+	Entries []TimelineEntry
+
+	// Gaps lists the time between consecutive entries; Gaps[i] follows
+	// Entries[i].
+	Gaps []TimelineGap
+
+	// MeanInterval and MedianInterval summarize the release cadence. They
+	// are zero with fewer than two entries.
+	MeanInterval   time.Duration
+	MedianInterval time.Duration
+}
+
+// NewVersionTimeline returns the timeline of the server named name from its
+// registry entries, for example the result of a crawl. Entries of other
+// servers and entries without a publication time are ignored.
+func NewVersionTimeline(name string, entries []registryv0.ServerResponse) *VersionTimeline {
+	t := &VersionTimeline{Name: name}
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Server.Name != name || entry.Meta.Official == nil || entry.Meta.Official.PublishedAt.IsZero() {
+			continue
+		}
+		t.Entries = append(t.Entries, TimelineEntry{
+			Version:     entry.Server.Version,
+			PublishedAt: entry.Meta.Official.PublishedAt,
+			Status:      entryStatus(entry),
+			Repository:  entry.Server.Repository,
+			Packages:    packageKeys(entry.Server.Packages),
+		})
+	}
+	slices.SortStableFunc(t.Entries, func(a, b TimelineEntry) int {
+		return a.PublishedAt.Compare(b.PublishedAt)
+	})
+	if len(t.Entries) < 2 {
+		return t
+	}
+
+	intervals := make([]time.Duration, len(t.Entries)-1)
+	for i := range intervals {
+		from, to := t.Entries[i], t.Entries[i+1]
+		intervals[i] = to.PublishedAt.Sub(from.PublishedAt)
+		t.Gaps = append(t.Gaps, TimelineGap{From: from.Version, To: to.Version, Duration: intervals[i]})
+	}
+	t.MeanInterval = t.Entries[len(t.Entries)-1].PublishedAt.Sub(t.Entries[0].PublishedAt) / time.Duration(len(intervals))
+
+	slices.Sort(intervals)
+	if n := len(intervals); n%2 == 1 {
+		t.MedianInterval = intervals[n/2]
+	} else {
+		t.MedianInterval = (intervals[n/2-1] + intervals[n/2]) / 2
+	}
+	return t
+}
+
+// packageKeys returns the "registryType:identifier" of each of packages.
+func packageKeys(packages []model.Package) []string {
+	var keys []string
+	for _, pkg := range packages {
+		keys = append(keys, pkg.RegistryType+":"+pkg.Identifier)
+	}
+	return keys
+}
+
+// LongestGap returns the longest time between two consecutive releases, or
+// the zero TimelineGap with fewer than two entries.
+func (t *VersionTimeline) LongestGap() TimelineGap {
+	var longest TimelineGap
+	for _, gap := range t.Gaps {
+		if gap.Duration > longest.Duration {
+			longest = gap
+		}
+	}
+	return longest
+}
+
+// VersionTimeline returns the release history of the server named name,
+// with every version the registry lists ordered by publication time.
+func (s *ServersService) VersionTimeline(ctx context.Context, name string, opts ...RequestOption) (*VersionTimeline, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+	params := map[string]string{"serverName": name}
+	req, err := s.client.NewRouteRequest(RouteGetServerVersions, params, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var versions *registryv0.ServerListResponse
+	resp, err := s.client.Do(ctx, req, &versions)
+	if err != nil {
+		return nil, resp, s.notFound(ctx, err, name, "")
+	}
+	if versions == nil {
+		return NewVersionTimeline(name, nil), resp, nil
+	}
+
+	return NewVersionTimeline(name, versions.Servers), resp, nil
+}
+
+// timelineMarks are the ASCII markers of each status.
+var timelineMarks = map[model.Status]byte{
+	model.StatusActive:     '*',
+	model.StatusDeprecated: '~',
+	model.StatusDeleted:    'x',
+}
+
+// WriteASCII writes t to w as a text timeline width columns wide, followed
+// by a list of the releases and a summary of the cadence, for terminal
+// reports. Active releases are marked *, deprecated ones ~ and deleted ones
+// x; # marks a column holding releases with different statuses.
+func (t *VersionTimeline) WriteASCII(w io.Writer, width int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", t.Name)
+	if len(t.Entries) == 0 {
+		b.WriteString("no releases\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	width = max(width, 2)
+	line := []byte(strings.Repeat("-", width))
+	for _, entry := range t.Entries {
+		col := int(t.position(entry.PublishedAt) * float64(width-1))
+		mark := timelineMarks[entry.Status]
+		if mark == 0 {
+			mark = '?'
+		}
+		if line[col] != '-' && line[col] != mark {
+			mark = '#'
+		}
+		line[col] = mark
+	}
+	first, last := t.Entries[0].PublishedAt, t.Entries[len(t.Entries)-1].PublishedAt
+	fmt.Fprintf(&b, "%s |%s| %s\n", first.Format(time.DateOnly), line, last.Format(time.DateOnly))
+
+	for _, entry := range t.Entries {
+		fmt.Fprintf(&b, "  %-20s %s  %s\n", entry.Version, entry.PublishedAt.Format(time.DateOnly), entry.Status)
+	}
+	if len(t.Gaps) > 0 {
+		longest := t.LongestGap()
+		fmt.Fprintf(&b, "mean interval %s, median interval %s, longest gap %s (%s to %s)\n",
+			formatDays(t.MeanInterval), formatDays(t.MedianInterval), formatDays(longest.Duration), longest.From, longest.To)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// timelineColors are the SVG fill colors of each status.
+var timelineColors = map[model.Status]string{
+	model.StatusActive:     "#2e7d32",
+	model.StatusDeprecated: "#f9a825",
+	model.StatusDeleted:    "#c62828",
+}
+
+// WriteSVG writes t to w as a standalone SVG image width pixels wide, with
+// a dot per release colored by status and labeled with its version, for
+// HTML reports. Hovering over a dot shows the release date and status.
+func (t *VersionTimeline) WriteSVG(w io.Writer, width int) error {
+	const (
+		height = 80
+		margin = 40
+		axisY  = 50
+	)
+	width = max(width, 2*margin+1)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="10">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<text x="%d" y="14" font-size="12">%s</text>`+"\n", margin, svgEscape(t.Name))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#9e9e9e"/>`+"\n", margin, axisY, width-margin, axisY)
+
+	for i, entry := range t.Entries {
+		x := margin + t.position(entry.PublishedAt)*float64(width-2*margin)
+		color := timelineColors[entry.Status]
+		if color == "" {
+			color = "#757575"
+		}
+		// Alternate labels above and below the axis so that close
+		// releases stay readable.
+		labelY := axisY - 10
+		if i%2 == 1 {
+			labelY = axisY + 18
+		}
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%d" r="4" fill="%s"><title>%s %s %s</title></circle>`+"\n",
+			x, axisY, color, svgEscape(entry.Version), entry.PublishedAt.Format(time.DateOnly), svgEscape(string(entry.Status)))
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", x, labelY, svgEscape(entry.Version))
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// position returns the position of at between the first and last entries
+// of t, from 0 to 1.
+func (t *VersionTimeline) position(at time.Time) float64 {
+	first, last := t.Entries[0].PublishedAt, t.Entries[len(t.Entries)-1].PublishedAt
+	span := last.Sub(first)
+	if span <= 0 {
+		return 0.5
+	}
+	return float64(at.Sub(first)) / float64(span)
+}
+
+// formatDays formats d as a number of days.
+func formatDays(d time.Duration) string {
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
+
+// svgEscape escapes s for use in SVG text and attributes.
+func svgEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
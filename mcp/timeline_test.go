@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServersService_VersionTimeline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers/com.example%2Fweather/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"com.example/weather","version":"2.0.0"},
+			 "_meta":{"io.modelcontextprotocol.registry/official":{"status":"deprecated","publishedAt":"2025-01-31T00:00:00Z"}}},
+			{"server":{"name":"com.example/weather","version":"1.0.0"},
+			 "_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-01-01T00:00:00Z"}}},
+			{"server":{"name":"com.example/weather","version":"0.1.0"}},
+			{"server":{"name":"com.example/weather","version":"1.1.0"},
+			 "_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","publishedAt":"2025-01-11T00:00:00Z"}}}
+		],"metadata":{}}`)
+	})
+
+	timeline, _, err := client.Servers.VersionTimeline(context.Background(), "com.example/weather")
+	if err != nil {
+		t.Fatalf("VersionTimeline returned error: %v", err)
+	}
+
+	day := 24 * time.Hour
+	want := &VersionTimeline{
+		Name: "com.example/weather",
+		Entries: []TimelineEntry{
+			{Version: "1.0.0", PublishedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Status: model.StatusActive},
+			{Version: "1.1.0", PublishedAt: time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC), Status: model.StatusActive},
+			{Version: "2.0.0", PublishedAt: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), Status: model.StatusDeprecated},
+		},
+		Gaps: []TimelineGap{
+			{From: "1.0.0", To: "1.1.0", Duration: 10 * day},
+			{From: "1.1.0", To: "2.0.0", Duration: 20 * day},
+		},
+		MeanInterval:   15 * day,
+		MedianInterval: 15 * day,
+	}
+	if !reflect.DeepEqual(timeline, want) {
+		t.Errorf("VersionTimeline returned %+v, want %+v", timeline, want)
+	}
+	if got := timeline.LongestGap(); got != want.Gaps[1] {
+		t.Errorf("LongestGap = %+v, want %+v", got, want.Gaps[1])
+	}
+
+	var ascii bytes.Buffer
+	if err := timeline.WriteASCII(&ascii, 7); err != nil {
+		t.Fatalf("WriteASCII returned error: %v", err)
+	}
+	wantASCII := `com.example/weather
+2025-01-01 |*-*---~| 2025-01-31
+  1.0.0                2025-01-01  active
+  1.1.0                2025-01-11  active
+  2.0.0                2025-01-31  deprecated
+mean interval 15.0d, median interval 15.0d, longest gap 20.0d (1.1.0 to 2.0.0)
+`
+	if ascii.String() != wantASCII {
+		t.Errorf("WriteASCII wrote\n%s\nwant\n%s", ascii.String(), wantASCII)
+	}
+
+	var svg bytes.Buffer
+	if err := timeline.WriteSVG(&svg, 400); err != nil {
+		t.Fatalf("WriteSVG returned error: %v", err)
+	}
+	if n := strings.Count(svg.String(), "<circle"); n != 3 {
+		t.Errorf("WriteSVG drew %d releases, want 3", n)
+	}
+	if !strings.HasPrefix(svg.String(), "<svg ") || !strings.HasSuffix(svg.String(), "</svg>\n") {
+		t.Errorf("WriteSVG output is not an SVG document:\n%s", svg.String())
+	}
+}
+
+func TestNewVersionTimeline_Empty(t *testing.T) {
+	timeline := NewVersionTimeline("com.example/none", nil)
+	if timeline.LongestGap() != (TimelineGap{}) || timeline.MeanInterval != 0 {
+		t.Errorf("NewVersionTimeline(nil) = %+v, want no gaps", timeline)
+	}
+
+	var ascii bytes.Buffer
+	if err := timeline.WriteASCII(&ascii, 20); err != nil {
+		t.Fatalf("WriteASCII returned error: %v", err)
+	}
+	if want := "com.example/none\nno releases\n"; ascii.String() != want {
+		t.Errorf("WriteASCII = %q, want %q", ascii.String(), want)
+	}
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// WithTLSConfig returns an Option that uses config for connections to the
+// registry, for example to trust an internal certificate authority. The
+// config replaces any TLS configuration of the client's transport, so apply
+// WithClientCertificate after it.
+//
+// The http.Client passed to NewClient is not modified: the client uses a copy
+// with a cloned transport. The transport must be nil or an *http.Transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) error {
+		if config == nil {
+			return fmt.Errorf("TLS config cannot be nil")
+		}
+		transport, err := c.cloneTransport()
+		if err != nil {
+			return err
+		}
+		transport.TLSClientConfig = config.Clone()
+		return nil
+	}
+}
+
+// WithClientCertificate returns an Option that presents the certificate and
+// private key in the PEM files certFile and keyFile to the registry, for
+// internal registries that require mutual TLS. The certificate is added to
+// the TLS configuration of the client's transport, with the same
+// restrictions as WithTLSConfig.
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(c *Client) error {
+		if certFile == "" || keyFile == "" {
+			return fmt.Errorf("client certificate and key files cannot be empty")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("loading client certificate: %w", err)
+		}
+
+		transport, err := c.cloneTransport()
+		if err != nil {
+			return err
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, cert)
+		return nil
+	}
+}
+
+// cloneTransport replaces the client's http.Client with a copy whose
+// transport is a clone of the original, and returns the clone so it can be
+// configured without affecting the caller's http.Client.
+func (c *Client) cloneTransport() (*http.Transport, error) {
+	var transport *http.Transport
+	switch rt := c.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = rt.Clone()
+	default:
+		return nil, fmt.Errorf("cannot configure transport of type %T", rt)
+	}
+
+	client := *c.client
+	client.Transport = transport
+	c.client = &client
+	return transport, nil
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeClientCertificate writes a self-signed client certificate and its
+// key to PEM files in a temporary directory.
+func writeClientCertificate(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "registry-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	var gotCN string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			gotCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		fmt.Fprint(w, `{"servers":[],"metadata":{"count":0}}`)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	certFile, keyFile := writeClientCertificate(t)
+
+	httpClient := &http.Client{}
+	client, err := NewClient(httpClient,
+		WithBaseURL(srv.URL),
+		WithTLSConfig(&tls.Config{RootCAs: roots}),
+		WithClientCertificate(certFile, keyFile),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if httpClient.Transport != nil {
+		t.Error("NewClient modified the caller's http.Client")
+	}
+
+	if _, _, err := client.Servers.List(context.Background(), nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if gotCN != "registry-client" {
+		t.Errorf("server saw client certificate %q, want registry-client", gotCN)
+	}
+
+	// Without a certificate the handshake is rejected
+	client, err = NewClient(nil, WithBaseURL(srv.URL), WithTLSConfig(&tls.Config{RootCAs: roots}))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if _, _, err := client.Servers.List(context.Background(), nil); err == nil {
+		t.Error("List without client certificate returned no error")
+	}
+}
+
+type customTransport struct{}
+
+func (customTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestWithTLSConfig_Errors(t *testing.T) {
+	tests := map[string]struct {
+		httpClient *http.Client
+		opt        Option
+	}{
+		"nil config":       {nil, WithTLSConfig(nil)},
+		"empty files":      {nil, WithClientCertificate("", "")},
+		"missing files":    {nil, WithClientCertificate("testdata/missing.crt", "testdata/missing.key")},
+		"custom transport": {&http.Client{Transport: customTransport{}}, WithTLSConfig(&tls.Config{})},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient(tt.httpClient, tt.opt); err == nil {
+				t.Error("NewClient returned no error")
+			}
+		})
+	}
+}
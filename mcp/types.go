@@ -1,16 +1,17 @@
 package mcp
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Client manages communication with the MCP Registry API.
 type Client struct {
-	clientMu sync.Mutex   // protects the client during calls
-	client   *http.Client // HTTP client used to communicate with the API
+	client *http.Client // HTTP client used to communicate with the API
 
 	// Base URL for API requests.
 	// Defaults to https://registry.modelcontextprotocol.io, but can be
@@ -20,14 +21,116 @@ type Client struct {
 	// User agent used when communicating with the MCP Registry API.
 	UserAgent string
 
+	// Source of the Bearer token sent with every request, if configured
+	// with WithAuthToken or WithTokenSource
+	tokenSource TokenSource
+
+	// Header and value prefix tokens are sent with, set by WithAuthScheme
+	authHeader string
+	authPrefix string
+
+	// Gateway API key sent with every request, if configured with WithAPIKey
+	apiKeyHeader string
+	apiKey       string
+
+	// Unix domain socket dialed by the transport, if configured with a
+	// unix base URL
+	socketPath string
+
+	// Reverse-proxy credentials, if configured with WithBasicAuth
+	basicUser     string
+	basicPassword string
+
+	// Per-host credentials applied by NewClient, if configured with
+	// WithCredentials
+	credentials CredentialResolver
+
+	// Accept-Encoding sent with every request, if configured with
+	// WithCompression
+	acceptEncoding string
+
+	// Destination of request and response dumps, if configured with
+	// WithDebug or SetDebug
+	debug atomic.Pointer[debugWriter]
+
+	// Transport wrappers applied by NewClient, if configured with
+	// WithMiddleware
+	middleware []Middleware
+
+	// API version path prefix, set with WithAPIVersion, and endpoint route
+	// table
+	apiVersion string
+	routes     map[string]Route
+
 	common service // Reuse a single struct instead of allocating one for each service
 
 	// Services used for talking to different parts of the MCP Registry API
 	Servers *ServersService
+	Auth    *AuthService
+
+	// Admin is only available on clients created with WithAdmin
+	Admin *AdminService
 
 	// Rate limit tracking
 	rateMu     sync.Mutex
 	rateLimits map[string]Rate
+
+	// Source of time, replaceable with WithClock
+	clock Clock
+
+	// Shared request budget, if configured with WithRateBudget
+	rateBudget *RateBudget
+
+	// Retry behavior for failed idempotent requests, if configured with
+	// WithRetry
+	retry *retryPolicy
+
+	// Recent list pages, if configured with WithListCache
+	listCache *listCache
+
+	// Recent version lookups, if configured with WithVersionCache
+	versionCache *listCache
+
+	// How ServersService lookups report missing servers, set with
+	// WithNotFoundPolicy
+	notFoundPolicy NotFoundPolicy
+
+	// Number of suggestions attached to NotFoundError, set with
+	// WithNotFoundSuggestions
+	notFoundSuggestions int
+
+	// Whether responses with unknown fields are rejected, set with
+	// WithStrictDecoding
+	strictDecoding bool
+
+	// Whether response bodies are kept in Response.RawJSON, set with
+	// WithRawJSON
+	rawJSON bool
+
+	// Forecasts rate limit exhaustion, set with WithRateForecast
+	rateForecaster *rateForecaster
+
+	// Endpoints reads are spread over, set with WithEndpoints
+	endpoints *endpointSelector
+
+	// Headers added to every request, if configured with WithDefaultHeaders
+	defaultHeaders http.Header
+
+	// Hook called after each request, if configured with WithAuditHook
+	auditHook AuditHook
+
+	// Client-side search, if enabled with WithSearchFallback, and whether
+	// the registry has been found to reject the search parameter
+	searchFallback    bool
+	searchUnsupported atomic.Bool
+
+	// Name matching for the name-based helpers, if configured with
+	// WithNameMatcher
+	nameMatcher NameMatcher
+
+	// Known renames followed by version resolution, if configured with
+	// WithAliases
+	aliases Aliases
 }
 
 // service provides a general service interface for the API.
@@ -41,6 +144,17 @@ type service struct {
 // MCP Registry API docs: https://registry.modelcontextprotocol.io/docs
 type ServersService service
 
+// AuthService provides information about the credentials the client
+// authenticates with.
+type AuthService service
+
+// AdminService handles communication with the moderation endpoints of
+// self-hosted registries running the official registry server. It is only
+// available on clients created with the WithAdmin option.
+//
+// MCP Registry API docs: https://registry.modelcontextprotocol.io/docs#/operations/edit-server
+type AdminService service
+
 // Response wraps the standard http.Response and provides convenient access to
 // pagination and rate limit information.
 type Response struct {
@@ -51,6 +165,27 @@ type Response struct {
 
 	// Rate limiting information
 	Rate Rate
+
+	// RequestID is the identifier the registry, or a proxy in front of
+	// it, assigned to the request, taken from the X-Request-Id header or a
+	// tracing header such as traceparent. Quote it when reporting registry
+	// problems. It is empty if the response carries none.
+	RequestID string
+
+	// SearchFallback reports whether ServersService.List filtered servers
+	// client-side because the registry rejected the search parameter. See
+	// WithSearchFallback.
+	SearchFallback bool
+
+	// RawJSON is the body of the response if the client is configured with
+	// WithRawJSON. It is shared with cached copies of the response and must
+	// not be modified.
+	RawJSON json.RawMessage
+
+	// Cached reports whether the response was served from the cache
+	// configured with WithListCache or WithVersionCache, without
+	// contacting the registry.
+	Cached bool
 }
 
 // Rate represents the rate limit information returned in API responses.
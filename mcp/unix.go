@@ -0,0 +1,24 @@
+package mcp
+
+import (
+	"context"
+	"net"
+)
+
+// dialSocket configures the client's transport to connect to the Unix
+// domain socket set by WithBaseURL, whatever the address of the request.
+func (c *Client) dialSocket() error {
+	transport, err := c.cloneTransport()
+	if err != nil {
+		return err
+	}
+
+	socketPath := c.socketPath
+	var dialer net.Dialer
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", socketPath)
+	}
+	// Proxies from the environment cannot reach a local socket.
+	transport.Proxy = nil
+	return nil
+}
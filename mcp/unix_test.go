@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithBaseURL_UnixSocket(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, so avoid t.TempDir,
+	// whose path includes the test name.
+	dir, err := os.MkdirTemp("", "mcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "registry.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix domain sockets are not supported: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "localhost" {
+			t.Errorf("Host = %q, want localhost", r.Host)
+		}
+		fmt.Fprint(w, `{"servers":[{"server":{"name":"com.example/weather","version":"1.0.0"}}],"metadata":{}}`)
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	client, err := NewClient(nil, WithBaseURL("unix://"+socket))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if got, want := client.BaseURL.String(), "http://localhost/"; got != want {
+		t.Errorf("BaseURL = %s, want %s", got, want)
+	}
+
+	list, _, err := client.Servers.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(list.Servers) != 1 || list.Servers[0].Server.Name != "com.example/weather" {
+		t.Errorf("List returned %+v, want com.example/weather", list.Servers)
+	}
+}
+
+func TestWithBaseURL_UnixSocketErrors(t *testing.T) {
+	if _, err := NewClient(nil, WithBaseURL("unix://")); err == nil {
+		t.Error("NewClient with a unix base URL without socket path returned no error")
+	}
+
+	custom := &http.Client{Transport: RoundTripperFunc(http.DefaultTransport.RoundTrip)}
+	if _, err := NewClient(custom, WithBaseURL("unix:///var/run/registry.sock")); err == nil {
+		t.Error("NewClient with a unix base URL and a custom transport returned no error")
+	}
+
+	// A later base URL replaces the socket.
+	client, err := NewClient(nil, WithBaseURL("unix:///var/run/registry.sock"), WithBaseURL("https://registry.example.com"))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if client.client.Transport != nil {
+		t.Errorf("transport = %T, want the default transport", client.client.Transport)
+	}
+}
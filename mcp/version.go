@@ -0,0 +1,30 @@
+package mcp
+
+import (
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// parseVersion parses a server version for comparison. It accepts the forms
+// found in real registry entries besides strict semantic versions: a leading
+// "v" or "V", surrounding whitespace, missing minor or patch numbers, and
+// build metadata such as "+build.5".
+func parseVersion(s string) (*semver.Version, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "V") {
+		s = "v" + s[1:]
+	}
+	return semver.NewVersion(s)
+}
+
+// versionGreater reports whether a is a newer version than b. Build metadata
+// does not affect precedence, so versions that differ only in build metadata
+// are ordered by their metadata strings, making the result independent of
+// the order in which the registry lists them.
+func versionGreater(a, b *semver.Version) bool {
+	if c := a.Compare(b); c != 0 {
+		return c > 0
+	}
+	return a.Metadata() > b.Metadata()
+}
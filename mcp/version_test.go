@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{"V1.2.3", "1.2.3"},
+		{"1.2.3+build.5", "1.2.3+build.5"},
+		{"v1.2.3+20250101", "1.2.3+20250101"},
+		{"1.2.3-beta.1+exp.sha.5114f85", "1.2.3-beta.1+exp.sha.5114f85"},
+		{" 1.2.3\n", "1.2.3"},
+		{"1.2", "1.2.0"},
+		{"1", "1.0.0"},
+	}
+
+	for _, tt := range tests {
+		v, err := parseVersion(tt.version)
+		if err != nil {
+			t.Errorf("parseVersion(%q) returned error: %v", tt.version, err)
+			continue
+		}
+		if got := v.String(); got != tt.want {
+			t.Errorf("parseVersion(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+
+	for _, version := range []string{"", "latest", "1.2.3.4", "release-1.2.3", "v.1.2.3"} {
+		if _, err := parseVersion(version); err == nil {
+			t.Errorf("parseVersion(%q) returned nil error", version)
+		}
+	}
+}
+
+func TestVersionGreater(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"1.2.4", "1.2.3", true},
+		{"1.2.3", "v1.2.4", false},
+		{"1.2.3", "1.2.3-rc.1", true},
+		{"1.2.3+build.2", "1.2.3+build.1", true},
+		{"1.2.3+build.1", "1.2.3+build.2", false},
+		{"1.2.3+build.1", "1.2.3", true},
+		{"1.2.3", "1.2.3", false},
+	}
+
+	for _, tt := range tests {
+		a, _ := parseVersion(tt.a)
+		b, _ := parseVersion(tt.b)
+		if got := versionGreater(a, b); got != tt.want {
+			t.Errorf("versionGreater(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestServersService_GetByNameLatestActiveVersion_MessyVersions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"servers":[
+			{"server":{"name":"com.example/weather","version":"v1.9.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+			{"server":{"name":"com.example/weather","version":"V2.0.0+build.1"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+			{"server":{"name":"com.example/weather","version":"2.0.0+build.2"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}},
+			{"server":{"name":"com.example/weather","version":"1.10"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active"}}}
+		],"metadata":{"count":4}}`)
+	})
+
+	server, _, err := client.Servers.GetByNameLatestActiveVersion(context.Background(), "com.example/weather")
+	if err != nil {
+		t.Fatalf("GetByNameLatestActiveVersion returned error: %v", err)
+	}
+	if server == nil || server.Version != "2.0.0+build.2" {
+		t.Errorf("GetByNameLatestActiveVersion = %+v, want version 2.0.0+build.2", server)
+	}
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// warmupWorkers is the number of servers Warmup fetches concurrently.
+const warmupWorkers = 8
+
+// Warmup fetches the latest version and the version list of each named
+// server in parallel, so that the first requests of an embedding service
+// are served from the caches configured with WithVersionCache and
+// WithListCache. It is meant to run at startup for the servers the service
+// is known to use. With only the list cache configured, it primes the
+// lookups of GetByNameLatest.
+//
+// Every server is fetched even if others fail; the errors are returned
+// joined together. Warmup returns an error without sending requests if the
+// client has neither cache.
+func (c *Client) Warmup(ctx context.Context, names []string) error {
+	if c.versionCache == nil && c.listCache == nil {
+		return fmt.Errorf("warmup requires WithVersionCache or WithListCache")
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, warmupWorkers)
+	)
+	for _, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := c.warmup(ctx, name); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("warming up %s: %w", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// warmup refreshes the cached lookups of the server named name.
+func (c *Client) warmup(ctx context.Context, name string) error {
+	if c.versionCache != nil {
+		if _, _, err := c.Servers.Get(ctx, name, nil, WithNoCache()); err != nil {
+			return err
+		}
+		if _, _, err := c.Servers.ListVersionsByName(ctx, name, WithNoCache()); err != nil {
+			return err
+		}
+	}
+	if c.listCache != nil {
+		if _, _, err := c.Servers.GetByNameLatest(ctx, name, WithNoCache()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Warmup(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	if err := WithVersionCache(time.Hour)(client); err != nil {
+		t.Fatalf("WithVersionCache returned error: %v", err)
+	}
+
+	var requests atomic.Int32
+	for _, name := range []string{"a", "b"} {
+		mux.HandleFunc(fmt.Sprintf("/v0.1/servers/com.example%%2F%s/versions/latest", name), func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			fmt.Fprintf(w, `{"server":{"name":"com.example/%s","version":"1.0.0"}}`, name)
+		})
+		mux.HandleFunc(fmt.Sprintf("/v0.1/servers/com.example%%2F%s/versions", name), func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			fmt.Fprintf(w, `{"servers":[{"server":{"name":"com.example/%s","version":"1.0.0"}}],"metadata":{}}`, name)
+		})
+	}
+
+	ctx := context.Background()
+	if err := client.Warmup(ctx, []string{"com.example/a", "com.example/b"}); err != nil {
+		t.Fatalf("Warmup returned error: %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Warmup sent %d requests, want 4", got)
+	}
+
+	if _, resp, err := client.Servers.Get(ctx, "com.example/a", nil); err != nil || !resp.Cached {
+		t.Errorf("Get after Warmup: err %v, Cached %v; want cached", err, resp != nil && resp.Cached)
+	}
+	if _, resp, err := client.Servers.ListVersionsByName(ctx, "com.example/b"); err != nil || !resp.Cached {
+		t.Errorf("ListVersionsByName after Warmup: err %v, Cached %v; want cached", err, resp != nil && resp.Cached)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("server received %d requests after Warmup, want 4", got)
+	}
+
+	err := client.Warmup(ctx, []string{"com.example/a", "com.example/missing"})
+	if err == nil || !strings.Contains(err.Error(), "com.example/missing") {
+		t.Errorf("Warmup with missing server error = %v, want error naming it", err)
+	}
+}
+
+func TestClient_Warmup_NoCache(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if err := client.Warmup(context.Background(), []string{"com.example/a"}); err == nil {
+		t.Error("Warmup without caches returned nil error")
+	}
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNoToken is returned by AuthService.WhoAmI when no registry token is
+// configured for the request context.
+var ErrNoToken = errors.New("no registry token configured")
+
+// Permission actions granted by registry tokens.
+const (
+	PermissionPublish = "publish"
+	PermissionEdit    = "edit"
+)
+
+// Permission is an action a registry token allows on the server names
+// matching Resource.
+type Permission struct {
+	// Action is PermissionPublish or PermissionEdit.
+	Action string `json:"action"`
+
+	// Resource is a server name pattern, e.g. "io.github.octocat/*". A
+	// trailing "*" matches any suffix, and "*" alone matches every name.
+	Resource string `json:"resource"`
+}
+
+// Allows reports whether p grants action on the server named name.
+func (p Permission) Allows(action, name string) bool {
+	if p.Action != action {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(p.Resource, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return name == p.Resource
+}
+
+// Identity describes the holder of a registry token.
+type Identity struct {
+	// Method is how the token was obtained, e.g. "github-oidc" or "dns".
+	Method string
+
+	// Subject identifies the holder within Method, e.g. a GitHub user name
+	// or a domain.
+	Subject string
+
+	// Permissions lists what the token allows.
+	Permissions []Permission
+
+	// ExpiresAt is when the token expires.
+	ExpiresAt time.Time
+}
+
+// Namespaces returns the server name patterns the identity may publish to.
+func (id *Identity) Namespaces() []string {
+	var namespaces []string
+	for _, p := range id.Permissions {
+		if p.Action == PermissionPublish {
+			namespaces = append(namespaces, p.Resource)
+		}
+	}
+	return namespaces
+}
+
+// CanPublish reports whether the identity may publish the server named name.
+func (id *Identity) CanPublish(name string) bool {
+	for _, p := range id.Permissions {
+		if p.Allows(PermissionPublish, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// registryClaims are the claims of a registry token.
+type registryClaims struct {
+	AuthMethod        string       `json:"auth_method"`
+	AuthMethodSubject string       `json:"auth_method_sub"`
+	Permissions       []Permission `json:"permissions"`
+	ExpiresAt         int64        `json:"exp"`
+}
+
+// WhoAmI returns the identity of the registry token requests with ctx are
+// authenticated with, following the precedence rules of ContextWithToken, so
+// tooling can check credentials before attempting a publish. It returns
+// ErrNoToken if there is no token.
+//
+// The registry has no introspection endpoint, so the token's claims are
+// decoded locally. Its signature is not verified: the result describes what
+// the token claims, and the registry remains the authority on whether it is
+// accepted.
+func (s *AuthService) WhoAmI(ctx context.Context, opts ...RequestOption) (*Identity, error) {
+	ctx, cancel := withRequestOptions(ctx, opts)
+	defer cancel()
+	token, _, err := s.client.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, ErrNoToken
+	}
+
+	return ParseIdentity(token)
+}
+
+// ParseIdentity decodes the claims of a registry token without verifying
+// its signature.
+func ParseIdentity(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("registry token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding registry token claims: %w", err)
+	}
+
+	var claims registryClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("decoding registry token claims: %w", err)
+	}
+
+	id := &Identity{
+		Method:      claims.AuthMethod,
+		Subject:     claims.AuthMethodSubject,
+		Permissions: claims.Permissions,
+	}
+	if claims.ExpiresAt != 0 {
+		id.ExpiresAt = time.Unix(claims.ExpiresAt, 0)
+	}
+
+	return id, nil
+}
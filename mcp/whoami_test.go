@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testRegistryToken returns an unsigned JWT carrying claims.
+func testRegistryToken(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"EdDSA","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".c2ln"
+}
+
+func TestAuthService_WhoAmI(t *testing.T) {
+	token := testRegistryToken(`{"auth_method":"github-oidc","auth_method_sub":"octocat","permissions":[{"action":"publish","resource":"io.github.octocat/*"},{"action":"edit","resource":"io.github.octocat/tools"}],"exp":1735689600}`)
+
+	client, _, _, teardown := setup()
+	defer teardown()
+	if err := WithAuthToken(token)(client); err != nil {
+		t.Fatalf("WithAuthToken returned error: %v", err)
+	}
+
+	id, err := client.Auth.WhoAmI(context.Background())
+	if err != nil {
+		t.Fatalf("WhoAmI returned error: %v", err)
+	}
+
+	want := &Identity{
+		Method:  "github-oidc",
+		Subject: "octocat",
+		Permissions: []Permission{
+			{Action: PermissionPublish, Resource: "io.github.octocat/*"},
+			{Action: PermissionEdit, Resource: "io.github.octocat/tools"},
+		},
+		ExpiresAt: time.Unix(1735689600, 0),
+	}
+	if !reflect.DeepEqual(id, want) {
+		t.Errorf("WhoAmI = %+v, want %+v", id, want)
+	}
+
+	if got := id.Namespaces(); !reflect.DeepEqual(got, []string{"io.github.octocat/*"}) {
+		t.Errorf("Namespaces = %v, want [io.github.octocat/*]", got)
+	}
+	if !id.CanPublish("io.github.octocat/weather") {
+		t.Error("CanPublish(io.github.octocat/weather) = false, want true")
+	}
+	if id.CanPublish("io.github.other/weather") {
+		t.Error("CanPublish(io.github.other/weather) = true, want false")
+	}
+}
+
+func TestAuthService_WhoAmI_ContextToken(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+	if err := WithAuthToken(testRegistryToken(`{"auth_method_sub":"client"}`))(client); err != nil {
+		t.Fatalf("WithAuthToken returned error: %v", err)
+	}
+
+	ctx := ContextWithToken(context.Background(), testRegistryToken(`{"auth_method_sub":"tenant"}`))
+	id, err := client.Auth.WhoAmI(ctx)
+	if err != nil {
+		t.Fatalf("WhoAmI returned error: %v", err)
+	}
+	if id.Subject != "tenant" {
+		t.Errorf("Subject = %q, want the context token's subject", id.Subject)
+	}
+
+	ctx = ContextWithToken(context.Background(), "")
+	if _, err := client.Auth.WhoAmI(ctx); !errors.Is(err, ErrNoToken) {
+		t.Errorf("WhoAmI with empty context token error = %v, want ErrNoToken", err)
+	}
+}
+
+func TestAuthService_WhoAmI_NoToken(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if _, err := client.Auth.WhoAmI(context.Background()); !errors.Is(err, ErrNoToken) {
+		t.Errorf("WhoAmI error = %v, want ErrNoToken", err)
+	}
+}
+
+func TestParseIdentity_Invalid(t *testing.T) {
+	for _, token := range []string{"opaque-token", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("[]")) + ".c"} {
+		if _, err := ParseIdentity(token); err == nil {
+			t.Errorf("ParseIdentity(%q) returned nil error", token)
+		}
+	}
+}
+
+func TestPermission_Allows(t *testing.T) {
+	tests := []struct {
+		perm   Permission
+		action string
+		name   string
+		want   bool
+	}{
+		{Permission{PermissionPublish, "*"}, PermissionPublish, "com.example/server", true},
+		{Permission{PermissionPublish, "com.example/*"}, PermissionPublish, "com.example/server", true},
+		{Permission{PermissionPublish, "com.example/*"}, PermissionEdit, "com.example/server", false},
+		{Permission{PermissionEdit, "com.example/server"}, PermissionEdit, "com.example/server", true},
+		{Permission{PermissionEdit, "com.example/server"}, PermissionEdit, "com.example/server2", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.perm.Allows(tt.action, tt.name); got != tt.want {
+			t.Errorf("%+v.Allows(%q, %q) = %v, want %v", tt.perm, tt.action, tt.name, got, tt.want)
+		}
+	}
+}
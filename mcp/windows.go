@@ -0,0 +1,219 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	registryv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListByUpdatedBetween retrieves all servers last updated at or after from
+// and before to, handling pagination automatically. Servers without registry
+// metadata are omitted, since their update time is unknown.
+//
+// The registry only filters by a lower bound, so servers updated after to are
+// still transferred and dropped client-side.
+func (s *ServersService) ListByUpdatedBetween(ctx context.Context, from, to time.Time, reqOpts ...RequestOption) ([]registryv0.ServerJSON, *Response, error) {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
+	// updated_since has second precision and is exclusive, so ask for a
+	// second earlier and filter precisely below
+	since := from.Truncate(time.Second).Add(-time.Second)
+	opts := &ServerListOptions{
+		UpdatedSince: &since,
+		ListOptions: ListOptions{
+			Limit: 100,
+		},
+	}
+
+	var servers []registryv0.ServerJSON
+	resp, err := s.listPages(ctx, opts, func(entry *registryv0.ServerResponse) {
+		if entry.Meta.Official == nil {
+			return
+		}
+		updated := entry.Meta.Official.UpdatedAt
+		if !updated.Before(from) && updated.Before(to) {
+			servers = append(servers, entry.Server)
+		}
+	})
+	if err != nil {
+		return servers, resp, err
+	}
+
+	return servers, resp, nil
+}
+
+// UpdateWindow is the half-open time range [From, To) of a windowed crawl.
+type UpdateWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// SplitUpdateWindows slices [from, to) into consecutive windows of size, the
+// last of which may be shorter. It returns nil if to is not after from or
+// size is not positive.
+func SplitUpdateWindows(from, to time.Time, size time.Duration) []UpdateWindow {
+	if !to.After(from) || size <= 0 {
+		return nil
+	}
+
+	var windows []UpdateWindow
+	for start := from; start.Before(to); start = start.Add(size) {
+		end := start.Add(size)
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, UpdateWindow{From: start, To: end})
+	}
+	return windows
+}
+
+// UpdateWindowCrawlOptions specifies the parameters to the
+// ServersService.CrawlByUpdateWindow method.
+type UpdateWindowCrawlOptions struct {
+	// From and To bound the update times to crawl. From is required, and
+	// the range must be shorter than about 290 years. To defaults to the
+	// current time of the client's clock.
+	From time.Time
+	To   time.Time
+
+	// Window is the length of each window. It must be positive.
+	Window time.Duration
+
+	// Cursor resumes an interrupted crawl after the page whose
+	// UpdateWindowBatch.Cursor it is. Empty starts from the first page.
+	Cursor string
+
+	// PageSize is the number of servers requested per page. Defaults to
+	// 100.
+	PageSize int
+}
+
+// UpdateWindowServers are the servers of a windowed crawl updated within
+// Window.
+type UpdateWindowServers struct {
+	Window  UpdateWindow
+	Servers []registryv0.ServerJSON
+}
+
+// UpdateWindowBatch is a page of a windowed crawl.
+type UpdateWindowBatch struct {
+	// Windows holds the servers of the page by update window, in window
+	// order. Windows without servers in the page are omitted.
+	Windows []UpdateWindowServers
+
+	// Cursor is the registry's "name:version" cursor after the page.
+	// Storing it as a checkpoint and passing it as
+	// UpdateWindowCrawlOptions.Cursor resumes the crawl after the page.
+	Cursor string
+}
+
+// CrawlByUpdateWindow syncs the servers updated between opts.From and
+// opts.To, bucketed into windows of opts.Window. It lists the servers
+// updated since opts.From in a single paginated pass and passes each page
+// to fn, with its servers grouped by window and the cursor to resume after
+// it, so huge syncs can checkpoint after every page.
+//
+// The registry filters by a lower bound only and orders servers by name,
+// so each window is complete only once the crawl finishes, and servers
+// updated after opts.To are transferred and dropped client-side. Servers
+// without registry metadata are omitted, since their update time is
+// unknown.
+//
+// The registry has no upper bound for updated_since, so windows cannot be
+// listed separately and the crawl cannot be parallelized; pages are fetched
+// one after another.
+//
+// The crawl stops at the first error, from a fetch or from fn, and returns
+// it. fn is not called for the page that failed.
+func (s *ServersService) CrawlByUpdateWindow(ctx context.Context, opts *UpdateWindowCrawlOptions, fn func(UpdateWindowBatch) error, reqOpts ...RequestOption) error {
+	ctx, cancel := withRequestOptions(ctx, reqOpts)
+	defer cancel()
+	if opts == nil || opts.Window <= 0 {
+		return fmt.Errorf("crawl window must be positive")
+	}
+	if opts.From.IsZero() {
+		return fmt.Errorf("crawl start must be set")
+	}
+	to := opts.To
+	if to.IsZero() {
+		to = s.client.clock.Now()
+	}
+	// Sub saturates for ranges that do not fit a Duration
+	if !opts.From.Add(to.Sub(opts.From)).Equal(to) {
+		return fmt.Errorf("crawl range from %v to %v is too long", opts.From, to)
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	// updated_since has second precision and is exclusive, so ask for a
+	// second earlier and filter precisely below
+	since := opts.From.Truncate(time.Second).Add(-time.Second)
+	listOpts := &ServerListOptions{
+		UpdatedSince: &since,
+		ListOptions: ListOptions{
+			Cursor: opts.Cursor,
+			Limit:  pageSize,
+		},
+	}
+
+	for {
+		page, _, err := s.List(ctx, listOpts)
+		if err != nil {
+			return fmt.Errorf("crawling after cursor %q: %w", listOpts.Cursor, err)
+		}
+
+		batch := UpdateWindowBatch{Windows: bucketUpdateWindows(page.Servers, opts.From, to, opts.Window)}
+		if n := len(page.Servers); n > 0 && page.Metadata.NextCursor != "" {
+			last := page.Servers[n-1].Server
+			batch.Cursor = last.Name + ":" + last.Version
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+
+		if batch.Cursor == "" {
+			return nil
+		}
+		listOpts.Cursor = batch.Cursor
+	}
+}
+
+// bucketUpdateWindows groups the servers of entries updated within [from,
+// to) by their window of length size, in window order. Only windows with
+// servers are created.
+func bucketUpdateWindows(entries []registryv0.ServerResponse, from, to time.Time, size time.Duration) []UpdateWindowServers {
+	var grouped []UpdateWindowServers
+	positions := make(map[time.Duration]int) // window index to position in grouped
+	for _, entry := range entries {
+		if entry.Meta.Official == nil {
+			continue
+		}
+		updated := entry.Meta.Official.UpdatedAt
+		if updated.Before(from) || !updated.Before(to) {
+			continue
+		}
+		i := updated.Sub(from) / size
+		pos, ok := positions[i]
+		if !ok {
+			start := from.Add(i * size)
+			end := start.Add(size)
+			if end.After(to) {
+				end = to
+			}
+			pos = len(grouped)
+			positions[i] = pos
+			grouped = append(grouped, UpdateWindowServers{Window: UpdateWindow{From: start, To: end}})
+		}
+		grouped[pos].Servers = append(grouped[pos].Servers, entry.Server)
+	}
+
+	slices.SortFunc(grouped, func(a, b UpdateWindowServers) int {
+		return a.Window.From.Compare(b.Window.From)
+	})
+	return grouped
+}
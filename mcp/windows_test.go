@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var windowsStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// handleUpdatedServers serves one server per day of January 2025, updated
+// at noon, filtered by updated_since and paginated like the registry. It
+// returns the number of requests served.
+func handleUpdatedServers(t *testing.T, mux *http.ServeMux) *int {
+	t.Helper()
+	requests := 0
+	mux.HandleFunc("/v0.1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		since, err := time.Parse(time.RFC3339, r.URL.Query().Get("updated_since"))
+		if err != nil {
+			t.Errorf("updated_since = %q, want RFC 3339 time", r.URL.Query().Get("updated_since"))
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		cursor := r.URL.Query().Get("cursor")
+
+		var entries []string
+		next := ""
+		for day := 1; day <= 31; day++ {
+			updated := time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC)
+			name := fmt.Sprintf("com.example/day-%02d", day)
+			if !updated.After(since) || (cursor != "" && name+":1.0.0" <= cursor) {
+				continue
+			}
+			if limit > 0 && len(entries) == limit {
+				next = fmt.Sprintf("com.example/day-%02d:1.0.0", day-1)
+				break
+			}
+			entries = append(entries, fmt.Sprintf(`{"server":{"name":%q,"version":"1.0.0"},"_meta":{"io.modelcontextprotocol.registry/official":{"status":"active","updatedAt":%q}}}`,
+				name, updated.Format(time.RFC3339)))
+		}
+		fmt.Fprintf(w, `{"servers":[%s],"metadata":{"nextCursor":%q}}`, strings.Join(entries, ","), next)
+	})
+	return &requests
+}
+
+func TestServersService_ListByUpdatedBetween(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleUpdatedServers(t, mux)
+
+	from := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	servers, _, err := client.Servers.ListByUpdatedBetween(context.Background(), from, from.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("ListByUpdatedBetween returned error: %v", err)
+	}
+
+	var names []string
+	for _, s := range servers {
+		names = append(names, s.Name)
+	}
+	if want := []string{"com.example/day-02", "com.example/day-03"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListByUpdatedBetween = %v, want %v", names, want)
+	}
+}
+
+func TestSplitUpdateWindows(t *testing.T) {
+	got := SplitUpdateWindows(windowsStart, windowsStart.Add(50*time.Hour), 24*time.Hour)
+	want := []UpdateWindow{
+		{windowsStart, windowsStart.Add(24 * time.Hour)},
+		{windowsStart.Add(24 * time.Hour), windowsStart.Add(48 * time.Hour)},
+		{windowsStart.Add(48 * time.Hour), windowsStart.Add(50 * time.Hour)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitUpdateWindows = %v, want %v", got, want)
+	}
+
+	if got := SplitUpdateWindows(windowsStart, windowsStart, time.Hour); got != nil {
+		t.Errorf("empty range: SplitUpdateWindows = %v, want nil", got)
+	}
+}
+
+func TestServersService_CrawlByUpdateWindow(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	requests := handleUpdatedServers(t, mux)
+
+	got := make(map[UpdateWindow][]string)
+	var cursors []string
+	opts := &UpdateWindowCrawlOptions{
+		From:     windowsStart,
+		To:       windowsStart.AddDate(0, 0, 10),
+		Window:   72 * time.Hour,
+		PageSize: 4,
+	}
+	err := client.Servers.CrawlByUpdateWindow(context.Background(), opts, func(batch UpdateWindowBatch) error {
+		cursors = append(cursors, batch.Cursor)
+		for _, w := range batch.Windows {
+			for _, s := range w.Servers {
+				got[w.Window] = append(got[w.Window], s.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("CrawlByUpdateWindow returned error: %v", err)
+	}
+
+	// The 31 servers updated since From are listed once, in 8 pages of 4.
+	if *requests != 8 {
+		t.Errorf("sent %d requests, want 8", *requests)
+	}
+	if want := []string{"com.example/day-04:1.0.0", "com.example/day-08:1.0.0"}; !reflect.DeepEqual(cursors[:2], want) || cursors[7] != "" {
+		t.Errorf("cursors = %q, want checkpoints after each page and none after the last", cursors)
+	}
+	windows := SplitUpdateWindows(opts.From, opts.To, opts.Window)
+	want := map[UpdateWindow][]string{
+		windows[0]: {"com.example/day-01", "com.example/day-02", "com.example/day-03"},
+		windows[1]: {"com.example/day-04", "com.example/day-05", "com.example/day-06"},
+		windows[2]: {"com.example/day-07", "com.example/day-08", "com.example/day-09"},
+		windows[3]: {"com.example/day-10"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("crawled windows = %v, want %v", got, want)
+	}
+
+	// Resuming from a checkpoint lists only the remaining pages.
+	*requests = 0
+	var names []string
+	opts.Cursor = cursors[1]
+	err = client.Servers.CrawlByUpdateWindow(context.Background(), opts, func(batch UpdateWindowBatch) error {
+		for _, w := range batch.Windows {
+			for _, s := range w.Servers {
+				names = append(names, s.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("resumed CrawlByUpdateWindow returned error: %v", err)
+	}
+	if *requests != 6 || !reflect.DeepEqual(names, []string{"com.example/day-09", "com.example/day-10"}) {
+		t.Errorf("resumed crawl sent %d requests for %v, want 6 for days 9 and 10", *requests, names)
+	}
+}
+
+func TestServersService_CrawlByUpdateWindow_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleUpdatedServers(t, mux)
+
+	stop := errors.New("checkpoint store unavailable")
+	opts := &UpdateWindowCrawlOptions{From: windowsStart, To: windowsStart.AddDate(0, 0, 10), Window: 24 * time.Hour}
+	err := client.Servers.CrawlByUpdateWindow(context.Background(), opts, func(UpdateWindowBatch) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("CrawlByUpdateWindow error = %v, want %v", err, stop)
+	}
+
+	invalid := []*UpdateWindowCrawlOptions{
+		{From: windowsStart},
+		{Window: time.Hour},
+		{From: windowsStart.AddDate(-300, 0, 0), To: windowsStart, Window: time.Hour},
+	}
+	for _, opts := range invalid {
+		if err := client.Servers.CrawlByUpdateWindow(context.Background(), opts, nil); err == nil {
+			t.Errorf("CrawlByUpdateWindow(%+v) returned nil error", opts)
+		}
+	}
+}
+
+func TestServersService_CrawlByUpdateWindow_TinyWindow(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	handleUpdatedServers(t, mux)
+
+	// A nanosecond window over 200 years would be 6e18 windows if every
+	// window were allocated; only those with servers are.
+	var got []UpdateWindow
+	opts := &UpdateWindowCrawlOptions{
+		From:   windowsStart.AddDate(0, 0, 29),
+		To:     windowsStart.AddDate(200, 0, 0),
+		Window: time.Nanosecond,
+	}
+	err := client.Servers.CrawlByUpdateWindow(context.Background(), opts, func(batch UpdateWindowBatch) error {
+		for _, w := range batch.Windows {
+			got = append(got, w.Window)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("CrawlByUpdateWindow returned error: %v", err)
+	}
+
+	var want []UpdateWindow
+	for _, day := range []int{30, 31} {
+		updated := time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC)
+		want = append(want, UpdateWindow{From: updated, To: updated.Add(time.Nanosecond)})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("crawled windows = %v, want %v", got, want)
+	}
+}